@@ -0,0 +1,245 @@
+package ics
+
+import (
+	"sort"
+	"time"
+)
+
+type availabilityRange struct {
+	start, end time.Time
+	priority   int
+	busy       ParamFreeBusyType
+	free       []instanceDate
+}
+
+// EffectiveAvailability returns the free and busy periods, within the given
+// time range, described by the Availability components of the Calendar.
+//
+// Time covered by an Availability component is busy, according to its
+// BusyType, except for the instances of its Available components, which are
+// free. Where components overlap, the one with the highest priority takes
+// precedence, with an undefined priority being the lowest. Where components of
+// the same priority overlap, time is free if any of them mark it as such.
+//
+// The returned periods are in UTC, sorted, and do not overlap. Time not covered
+// by any Availability component is omitted.
+func (c *Calendar) EffectiveAvailability(start, end time.Time) []PropFreeBusy {
+	var (
+		ranges []availabilityRange
+		bounds = []time.Time{start, end}
+	)
+
+	for n := range c.Availability {
+		r, ok := c.Availability[n].availabilityRange(start, end)
+		if !ok {
+			continue
+		}
+
+		bounds = append(bounds, r.start, r.end)
+
+		for _, f := range r.free {
+			bounds = append(bounds, f.start, f.end)
+		}
+
+		ranges = append(ranges, r)
+	}
+
+	sort.Slice(bounds, func(i, j int) bool {
+		return bounds[i].Before(bounds[j])
+	})
+
+	var periods []PropFreeBusy
+
+	for n := 1; n < len(bounds); n++ {
+		from, to := bounds[n-1], bounds[n]
+		if !from.Before(to) {
+			continue
+		}
+
+		typ, ok := availabilityAt(ranges, from)
+		if !ok {
+			continue
+		}
+
+		periods = appendFreeBusy(periods, from, to, typ)
+	}
+
+	return periods
+}
+
+func availabilityAt(ranges []availabilityRange, t time.Time) (ParamFreeBusyType, bool) {
+	var (
+		typ      ParamFreeBusyType
+		priority = -1
+	)
+
+	for _, r := range ranges {
+		if t.Before(r.start) || !t.Before(r.end) || priority != -1 && r.priority > priority {
+			continue
+		}
+
+		if r.priority < priority || priority == -1 {
+			priority = r.priority
+			typ = r.busy
+		}
+
+		for _, f := range r.free {
+			if !t.Before(f.start) && t.Before(f.end) {
+				typ = FreeBusyTypeFree
+
+				break
+			}
+		}
+	}
+
+	return typ, priority != -1
+}
+
+// availabilityRange returns the part of the Availability within the given
+// range, along with the free periods within it.
+func (a *Availability) availabilityRange(start, end time.Time) (availabilityRange, bool) {
+	r := availabilityRange{
+		start:    start,
+		end:      end,
+		priority: 10,
+		busy:     FreeBusyTypeBusyUnavailable,
+	}
+
+	if a.DateTimeStart != nil {
+		s, date := startTime(a.DateTimeStart.DateTime, a.DateTimeStart.Date)
+
+		if s.After(r.start) {
+			r.start = s
+		}
+
+		var e time.Time
+
+		if a.DateTimeEnd != nil {
+			e, _ = startTime(a.DateTimeEnd.DateTime, a.DateTimeEnd.Date)
+		} else if a.Duration != nil {
+			e = endFunc(s, date, time.Time{}, a.Duration)(s)
+		}
+
+		if !e.IsZero() && e.Before(r.end) {
+			r.end = e
+		}
+	}
+
+	if !r.start.Before(r.end) {
+		return r, false
+	}
+
+	if a.Priority != nil && *a.Priority > 0 {
+		r.priority = int(*a.Priority)
+	}
+
+	if a.BusyType != nil {
+		switch *a.BusyType {
+		case BusyTypeBusy:
+			r.busy = FreeBusyTypeBusy
+		case BusyTypeBusyTentative:
+			r.busy = FreeBusyTypeBusyTentative
+		}
+	}
+
+	for _, s := range a.availableSeries() {
+		it := s.iterator()
+
+		for {
+			o, _, ok := it.next()
+			if !ok || !o.RecurrenceID.Before(r.end) && !o.Start.Before(r.end) {
+				break
+			}
+
+			if o.Start.Before(r.end) && o.End.After(r.start) {
+				f := instanceDate{start: o.Start, end: o.End}
+
+				if f.start.Before(r.start) {
+					f.start = r.start
+				}
+
+				if f.end.After(r.end) {
+					f.end = r.end
+				}
+
+				r.free = append(r.free, f)
+			}
+		}
+	}
+
+	return r, true
+}
+
+type availableSeries struct {
+	master    *Available
+	overrides []*Available
+}
+
+// availableSeries groups the Available components by UID.
+func (a *Availability) availableSeries() []availableSeries {
+	var (
+		series []availableSeries
+		uids   = make(map[PropUID]int)
+	)
+
+	for n := range a.Available {
+		av := &a.Available[n]
+		pos, ok := uids[av.UID]
+
+		if !ok || av.RecurrenceID == nil && series[pos].master != nil {
+			pos = len(series)
+			uids[av.UID] = pos
+			series = append(series, availableSeries{})
+		}
+
+		if av.RecurrenceID == nil {
+			series[pos].master = av
+		} else {
+			series[pos].overrides = append(series[pos].overrides, av)
+		}
+	}
+
+	return series
+}
+
+func (s *availableSeries) iterator() *occurrenceIterator {
+	var (
+		r   *recurrence
+		ids = make([]*PropRecurrenceID, len(s.overrides))
+		rs  = make([]*recurrence, len(s.overrides))
+	)
+
+	if s.master != nil {
+		r = s.master.recurrence()
+	}
+
+	for n, o := range s.overrides {
+		ids[n] = o.RecurrenceID
+		rs[n] = o.recurrence()
+	}
+
+	return newSeriesIterator(r, ids, rs)
+}
+
+func (a *Available) recurrence() *recurrence {
+	var end time.Time
+
+	start, date := startTime(a.DateTimeStart.DateTime, a.DateTimeStart.Date)
+
+	if a.DateTimeEnd != nil {
+		end, _ = startTime(a.DateTimeEnd.DateTime, a.DateTimeEnd.Date)
+	}
+
+	r := &recurrence{
+		start:   start,
+		end:     endFunc(start, date, end, a.Duration),
+		rdates:  a.RecurrenceDateTimes,
+		exdates: a.ExceptionDateTime,
+	}
+
+	if a.RecurrenceRule != nil {
+		r.rule = (*Recur)(a.RecurrenceRule)
+	}
+
+	return r
+}
@@ -0,0 +1,100 @@
+package ics
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEffectiveAvailability(t *testing.T) {
+	const input = "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:TEST\r\n" +
+		"BEGIN:VAVAILABILITY\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID:office@example.com\r\n" +
+		"BUSYTYPE:BUSY\r\n" +
+		"DTSTART:20200106T000000Z\r\n" +
+		"DTEND:20200111T000000Z\r\n" +
+		"BEGIN:AVAILABLE\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"DTSTART:20200106T090000Z\r\n" +
+		"UID:hours@example.com\r\n" +
+		"RRULE:FREQ=DAILY;COUNT=5\r\n" +
+		"DTEND:20200106T170000Z\r\n" +
+		"EXDATE:20200108T090000Z\r\n" +
+		"END:AVAILABLE\r\n" +
+		"BEGIN:AVAILABLE\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"DTSTART:20200109T120000Z\r\n" +
+		"UID:hours@example.com\r\n" +
+		"RECURRENCE-ID:20200109T090000Z\r\n" +
+		"DTEND:20200109T170000Z\r\n" +
+		"END:AVAILABLE\r\n" +
+		"END:VAVAILABILITY\r\n" +
+		"BEGIN:VAVAILABILITY\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID:meeting@example.com\r\n" +
+		"DTSTART:20200107T120000Z\r\n" +
+		"PRIORITY:1\r\n" +
+		"DURATION:PT2H\r\n" +
+		"END:VAVAILABILITY\r\n" +
+		"END:VCALENDAR\r\n"
+
+	cal, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	}
+
+	var buf bytes.Buffer
+
+	if err := Encode(&buf, cal); err != nil {
+		t.Fatalf("unexpected error encoding calendar: %s", err)
+	} else if output := buf.String(); output != input {
+		t.Errorf("expecting output:\n%s\ngot:\n%s", input, output)
+	}
+
+	date := func(day, hour int) time.Time {
+		return time.Date(2020, 1, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []typedPeriod{
+		{date(6, 0), date(6, 9), FreeBusyTypeBusy},
+		{date(6, 9), date(6, 17), FreeBusyTypeFree},
+		{date(6, 17), date(7, 9), FreeBusyTypeBusy},
+		{date(7, 9), date(7, 12), FreeBusyTypeFree},
+		{date(7, 12), date(7, 14), FreeBusyTypeBusyUnavailable},
+		{date(7, 14), date(7, 17), FreeBusyTypeFree},
+		{date(7, 17), date(9, 12), FreeBusyTypeBusy},
+		{date(9, 12), date(9, 17), FreeBusyTypeFree},
+		{date(9, 17), date(10, 0), FreeBusyTypeBusy},
+	}
+
+	periods := flattenFreeBusy(cal.EffectiveAvailability(date(5, 0), date(10, 0)))
+
+	if len(periods) != len(tests) {
+		t.Fatalf("expecting %d periods, got %d: %v", len(tests), len(periods), periods)
+	}
+
+	for n, test := range tests {
+		if p := periods[n]; !p.Start.Equal(test.Start) || !p.End.Equal(test.End) {
+			t.Errorf("test %d: expecting period %s-%s, got %s-%s", n+1, test.Start, test.End, p.Start, p.End)
+		} else if p.Type != test.Type {
+			t.Errorf("test %d: expecting type %d, got %d", n+1, test.Type, p.Type)
+		}
+	}
+
+	if _, err := Decode(strings.NewReader("BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:TEST\r\n" +
+		"BEGIN:VAVAILABILITY\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID:office@example.com\r\n" +
+		"DTEND:20200111T000000Z\r\n" +
+		"END:VAVAILABILITY\r\n" +
+		"END:VCALENDAR\r\n")); !errors.Is(err, ErrRequirementNotMet) {
+		t.Errorf("expecting error ErrRequirementNotMet, got %v", err)
+	}
+}
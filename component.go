@@ -0,0 +1,87 @@
+package ics
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// Component is a component not otherwise handled by this package, such as an
+// X- component, retained so that it can be re-encoded.
+type Component struct {
+	Name       string
+	Properties ExtensionProperties
+	Components []Component
+}
+
+func (c *Component) decode(t tokeniser, name string) error {
+	c.Name = name
+
+	for {
+		p, err := t.GetPhrase()
+		if err != nil {
+			return err
+		} else if p.Type == parser.PhraseDone {
+			return io.ErrUnexpectedEOF
+		}
+
+		params := p.Data[1 : len(p.Data)-1]
+		value := p.Data[len(p.Data)-1].Data
+
+		switch strings.ToUpper(p.Data[0].Data) {
+		case "BEGIN":
+			var e Component
+
+			if err := e.decode(t, strings.ToUpper(value)); err != nil {
+				return err
+			}
+
+			c.Components = append(c.Components, e)
+		case "END":
+			if strings.ToUpper(value) != name {
+				return ErrInvalidEnd
+			}
+
+			return nil
+		default:
+			checkProperty(t, p.Data[0].Data, params, value)
+
+			c.Properties = append(c.Properties, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
+		}
+	}
+}
+
+func (c *Component) encode(w writer) {
+	w.WriteString("BEGIN:")
+	w.WriteString(c.Name)
+	w.WriteString("\r\n")
+	c.Properties.encode(w)
+
+	for n := range c.Components {
+		c.Components[n].encode(w)
+	}
+
+	w.WriteString("END:")
+	w.WriteString(c.Name)
+	w.WriteString("\r\n")
+}
+
+func (c *Component) valid() error {
+	if !validName(c.Name) {
+		return ErrInvalidExtensionName
+	}
+
+	if err := c.Properties.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, c.Name, err)
+	}
+
+	for n := range c.Components {
+		if err := c.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, c.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package ics
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComponents(t *testing.T) {
+	const input = "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:TEST\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID:event@example.com\r\n" +
+		"DTSTART:20200106T100000Z\r\n" +
+		"BEGIN:VALARM\r\n" +
+		"ACTION:DISPLAY\r\n" +
+		"DESCRIPTION:Reminder\r\n" +
+		"TRIGGER:-PT15M\r\n" +
+		"BEGIN:X-SNOOZE\r\n" +
+		"ACTION:NONE\r\n" +
+		"END:X-SNOOZE\r\n" +
+		"END:VALARM\r\n" +
+		"BEGIN:X-ROOM\r\n" +
+		"NAME;X-FLOOR=2:Conference Room\r\n" +
+		"END:X-ROOM\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VPOLL\r\n" +
+		"UID:poll@example.com\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:choice@example.com\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:X-EMPTY\r\n" +
+		"END:X-EMPTY\r\n" +
+		"END:VPOLL\r\n" +
+		"END:VCALENDAR\r\n"
+
+	cal, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	}
+
+	if expected := []Component{
+		{
+			Name:       "VPOLL",
+			Properties: ExtensionProperties{{Name: "UID", Value: "poll@example.com"}},
+			Components: []Component{
+				{
+					Name:       "VEVENT",
+					Properties: ExtensionProperties{{Name: "UID", Value: "choice@example.com"}},
+				},
+				{
+					Name: "X-EMPTY",
+				},
+			},
+		},
+	}; !reflect.DeepEqual(cal.Components, expected) {
+		t.Errorf("expecting calendar components %v, got %v", expected, cal.Components)
+	}
+
+	if expected := []Component{
+		{
+			Name:       "X-ROOM",
+			Properties: ExtensionProperties{{Name: "NAME", Params: ExtensionParams{{Name: "X-FLOOR", Values: []string{"2"}}}, Value: "Conference Room"}},
+		},
+	}; !reflect.DeepEqual(cal.Event[0].Components, expected) {
+		t.Errorf("expecting event components %v, got %v", expected, cal.Event[0].Components)
+	}
+
+	if a, ok := cal.Event[0].Alarm[0].AlarmType.(*AlarmDisplay); !ok {
+		t.Errorf("expecting display alarm, got %T", cal.Event[0].Alarm[0].AlarmType)
+	} else if expected := []Component{
+		{
+			Name:       "X-SNOOZE",
+			Properties: ExtensionProperties{{Name: "ACTION", Value: "NONE"}},
+		},
+	}; !reflect.DeepEqual(a.Components, expected) {
+		t.Errorf("expecting alarm components %v, got %v", expected, a.Components)
+	}
+
+	var buf bytes.Buffer
+
+	if err := Encode(&buf, cal); err != nil {
+		t.Fatalf("unexpected error encoding calendar: %s", err)
+	} else if output := buf.String(); output != input {
+		t.Errorf("expecting output:\n%s\ngot:\n%s", input, output)
+	}
+
+	cal.Components[0].Components[1].Name = "X EMPTY"
+
+	if err := Encode(&buf, cal); !errors.Is(err, ErrInvalidExtensionName) {
+		t.Errorf("expecting error ErrInvalidExtensionName, got %v", err)
+	}
+
+	if _, err := Decode(strings.NewReader("BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:TEST\r\n" +
+		"BEGIN:X-A\r\n" +
+		"END:X-B\r\n" +
+		"END:VCALENDAR\r\n")); !errors.Is(err, ErrInvalidEnd) {
+		t.Errorf("expecting error ErrInvalidEnd, got %v", err)
+	}
+}
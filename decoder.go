@@ -2,8 +2,12 @@
 package ics // import "vimagination.zapto.org/ics"
 
 import (
+	"fmt"
 	"io"
+	"strconv"
 	"strings"
+
+	"vimagination.zapto.org/parser"
 )
 
 type section interface {
@@ -12,26 +16,331 @@ type section interface {
 	valid() error
 }
 
+// DecodeOptions modify the behaviour of DecodeWithOptions and
+// NewDecoderWithOptions.
+type DecodeOptions struct {
+	// Lenient recovers from common problems produced by other software,
+	// recording each as a Warning instead of failing. These include line
+	// endings without a carriage return, lines folded with a tab, duplicate
+	// properties, properties with invalid values, the case of END values,
+	// and unknown alarm actions. Duplicates and invalid properties are
+	// discarded, and unknown alarms are decoded as AlarmUnknown.
+	Lenient bool
+}
+
 // Decode decodes an iCalendar object from the given reader.
 func Decode(r io.Reader) (*Calendar, error) {
-	t := newTokeniser(&unfolder{r: r})
+	cal, _, err := DecodeWithOptions(r, DecodeOptions{})
+
+	return cal, err
+}
+
+// DecodeWithOptions acts as Decode, but with the given options applied, also
+// returning any warnings produced while decoding.
+func DecodeWithOptions(r io.Reader, opts DecodeOptions) (*Calendar, []*Warning, error) {
+	t := newLineTokeniser(r, opts)
+
+	if err := beginCalendar(t); err != nil {
+		return nil, nil, t.error(err)
+	}
+
+	cal := new(Calendar)
 
+	if err := cal.decode(t); err != nil {
+		return nil, nil, t.error(err)
+	}
+
+	fallbacks, err := cal.resolveTimezones()
+	if err != nil {
+		return nil, nil, t.timezoneError(err)
+	}
+
+	for _, name := range fallbacks {
+		t.warnTimezone(name, fmt.Errorf("timezone %q: %w", name, ErrTimezoneFallback))
+	}
+
+	return cal, t.sortedWarnings(), nil
+}
+
+func beginCalendar(t *lineTokeniser) error {
 	if p, err := t.GetPhrase(); err != nil {
-		return nil, err
+		return err
 	} else if p.Type != phraseContentLine {
 		if t.Err == io.EOF {
-			return nil, io.ErrUnexpectedEOF
+			return io.ErrUnexpectedEOF
 		}
-		return nil, t.Err
+		return t.Err
 	} else if strings.ToUpper(p.Data[0].Data) != "BEGIN" || strings.ToUpper(p.Data[len(p.Data)-1].Data) != "VCALENDAR" {
-		return nil, ErrInvalidCalendar
+		return ErrInvalidCalendar
 	}
 
-	cal := new(Calendar)
+	return nil
+}
 
-	if err := cal.decode(t); err != nil {
+// DecodeError is returned when decoding fails, recording where in the input the
+// failure occurred.
+type DecodeError struct {
+	// Line is the physical line, before unfolding, on which the failing
+	// content line starts.
+	Line int
+
+	// Path lists the components containing the failing content line, with
+	// the index of each among its siblings of the same name, e.g.
+	// ["VCALENDAR", "VEVENT[3]", "VALARM[0]"].
+	Path []string
+
+	// Property is the name of the failing property, if any.
+	Property string
+
+	// Raw is the unfolded content line.
+	Raw string
+
+	// Err is the underlying error, usually one of the Err* sentinels.
+	Err error
+}
+
+func (d *DecodeError) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString("line ")
+	sb.WriteString(strconv.Itoa(d.Line))
+
+	if len(d.Path) > 0 {
+		sb.WriteString(": ")
+		sb.WriteString(strings.Join(d.Path, " > "))
+	}
+
+	if d.Property != "" {
+		sb.WriteString(": ")
+		sb.WriteString(d.Property)
+	}
+
+	sb.WriteString(": ")
+	sb.WriteString(d.Err.Error())
+
+	return sb.String()
+}
+
+// Unwrap returns the underlying error.
+func (d *DecodeError) Unwrap() error {
+	return d.Err
+}
+
+// Warning is a problem found while decoding that did not stop the decoding,
+// such as the use of a deprecated property or one that was recovered from
+// while decoding leniently.
+type Warning DecodeError
+
+func (w *Warning) String() string {
+	return (*DecodeError)(w).Error()
+}
+
+// Decoder reads an iCalendar object from a stream, one top-level component at
+// a time.
+type Decoder struct {
+	t        *lineTokeniser
+	cal      *Calendar
+	resolver timezoneResolver
+	begin    string
+	err      error
+}
+
+// NewDecoder creates a Decoder that reads from the given reader.
+func NewDecoder(r io.Reader) *Decoder {
+	return NewDecoderWithOptions(r, DecodeOptions{})
+}
+
+// NewDecoderWithOptions acts as NewDecoder, but with the given options
+// applied.
+func NewDecoderWithOptions(r io.Reader, opts DecodeOptions) *Decoder {
+	return &Decoder{
+		t:        newLineTokeniser(r, opts),
+		resolver: newTimezoneResolver(),
+	}
+}
+
+// Warnings returns the warnings produced so far while decoding.
+func (d *Decoder) Warnings() []*Warning {
+	return d.t.sortedWarnings()
+}
+
+// Calendar returns the Calendar properties, which are read from the stream on
+// the first call to either Calendar or Next.
+//
+// The returned Calendar contains no components. Any Calendar properties that
+// follow the components in the stream are added to its Extensions as they are
+// read.
+func (d *Decoder) Calendar() (*Calendar, error) {
+	if d.cal == nil && d.err == nil {
+		d.err = d.t.error(d.header())
+	}
+
+	if d.cal == nil {
+		return nil, d.err
+	}
+
+	return d.cal, nil
+}
+
+func (d *Decoder) header() error {
+	if err := beginCalendar(d.t); err != nil {
+		return err
+	}
+
+	var (
+		pt  psuedoTokeniser
+		pos []position
+		end bool
+	)
+
+	for {
+		p, err := d.t.GetPhrase()
+		if err != nil {
+			return fmt.Errorf(errDecodingType, cCalendar, err)
+		} else if p.Type == parser.PhraseDone {
+			return fmt.Errorf(errDecodingType, cCalendar, io.ErrUnexpectedEOF)
+		}
+
+		switch strings.ToUpper(p.Data[0].Data) {
+		case "BEGIN":
+			d.begin = strings.ToUpper(p.Data[len(p.Data)-1].Data)
+		case "END":
+			end = true
+		default:
+			pt = append(pt, p)
+			pos = append(pos, d.t.pos)
+
+			continue
+		}
+
+		pt = append(pt, parser.Phrase{
+			Type: phraseContentLine,
+			Data: []parser.Token{
+				{Type: tokenName, Data: "END"},
+				{Type: tokenValue, Data: "VCALENDAR"},
+			},
+		})
+		pos = append(pos, position{line: d.t.pos.line, phrase: pt[len(pt)-1], path: d.t.pos.path[:1]})
+
+		cal := new(Calendar)
+
+		if err := d.t.replay(pt, pos, cal.decode); err != nil {
+			return err
+		}
+
+		d.cal = cal
+
+		if end {
+			return io.EOF
+		}
+
+		return nil
+	}
+}
+
+// Next returns the next top-level component from the stream, which will be one
+// of *Event, *Todo, *Journal, *FreeBusy, *Timezone, *Availability, or
+// *Component for those not otherwise handled by this package.
+//
+// Times are resolved against the Timezone components that have already been
+// read, so a TZID defined by a later Timezone will result in an error unless it
+// is a known IANA timezone.
+//
+// Returns io.EOF once the end of the Calendar has been reached.
+func (d *Decoder) Next() (interface{}, error) {
+	if _, err := d.Calendar(); err != nil {
 		return nil, err
+	} else if d.err != nil {
+		return nil, d.err
+	}
+
+	c, err := d.next()
+	if err != nil {
+		d.err = d.t.error(err)
+
+		return nil, d.err
+	}
+
+	return c, nil
+}
+
+func (d *Decoder) next() (interface{}, error) {
+	for d.begin == "" {
+		p, err := d.t.GetPhrase()
+		if err != nil {
+			return nil, fmt.Errorf(errDecodingType, cCalendar, err)
+		} else if p.Type == parser.PhraseDone {
+			return nil, fmt.Errorf(errDecodingType, cCalendar, io.ErrUnexpectedEOF)
+		}
+
+		params := p.Data[1 : len(p.Data)-1]
+		value := p.Data[len(p.Data)-1].Data
+
+		switch strings.ToUpper(p.Data[0].Data) {
+		case "BEGIN":
+			d.begin = strings.ToUpper(value)
+		case "END":
+			if err := endValue(d.t, value, "VCALENDAR"); err != nil {
+				return nil, fmt.Errorf(errDecodingType, cCalendar, err)
+			}
+
+			return nil, io.EOF
+		default:
+			checkProperty(d.t, p.Data[0].Data, params, value)
+
+			d.cal.Extensions = append(d.cal.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
+		}
+	}
+
+	var (
+		c    section
+		name string
+	)
+
+	switch d.begin {
+	case "VEVENT":
+		c, name = new(Event), cEvent
+	case "VTODO":
+		c, name = new(Todo), cTodo
+	case "VJOURNAL":
+		c, name = new(Journal), cJournal
+	case "VFREEBUSY":
+		c, name = new(FreeBusy), cFreeBusy
+	case "VTIMEZONE":
+		c, name = new(Timezone), cTimezone
+	case "VAVAILABILITY":
+		c, name = new(Availability), cAvailability
+	default:
+		var e Component
+
+		if err := e.decode(d.t, d.begin); err != nil {
+			return nil, fmt.Errorf(errDecodingType, cCalendar, err)
+		}
+
+		d.begin = ""
+
+		return &e, nil
+	}
+
+	d.begin = ""
+
+	if err := c.decode(d.t); err != nil {
+		return nil, fmt.Errorf(errDecodingProp, cCalendar, name, err)
+	}
+
+	if tz, ok := c.(*Timezone); ok {
+		if err := d.resolver.add(tz); err != nil {
+			return nil, d.t.timezoneError(err)
+		}
+	}
+
+	if err := d.resolver.resolve(c); err != nil {
+		return nil, d.t.timezoneError(err)
+	}
+
+	for _, name := range d.resolver.fallbacks() {
+		d.t.warnTimezone(name, fmt.Errorf("timezone %q: %w", name, ErrTimezoneFallback))
 	}
 
-	return cal, nil
+	return c, nil
 }
@@ -2,10 +2,12 @@ package ics
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -90,7 +92,42 @@ func indent(w io.Writer, indentLevel int) {
 }
 
 func TestDecode(t *testing.T) {
-	tzny, err := time.LoadLocation("America/New_York")
+	vtimezone := Timezone{
+		TimezoneID: "America/New_York",
+		Standard: []Standard{
+			{
+				DateTimeStart: PropDateTimeStart{
+					DateTime: &DateTime{
+						Time: time.Date(1998, 10, 25, 2, 0, 0, 0, time.Local),
+					},
+				},
+				TimezoneOffsetFrom: -4 * 3600,
+				TimezoneOffsetTo:   -5 * 3600,
+				TimezoneName: []PropTimezoneName{
+					{
+						Text: "EST",
+					},
+				},
+			},
+		},
+		Daylight: []Daylight{
+			{
+				DateTimeStart: PropDateTimeStart{
+					DateTime: &DateTime{
+						Time: time.Date(1999, 4, 4, 2, 0, 0, 0, time.Local),
+					},
+				},
+				TimezoneOffsetFrom: -5 * 3600,
+				TimezoneOffsetTo:   -4 * 3600,
+				TimezoneName: []PropTimezoneName{
+					{
+						Text: "EDT",
+					},
+				},
+			},
+		},
+	}
+	tzny, err := vtimezone.Location()
 	if err != nil {
 		t.Errorf("unexpected error: %s", err)
 		return
@@ -264,43 +301,7 @@ func TestDecode(t *testing.T) {
 						},
 					},
 				},
-				Timezone: []Timezone{
-					{
-						TimezoneID: "America/New_York",
-						Standard: []Standard{
-							{
-								DateTimeStart: PropDateTimeStart{
-									DateTime: &DateTime{
-										Time: time.Date(1998, 10, 25, 2, 0, 0, 0, time.Local),
-									},
-								},
-								TimezoneOffsetFrom: -4 * 3600,
-								TimezoneOffsetTo:   -5 * 3600,
-								TimezoneName: []PropTimezoneName{
-									{
-										Text: "EST",
-									},
-								},
-							},
-						},
-						Daylight: []Daylight{
-							{
-								DateTimeStart: PropDateTimeStart{
-									DateTime: &DateTime{
-										Time: time.Date(1999, 4, 4, 2, 0, 0, 0, time.Local),
-									},
-								},
-								TimezoneOffsetFrom: -5 * 3600,
-								TimezoneOffsetTo:   -4 * 3600,
-								TimezoneName: []PropTimezoneName{
-									{
-										Text: "EDT",
-									},
-								},
-							},
-						},
-					},
-				},
+				Timezone: []Timezone{vtimezone},
 			},
 		},
 		{
@@ -510,34 +511,34 @@ func TestDecode(t *testing.T) {
 						},
 						FreeBusy: []PropFreeBusy{
 							{
-								Period: Period{
+								MPeriod: MPeriod{{
 									Start: DateTime{
 										Time: time.Date(1998, 3, 14, 23, 30, 0, 0, time.UTC),
 									},
 									End: DateTime{
 										Time: time.Date(1998, 3, 15, 0, 30, 0, 0, time.UTC),
 									},
-								},
+								}},
 							},
 							{
-								Period: Period{
+								MPeriod: MPeriod{{
 									Start: DateTime{
 										Time: time.Date(1998, 3, 16, 15, 30, 0, 0, time.UTC),
 									},
 									End: DateTime{
 										Time: time.Date(1998, 3, 16, 16, 30, 0, 0, time.UTC),
 									},
-								},
+								}},
 							},
 							{
-								Period: Period{
+								MPeriod: MPeriod{{
 									Start: DateTime{
 										Time: time.Date(1998, 3, 18, 3, 0, 0, 0, time.UTC),
 									},
 									End: DateTime{
 										Time: time.Date(1998, 3, 18, 4, 0, 0, 0, time.UTC),
 									},
-								},
+								}},
 							},
 						},
 						URL: &PropURL{
@@ -555,10 +556,563 @@ func TestDecode(t *testing.T) {
 
 	for n, test := range tests {
 		c, err := Decode(strings.NewReader(test.Input))
-		if err != test.Error {
+		if !errors.Is(err, test.Error) {
 			t.Errorf("test %d: expecting error %q, got %q", n+1, test.Error, err)
 		} else if !reflect.DeepEqual(c, test.Output) {
 			t.Errorf("test %d: expecting calendar %s, got %s", n+1, test.Output, c)
 		}
 	}
 }
+
+func TestDecoder(t *testing.T) {
+	d := NewDecoder(strings.NewReader("BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:TEST\r\n" +
+		"X-WR-CALNAME:Rooms\r\n" +
+		"BEGIN:VTIMEZONE\r\n" +
+		"TZID:Custom\r\n" +
+		"BEGIN:STANDARD\r\n" +
+		"DTSTART:19700101T000000\r\n" +
+		"TZOFFSETFROM:+0300\r\n" +
+		"TZOFFSETTO:+0300\r\n" +
+		"END:STANDARD\r\n" +
+		"END:VTIMEZONE\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID:event@example.com\r\n" +
+		"DTSTART;TZID=Custom:20200106T100000\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:X-OTHER\r\n" +
+		"END:X-OTHER\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID:todo@example.com\r\n" +
+		"END:VTODO\r\n" +
+		"X-TRAILING:value\r\n" +
+		"END:VCALENDAR\r\n"))
+
+	cal, err := d.Calendar()
+	if err != nil {
+		t.Fatalf("unexpected error reading calendar: %s", err)
+	} else if cal.ProductID != "TEST" {
+		t.Errorf("expecting PRODID TEST, got %q", cal.ProductID)
+	} else if p, _ := cal.Extensions.Get("X-WR-CALNAME"); p.Value != "Rooms" {
+		t.Errorf("expecting X-WR-CALNAME Rooms, got %q", p.Value)
+	}
+
+	c, err := d.Next()
+	if tz, ok := c.(*Timezone); err != nil || !ok || tz.TimezoneID != "Custom" {
+		t.Fatalf("expecting Timezone, got %v, %v", c, err)
+	}
+
+	c, err = d.Next()
+	if ev, ok := c.(*Event); err != nil || !ok {
+		t.Fatalf("expecting Event, got %v, %v", c, err)
+	} else if start := ev.DateTimeStart.DateTime.Time; !start.Equal(time.Date(2020, 1, 6, 7, 0, 0, 0, time.UTC)) {
+		t.Errorf("expecting start of 2020-01-06 07:00 UTC, got %s", start)
+	}
+
+	c, err = d.Next()
+	if co, ok := c.(*Component); err != nil || !ok || co.Name != "X-OTHER" {
+		t.Fatalf("expecting Component, got %v, %v", c, err)
+	}
+
+	c, err = d.Next()
+	if td, ok := c.(*Todo); err != nil || !ok || td.UID != "todo@example.com" {
+		t.Fatalf("expecting Todo, got %v, %v", c, err)
+	}
+
+	if c, err = d.Next(); err != io.EOF {
+		t.Fatalf("expecting io.EOF, got %v, %v", c, err)
+	} else if p, _ := cal.Extensions.Get("X-TRAILING"); p.Value != "value" {
+		t.Errorf("expecting X-TRAILING value, got %q", p.Value)
+	} else if _, err = d.Next(); err != io.EOF {
+		t.Errorf("expecting repeated io.EOF, got %v", err)
+	}
+
+	d = NewDecoder(strings.NewReader("BEGIN:VCALENDAR\r\n" +
+		"PRODID:TEST\r\n" +
+		"BEGIN:VEVENT\r\n"))
+
+	if _, err := d.Next(); !errors.Is(err, ErrMissingRequired) {
+		t.Errorf("expecting error ErrMissingRequired, got %v", err)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	const header = "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:TEST\r\n"
+
+	const event = "BEGIN:VEVENT\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID:event@example.com\r\n"
+
+	tests := []struct {
+		Input string
+		Error DecodeError
+	}{
+		{
+			Input: "BEGIN:VEVENT\r\n",
+			Error: DecodeError{
+				Line: 1,
+				Path: []string{"VEVENT"},
+				Raw:  "BEGIN:VEVENT",
+				Err:  ErrInvalidCalendar,
+			},
+		},
+		{
+			Input: header +
+				"DESCRIPTION:A long\r\n" +
+				"  description\r\n" +
+				event +
+				"END:VEVENT\r\n" +
+				event +
+				"DTSTART:20200101T000000Z\r\n" +
+				"DTSTART;X-A=\"b\",c:2020\r\n" +
+				" 0101T000000Z\r\n",
+			Error: DecodeError{
+				Line:     14,
+				Path:     []string{"VCALENDAR", "VEVENT[1]"},
+				Property: "DTSTART",
+				Raw:      "DTSTART;X-A=\"b\",c:20200101T000000Z",
+				Err:      ErrDuplicateProperty,
+			},
+		},
+		{
+			Input: header + event +
+				"BEGIN:VALARM\r\n" +
+				"ACTION:DISPLAY\r\n" +
+				"TRIGGER:BAD\r\n" +
+				"DESCRIPTION:Reminder\r\n" +
+				"END:VALARM\r\n",
+			Error: DecodeError{
+				Line:     9,
+				Path:     []string{"VCALENDAR", "VEVENT[0]", "VALARM[0]"},
+				Property: "TRIGGER",
+				Raw:      "TRIGGER:BAD",
+				Err:      ErrInvalidDuration,
+			},
+		},
+		{
+			Input: header +
+				"BEGIN:VEVENT\r\n" +
+				"UID:event@example.com\r\n" +
+				"END:VEVENT\r\n",
+			Error: DecodeError{
+				Line: 6,
+				Path: []string{"VCALENDAR", "VEVENT[0]"},
+				Raw:  "END:VEVENT",
+				Err:  ErrMissingRequired,
+			},
+		},
+		{
+			Input: header + event +
+				"DTSTART;TZID=Unknown:20200106T100000\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+			Error: DecodeError{
+				Line:     7,
+				Path:     []string{"VCALENDAR", "VEVENT[0]"},
+				Property: "DTSTART",
+				Raw:      "DTSTART;TZID=Unknown:20200106T100000",
+				Err:      ErrUnknownTimezone,
+			},
+		},
+	}
+
+	for n, test := range tests {
+		for m, decode := range []func(string) error{
+			func(input string) error {
+				_, err := Decode(strings.NewReader(input))
+
+				return err
+			},
+			func(input string) error {
+				d := NewDecoder(strings.NewReader(input))
+
+				for {
+					if _, err := d.Next(); err != nil {
+						return err
+					}
+				}
+			},
+		} {
+			var de *DecodeError
+
+			if err := decode(test.Input); !errors.As(err, &de) {
+				t.Errorf("test %d.%d: expecting DecodeError, got %v", n+1, m+1, err)
+			} else if !reflect.DeepEqual(*de, test.Error) {
+				t.Errorf("test %d.%d: expecting error %#v, got %#v", n+1, m+1, test.Error, *de)
+			} else if !errors.Is(err, test.Error.Err) {
+				t.Errorf("test %d.%d: expecting error to wrap %s", n+1, m+1, test.Error.Err)
+			}
+		}
+	}
+}
+
+func TestDecodeLenient(t *testing.T) {
+	const input = "BEGIN:VCALENDAR\n" +
+		"VERSION:2.0\n" +
+		"PRODID:TEST\n" +
+		"BEGIN:VEVENT\n" +
+		"DTSTAMP:20200101T000000Z\n" +
+		"UID:event@example.com\n" +
+		"SUMMARY:A long sum\n" +
+		"\tmary\n" +
+		"DTSTART:20200106T100000Z\n" +
+		"DTSTART:20200107T100000Z\n" +
+		"PRIORITY:high\n" +
+		"BEGIN:VALARM\n" +
+		"ACTION:PROCEDURE\n" +
+		"TRIGGER:-PT15M\n" +
+		"END:VALARM\n" +
+		"BEGIN:VALARM\n" +
+		"action:Display\n" +
+		"TRIGGER:-PT5M\n" +
+		"DESCRIPTION:Reminder\n" +
+		"END:VALARM\n" +
+		"END:vevent\n" +
+		"END:vcalendar\n"
+
+	if _, err := Decode(strings.NewReader(input)); !errors.Is(err, ErrInvalidContentLineValue) {
+		t.Errorf("expecting strict error ErrInvalidContentLineValue, got %v", err)
+	}
+
+	cal, warnings, err := DecodeWithOptions(strings.NewReader(input), DecodeOptions{Lenient: true})
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	}
+
+	ev := cal.Event[0]
+
+	if ev.Summary.Text != "A long summary" {
+		t.Errorf("expecting summary %q, got %q", "A long summary", ev.Summary.Text)
+	} else if start := ev.DateTimeStart.DateTime.Time; !start.Equal(time.Date(2020, 1, 6, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("expecting first DTSTART to be kept, got %s", start)
+	} else if ev.Priority != nil {
+		t.Errorf("expecting invalid PRIORITY to be discarded, got %v", ev.Priority)
+	} else if a, ok := ev.Alarm[0].AlarmType.(*AlarmUnknown); !ok {
+		t.Errorf("expecting unknown alarm to be AlarmUnknown, got %T", ev.Alarm[0].AlarmType)
+	} else if a.Action != "PROCEDURE" {
+		t.Errorf("expecting unknown alarm action %q, got %q", "PROCEDURE", a.Action)
+	} else if _, ok := ev.Alarm[1].AlarmType.(*AlarmDisplay); !ok {
+		t.Errorf("expecting lower-case alarm action to be AlarmDisplay, got %T", ev.Alarm[1].AlarmType)
+	}
+
+	var sb strings.Builder
+
+	if err := Encode(&sb, cal); err != nil {
+		t.Errorf("unexpected error encoding calendar: %s", err)
+	} else if out := sb.String(); !strings.Contains(out, "ACTION:PROCEDURE\r\n") || !strings.Contains(out, "ACTION:DISPLAY\r\n") {
+		t.Errorf("expecting alarm actions to be kept, got %q", out)
+	}
+
+	expected := []struct {
+		Line int
+		Err  error
+	}{
+		{1, ErrInvalidLineEnding},
+		{8, ErrInvalidFolding},
+		{10, ErrDuplicateProperty},
+		{11, strconv.ErrSyntax},
+		{13, ErrUnknownAlarmAction},
+		{13, ErrDeprecated},
+		{21, ErrInvalidEnd},
+		{22, ErrInvalidEnd},
+	}
+
+	if len(warnings) != len(expected) {
+		t.Fatalf("expecting %d warnings, got %d: %v", len(expected), len(warnings), warnings)
+	}
+
+	for n, w := range warnings {
+		if w.Line != expected[n].Line || !errors.Is(w.Err, expected[n].Err) {
+			t.Errorf("warning %d: expecting %s on line %d, got %s", n+1, expected[n].Err, expected[n].Line, w)
+		}
+	}
+}
+
+func TestDecodeWarnings(t *testing.T) {
+	cal, warnings, err := DecodeWithOptions(strings.NewReader("BEGIN:VCALENDAR\r\n"+
+		"VERSION:2.0\r\n"+
+		"PRODID:TEST\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		"DTSTAMP:20200101T000000Z\r\n"+
+		"UID:event@example.com\r\n"+
+		"DTSTART;TZID=America/New_York;TZID=Europe/London:20200106T100000\r\n"+
+		"SUMMARY;VALUE=DATE-TIME:Meeting\r\n"+
+		"EXRULE:FREQ=DAILY\r\n"+
+		"X-DATA;VALUE=UNKNOWN:data\r\n"+
+		"X-TIME;VALUE=DATE-TIME:20200106T100000Z\r\n"+
+		"END:VEVENT\r\n"+
+		"END:VCALENDAR\r\n"), DecodeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	} else if l := cal.Event[0].DateTimeStart.DateTime.Location().String(); l != "Europe/London" {
+		t.Errorf("expecting last TZID to be used, got %s", l)
+	}
+
+	expected := []struct {
+		Line int
+		Err  error
+	}{
+		{7, ErrIgnoredParam},
+		{7, ErrTimezoneFallback},
+		{8, ErrUnexpectedValueType},
+		{9, ErrDeprecated},
+		{10, ErrUnknownValueType},
+	}
+
+	if len(warnings) != len(expected) {
+		t.Fatalf("expecting %d warnings, got %d: %v", len(expected), len(warnings), warnings)
+	}
+
+	for n, w := range warnings {
+		if w.Line != expected[n].Line || !errors.Is(w.Err, expected[n].Err) {
+			t.Errorf("warning %d: expecting %s on line %d, got %s", n+1, expected[n].Err, expected[n].Line, w)
+		}
+	}
+}
+
+func TestDecodeCalendarProperties(t *testing.T) {
+	const input = "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:TEST\r\n" +
+		"CALSCALE:GREGORIAN\r\n" +
+		"METHOD:REQUEST\r\n" +
+		"UID:calendar@example.com\r\n" +
+		"LAST-MODIFIED:20200101T000000Z\r\n" +
+		"URL:https://example.com/calendar.ics\r\n" +
+		"REFRESH-INTERVAL;VALUE=DURATION:PT1H\r\n" +
+		"SOURCE:https://example.com/source.ics\r\n" +
+		"COLOR:turquoise\r\n" +
+		"NAME;LANGUAGE=en:Rooms\r\n" +
+		"NAME;LANGUAGE=fr:Salles\r\n" +
+		"DESCRIPTION:Room bookings\r\n" +
+		"CATEGORIES:WORK,ROOMS\r\n" +
+		"IMAGE;FMTTYPE=image/png;VALUE=URI:https://example.com/logo.png\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID:event@example.com\r\n" +
+		"DTSTART:20200106T100000Z\r\n" +
+		"COLOR:red\r\n" +
+		"IMAGE;DISPLAY=BADGE,THUMBNAIL,X-ICON;VALUE=URI:https://example.com/badge.pn\r\n" +
+		" g\r\n" +
+		"CONFERENCE;VALUE=URI;FEATURE=AUDIO,VIDEO;LABEL=Video call:https://example.c\r\n" +
+		" om/call\r\n" +
+		"CONFERENCE;VALUE=URI;FEATURE=PHONE,X-SIP;LABEL=\"Dial-in: ext. 1\":tel:+1-555\r\n" +
+		" -0100\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	cal, warnings, err := DecodeWithOptions(strings.NewReader(input), DecodeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	} else if len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+
+	if cal.CalendarScale == nil || *cal.CalendarScale != CalendarScaleGregorian {
+		t.Errorf("expecting CALSCALE GREGORIAN, got %v", cal.CalendarScale)
+	} else if cal.Method == nil || *cal.Method != "REQUEST" {
+		t.Errorf("expecting METHOD REQUEST, got %v", cal.Method)
+	} else if cal.RefreshInterval == nil || *cal.RefreshInterval != PropRefreshInterval(Duration{Hours: 1}) {
+		t.Errorf("expecting REFRESH-INTERVAL of 1 hour, got %v", cal.RefreshInterval)
+	} else if len(cal.Name) != 2 || cal.Name[1].Text != "Salles" || *cal.Name[1].Language != "fr" {
+		t.Errorf("unexpected NAME properties: %v", cal.Name)
+	} else if len(cal.Image) != 1 || cal.Image[0].URI == nil || cal.Image[0].URI.Host != "example.com" {
+		t.Errorf("unexpected IMAGE properties: %v", cal.Image)
+	} else if len(cal.Extensions) != 0 {
+		t.Errorf("unexpected extension properties: %v", cal.Extensions)
+	}
+
+	if e := cal.Event[0]; e.Color == nil || *e.Color != "red" {
+		t.Errorf("expecting COLOR red, got %v", e.Color)
+	} else if len(e.Image) != 1 || !reflect.DeepEqual(e.Image[0].Display, ParamDisplay{DisplayBadge, DisplayThumbnail, "X-ICON"}) {
+		t.Errorf("unexpected IMAGE properties: %v", e.Image)
+	} else if len(e.Conference) != 2 {
+		t.Errorf("expecting 2 CONFERENCE properties, got %d", len(e.Conference))
+	} else if !reflect.DeepEqual(e.Conference[0].Feature, ParamFeature{FeatureAudio, FeatureVideo}) || e.Conference[0].URI.Path != "/call" {
+		t.Errorf("unexpected CONFERENCE property: %v", e.Conference[0])
+	} else if e.Conference[1].Label == nil || *e.Conference[1].Label != "Dial-in: ext. 1" || e.Conference[1].URI.Scheme != "tel" || !reflect.DeepEqual(e.Conference[1].Feature, ParamFeature{FeaturePhone, "X-SIP"}) {
+		t.Errorf("unexpected CONFERENCE property: %v", e.Conference[1])
+	}
+
+	var buf bytes.Buffer
+
+	if err := Encode(&buf, cal); err != nil {
+		t.Fatalf("unexpected error encoding calendar: %s", err)
+	} else if output := buf.String(); output != input {
+		t.Errorf("expecting output:\n%s\ngot:\n%s", input, output)
+	}
+}
+
+func TestDecodeEventPublishing(t *testing.T) {
+	const input = "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:TEST\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID:talk@example.com\r\n" +
+		"DTSTART:20200106T100000Z\r\n" +
+		"STYLED-DESCRIPTION;FMTTYPE=text/html;VALUE=TEXT:<p>Keynote</p>\r\n" +
+		"STRUCTURED-DATA;FMTTYPE=application/ld+json;SCHEMA=\"https://schema.org/Even\r\n" +
+		" t\";VALUE=TEXT:{}\r\n" +
+		"STRUCTURED-DATA;FMTTYPE=text/plain;VALUE=BINARY;ENCODING=BASE64:aGVsbG8=\r\n" +
+		"BEGIN:PARTICIPANT\r\n" +
+		"UID:speaker@example.com\r\n" +
+		"PARTICIPANT-TYPE:SPEAKER\r\n" +
+		"CALENDAR-ADDRESS:mailto:speaker@example.com\r\n" +
+		"STYLED-DESCRIPTION;DERIVED=TRUE;VALUE=URI:https://example.com/speaker.html\r\n" +
+		"BEGIN:VLOCATION\r\n" +
+		"UID:home@example.com\r\n" +
+		"NAME:Home\r\n" +
+		"END:VLOCATION\r\n" +
+		"END:PARTICIPANT\r\n" +
+		"BEGIN:PARTICIPANT\r\n" +
+		"UID:host@example.com\r\n" +
+		"PARTICIPANT-TYPE:X-HOST\r\n" +
+		"END:PARTICIPANT\r\n" +
+		"BEGIN:VLOCATION\r\n" +
+		"UID:hall@example.com\r\n" +
+		"NAME:Main Hall\r\n" +
+		"LOCATION-TYPE:hall,auditorium\r\n" +
+		"END:VLOCATION\r\n" +
+		"BEGIN:VRESOURCE\r\n" +
+		"UID:projector@example.com\r\n" +
+		"RESOURCE-TYPE:PROJECTOR\r\n" +
+		"END:VRESOURCE\r\n" +
+		"BEGIN:VRESOURCE\r\n" +
+		"UID:catering@example.com\r\n" +
+		"RESOURCE-TYPE:catering\r\n" +
+		"END:VRESOURCE\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	cal, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	}
+
+	e := cal.Event[0]
+
+	if len(e.StyledDescription) != 1 || e.StyledDescription[0].Text == nil || *e.StyledDescription[0].Text != "<p>Keynote</p>" {
+		t.Errorf("unexpected STYLED-DESCRIPTION: %v", e.StyledDescription)
+	} else if len(e.StructuredData) != 2 || e.StructuredData[0].Schema == nil || e.StructuredData[1].Binary == nil || string(*e.StructuredData[1].Binary) != "hello" {
+		t.Errorf("unexpected STRUCTURED-DATA: %v", e.StructuredData)
+	} else if len(e.Participant) != 2 {
+		t.Errorf("expecting 2 participants, got %d", len(e.Participant))
+	} else if p := e.Participant[1]; p.ParticipantType != "X-HOST" {
+		t.Errorf("expecting X-HOST participant, got %v", p.ParticipantType)
+	} else if p := e.Participant[0]; p.ParticipantType != ParticipantTypeSpeaker {
+		t.Errorf("expecting speaker participant, got %v", p.ParticipantType)
+	} else if p.CalendarAddress == nil || p.CalendarAddress.Opaque != "speaker@example.com" {
+		t.Errorf("unexpected CALENDAR-ADDRESS: %v", p.CalendarAddress)
+	} else if len(p.StyledDescription) != 1 || p.StyledDescription[0].Derived == nil || !*p.StyledDescription[0].Derived {
+		t.Errorf("unexpected participant STYLED-DESCRIPTION: %v", p.StyledDescription)
+	} else if len(p.StructuredLocation) != 1 || p.StructuredLocation[0].UID != "home@example.com" {
+		t.Errorf("unexpected participant location: %v", p.StructuredLocation)
+	} else if len(e.StructuredLocation) != 1 || !reflect.DeepEqual(e.StructuredLocation[0].LocationType, &PropLocationType{"hall", "auditorium"}) {
+		t.Errorf("unexpected location: %v", e.StructuredLocation)
+	} else if len(e.StructuredResource) != 2 || e.StructuredResource[0].ResourceType == nil || *e.StructuredResource[0].ResourceType != ResourceTypeProjector {
+		t.Errorf("unexpected resources: %v", e.StructuredResource)
+	} else if r := e.StructuredResource[1].ResourceType; r == nil || *r != "catering" {
+		t.Errorf("expecting catering resource, got %v", r)
+	}
+
+	var buf bytes.Buffer
+
+	if err := Encode(&buf, cal); err != nil {
+		t.Fatalf("unexpected error encoding calendar: %s", err)
+	} else if output := buf.String(); output != input {
+		t.Errorf("expecting output:\n%s\ngot:\n%s", input, output)
+	}
+
+	if _, err := Decode(strings.NewReader("BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:TEST\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID:talk@example.com\r\n" +
+		"BEGIN:PARTICIPANT\r\n" +
+		"UID:speaker@example.com\r\n" +
+		"END:PARTICIPANT\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n")); !errors.Is(err, ErrMissingRequired) {
+		t.Errorf("expecting error ErrMissingRequired, got %v", err)
+	}
+}
+
+func TestDecodeRelations(t *testing.T) {
+	const input = "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:TEST\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID:build@example.com\r\n" +
+		"RELATED-TO;RELTYPE=FINISHTOSTART;GAP=P1D:design@example.com\r\n" +
+		"RELATED-TO;RELTYPE=DEPENDS-ON;VALUE=UID:spec@example.com\r\n" +
+		"RELATED-TO;RELTYPE=X-OTHER;VALUE=URI:https://example.com/other\r\n" +
+		"LINK;LINKREL=\"https://example.com/rel/agenda\";LABEL=Agenda;VALUE=URI:https:\r\n" +
+		" //example.com/agenda\r\n" +
+		"LINK;LINKREL=latest-version;VALUE=UID:build-2@example.com\r\n" +
+		"LINK;LINKREL=describedby;VALUE=XML-REFERENCE:https://example.com/doc.xml#xp\r\n" +
+		" ointer(/a)\r\n" +
+		"REFID:project-x\r\n" +
+		"CONCEPT:https://example.com/concepts/planning\r\n" +
+		"END:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+
+	cal, warnings, err := DecodeWithOptions(strings.NewReader(input), DecodeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	} else if len(warnings) != 0 {
+		t.Errorf("expecting no warnings, got %v", warnings)
+	}
+
+	todo := cal.Todo[0]
+
+	if len(todo.RelatedTo) != 3 {
+		t.Fatalf("expecting 3 RELATED-TO properties, got %d", len(todo.RelatedTo))
+	} else if r := todo.RelatedTo[0]; *r.RelationshipType != RelationshipTypeFinishToStart || r.Gap == nil || r.Gap.Days != 1 || r.Text != "design@example.com" || r.URI != nil || r.UID != nil {
+		t.Errorf("unexpected RELATED-TO property: %v", r)
+	} else if r := todo.RelatedTo[1]; *r.RelationshipType != RelationshipTypeDependsOn || r.Gap != nil || r.UID == nil || *r.UID != "spec@example.com" {
+		t.Errorf("unexpected RELATED-TO property: %v", r)
+	} else if r := todo.RelatedTo[2]; *r.RelationshipType != "X-OTHER" || r.URI == nil || r.URI.Path != "/other" {
+		t.Errorf("unexpected RELATED-TO property: %v", r)
+	} else if len(todo.Link) != 3 {
+		t.Fatalf("expecting 3 LINK properties, got %d", len(todo.Link))
+	} else if l := todo.Link[0]; *l.LinkRelation != "https://example.com/rel/agenda" || *l.Label != "Agenda" || l.URI == nil || l.URI.Path != "/agenda" {
+		t.Errorf("unexpected LINK property: %v", l)
+	} else if l := todo.Link[1]; l.UID == nil || *l.UID != "build-2@example.com" {
+		t.Errorf("unexpected LINK property: %v", l)
+	} else if l := todo.Link[2]; l.XMLReference == nil || l.XMLReference.Fragment != "xpointer(/a)" {
+		t.Errorf("unexpected LINK property: %v", l)
+	} else if len(todo.RefID) != 1 || todo.RefID[0] != "project-x" {
+		t.Errorf("unexpected REFID properties: %v", todo.RefID)
+	} else if len(todo.Concept) != 1 || todo.Concept[0].Host != "example.com" {
+		t.Errorf("unexpected CONCEPT properties: %v", todo.Concept)
+	}
+
+	var buf bytes.Buffer
+
+	if err := Encode(&buf, cal); err != nil {
+		t.Fatalf("unexpected error encoding calendar: %s", err)
+	} else if output := buf.String(); output != input {
+		t.Errorf("expecting output:\n%s\ngot:\n%s", input, output)
+	}
+
+	todo.RelatedTo[2].UID = todo.RelatedTo[1].UID
+
+	if err := Encode(&buf, cal); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("expecting error ErrInvalidValue, got %v", err)
+	}
+
+	todo.RelatedTo[2].UID = nil
+	todo.Link[1].LinkRelation = nil
+
+	if err := Encode(&buf, cal); !errors.Is(err, ErrMissingRequired) {
+		t.Errorf("expecting error ErrMissingRequired, got %v", err)
+	}
+}
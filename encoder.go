@@ -1,7 +1,9 @@
 package ics
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"io"
 )
 
@@ -10,9 +12,25 @@ type writer interface {
 	WriteString(string) (int, error)
 }
 
+// EncodeOptions modify the behaviour of EncodeWithOptions.
+type EncodeOptions struct {
+	// AddTimezones adds a Timezone, generated by NewTimezone, for every TZID
+	// used in the Calendar that does not already have a matching Timezone.
+	AddTimezones bool
+}
+
 // Encode encodes the given iCalendar object into the writer. It first
 // validates the iCalendar object so as not to write invalid data to the writer.
 func Encode(w io.Writer, cal *Calendar) error {
+	return EncodeWithOptions(w, cal, EncodeOptions{})
+}
+
+// EncodeWithOptions acts as Encode, but with the given options applied.
+func EncodeWithOptions(w io.Writer, cal *Calendar, opts EncodeOptions) error {
+	if opts.AddTimezones {
+		cal = cal.withTimezones()
+	}
+
 	if err := cal.valid(); err != nil {
 		return err
 	}
@@ -24,7 +42,117 @@ func Encode(w io.Writer, cal *Calendar) error {
 	return f.err
 }
 
+// Encoder writes an iCalendar object to a stream, one top-level component at a
+// time.
+type Encoder struct {
+	f   folder
+	err error
+}
+
+// NewEncoder validates the properties of the given Calendar and writes them to
+// the writer. Any components in the Calendar are ignored and should instead be
+// written with the Encode method.
+//
+// The Close method must be called to finish the iCalendar object.
+func NewEncoder(w io.Writer, cal *Calendar) (*Encoder, error) {
+	header := *cal
+	header.Event = nil
+	header.Todo = nil
+	header.Journal = nil
+	header.FreeBusy = nil
+	header.Timezone = nil
+	header.Availability = nil
+	header.Components = nil
+
+	if err := header.valid(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	header.encode(&buf)
+
+	e := &Encoder{f: folder{w: w}}
+
+	e.f.Write(bytes.TrimSuffix(buf.Bytes(), []byte("END:VCALENDAR\r\n")))
+
+	if e.f.err != nil {
+		return nil, e.f.err
+	}
+
+	return e, nil
+}
+
+// Encode validates the given component and writes it to the stream. The
+// component must be one of *Event, *Todo, *Journal, *FreeBusy, *Timezone,
+// *Availability, or *Component.
+func (e *Encoder) Encode(c interface{}) error {
+	if e.err != nil {
+		return e.err
+	}
+
+	var (
+		s    section
+		name string
+	)
+
+	switch c := c.(type) {
+	case *Event:
+		s, name = c, cEvent
+	case *Todo:
+		s, name = c, cTodo
+	case *Journal:
+		s, name = c, cJournal
+	case *FreeBusy:
+		s, name = c, cFreeBusy
+	case *Timezone:
+		s, name = c, cTimezone
+	case *Availability:
+		s, name = c, cAvailability
+	case *Component:
+		if err := c.valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cCalendar, err)
+		}
+
+		c.encode(&e.f)
+
+		e.err = e.f.err
+
+		return e.err
+	default:
+		return ErrInvalidComponent
+	}
+
+	if err := s.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cCalendar, name, err)
+	}
+
+	s.encode(&e.f)
+
+	e.err = e.f.err
+
+	return e.err
+}
+
+// Close writes the end of the iCalendar object. It does not close the
+// underlying writer.
+func (e *Encoder) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	e.f.WriteString("END:VCALENDAR\r\n")
+
+	if e.err = e.f.err; e.err == nil {
+		e.err = ErrClosed
+	}
+
+	return e.f.err
+}
+
 // Errors.
 var (
-	ErrInvalidCalendar = errors.New("invalid calendar")
+	ErrInvalidCalendar  = errors.New("invalid calendar")
+	ErrInvalidComponent = errors.New("invalid component")
+	ErrClosed           = errors.New("encoder closed")
 )
@@ -2,6 +2,8 @@ package ics
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"net/url"
 	"reflect"
 	"strings"
@@ -10,7 +12,42 @@ import (
 )
 
 func TestEncode(t *testing.T) {
-	tzny, err := time.LoadLocation("America/New_York")
+	vtimezone := Timezone{
+		TimezoneID: "America/New_York",
+		Standard: []Standard{
+			{
+				DateTimeStart: PropDateTimeStart{
+					DateTime: &DateTime{
+						Time: time.Date(1998, 10, 25, 2, 0, 0, 0, time.Local),
+					},
+				},
+				TimezoneOffsetFrom: -4 * 3600,
+				TimezoneOffsetTo:   -5 * 3600,
+				TimezoneName: []PropTimezoneName{
+					{
+						Text: "EST",
+					},
+				},
+			},
+		},
+		Daylight: []Daylight{
+			{
+				DateTimeStart: PropDateTimeStart{
+					DateTime: &DateTime{
+						Time: time.Date(1999, 4, 4, 2, 0, 0, 0, time.Local),
+					},
+				},
+				TimezoneOffsetFrom: -5 * 3600,
+				TimezoneOffsetTo:   -4 * 3600,
+				TimezoneName: []PropTimezoneName{
+					{
+						Text: "EDT",
+					},
+				},
+			},
+		},
+	}
+	tzny, err := vtimezone.Location()
 	if err != nil {
 		t.Errorf("unexpected error: %s", err)
 		return
@@ -148,43 +185,7 @@ func TestEncode(t *testing.T) {
 						},
 					},
 				},
-				Timezone: []Timezone{
-					{
-						TimezoneID: "America/New_York",
-						Standard: []Standard{
-							{
-								DateTimeStart: PropDateTimeStart{
-									DateTime: &DateTime{
-										Time: time.Date(1998, 10, 25, 2, 0, 0, 0, time.Local),
-									},
-								},
-								TimezoneOffsetFrom: -4 * 3600,
-								TimezoneOffsetTo:   -5 * 3600,
-								TimezoneName: []PropTimezoneName{
-									{
-										Text: "EST",
-									},
-								},
-							},
-						},
-						Daylight: []Daylight{
-							{
-								DateTimeStart: PropDateTimeStart{
-									DateTime: &DateTime{
-										Time: time.Date(1999, 4, 4, 2, 0, 0, 0, time.Local),
-									},
-								},
-								TimezoneOffsetFrom: -5 * 3600,
-								TimezoneOffsetTo:   -4 * 3600,
-								TimezoneName: []PropTimezoneName{
-									{
-										Text: "EDT",
-									},
-								},
-							},
-						},
-					},
-				},
+				Timezone: []Timezone{vtimezone},
 			},
 			Output: "BEGIN:VCALENDAR\r\n" +
 				"VERSION:2.0\r\n" +
@@ -410,34 +411,34 @@ func TestEncode(t *testing.T) {
 						},
 						FreeBusy: []PropFreeBusy{
 							{
-								Period: Period{
+								MPeriod: MPeriod{{
 									Start: DateTime{
 										Time: time.Date(1998, 3, 14, 23, 30, 0, 0, time.UTC),
 									},
 									End: DateTime{
 										Time: time.Date(1998, 3, 15, 0, 30, 0, 0, time.UTC),
 									},
-								},
+								}},
 							},
 							{
-								Period: Period{
+								MPeriod: MPeriod{{
 									Start: DateTime{
 										Time: time.Date(1998, 3, 16, 15, 30, 0, 0, time.UTC),
 									},
 									End: DateTime{
 										Time: time.Date(1998, 3, 16, 16, 30, 0, 0, time.UTC),
 									},
-								},
+								}},
 							},
 							{
-								Period: Period{
+								MPeriod: MPeriod{{
 									Start: DateTime{
 										Time: time.Date(1998, 3, 18, 3, 0, 0, 0, time.UTC),
 									},
 									End: DateTime{
 										Time: time.Date(1998, 3, 18, 4, 0, 0, 0, time.UTC),
 									},
-								},
+								}},
 							},
 						},
 						URL: &PropURL{
@@ -486,3 +487,98 @@ func TestEncode(t *testing.T) {
 		buf.Reset()
 	}
 }
+
+func TestEncodeAddTimezones(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("unexpected error loading timezone: %s", err)
+	}
+
+	cal := &Calendar{
+		ProductID: "TEST",
+		Version:   "2.0",
+		Event: []Event{
+			{
+				DateTimeStamp: PropDateTimeStamp{
+					Time: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				UID: "event@example.com",
+				DateTimeStart: &PropDateTimeStart{
+					DateTime: &DateTime{
+						Time: time.Date(2020, 6, 1, 9, 0, 0, 0, london),
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	if err := EncodeWithOptions(&buf, cal, EncodeOptions{AddTimezones: true}); err != nil {
+		t.Fatalf("unexpected error encoding calendar: %s", err)
+	} else if len(cal.Timezone) != 0 {
+		t.Fatalf("expecting original calendar to be unmodified")
+	}
+
+	c, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error decoding encoded calendar: %s", err)
+	} else if len(c.Timezone) != 1 {
+		t.Fatalf("expecting 1 timezone, got %d", len(c.Timezone))
+	} else if tzid := string(c.Timezone[0].TimezoneID); tzid != "Europe/London" {
+		t.Errorf("expecting TZID %q, got %q", "Europe/London", tzid)
+	} else if start := c.Event[0].DateTimeStart.DateTime.Time; !start.Equal(cal.Event[0].DateTimeStart.DateTime.Time) {
+		t.Errorf("expecting start time %s, got %s", cal.Event[0].DateTimeStart.DateTime.Time, start)
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	var buf bytes.Buffer
+
+	e, err := NewEncoder(&buf, &Calendar{
+		Version:    "2.0",
+		ProductID:  "TEST",
+		Extensions: ExtensionProperties{{Name: "X-WR-CALNAME", Value: "Rooms"}},
+		Event:      []Event{{UID: "ignored@example.com"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating encoder: %s", err)
+	}
+
+	for n := 0; n < 3; n++ {
+		if err := e.Encode(&Event{
+			DateTimeStamp: PropDateTimeStamp{Time: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+			UID:           PropUID(fmt.Sprintf("event%d@example.com", n)),
+			Summary:       &PropSummary{Text: Text(strings.Repeat("Long Summary ", 6))},
+		}); err != nil {
+			t.Fatalf("unexpected error encoding event %d: %s", n, err)
+		}
+	}
+
+	if err := e.Encode(&Event{}); !errors.Is(err, ErrInvalidTime) {
+		t.Errorf("expecting error ErrInvalidTime, got %v", err)
+	} else if err := e.Encode(Event{}); !errors.Is(err, ErrInvalidComponent) {
+		t.Errorf("expecting error ErrInvalidComponent, got %v", err)
+	} else if err := e.Encode(&Component{Name: "X-OTHER"}); err != nil {
+		t.Errorf("unexpected error encoding component: %s", err)
+	} else if err := e.Close(); err != nil {
+		t.Errorf("unexpected error closing encoder: %s", err)
+	} else if err := e.Encode(&Component{Name: "X-OTHER"}); !errors.Is(err, ErrClosed) {
+		t.Errorf("expecting error ErrClosed, got %v", err)
+	}
+
+	cal, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error decoding encoded calendar: %s", err)
+	} else if len(cal.Event) != 3 || cal.Event[2].UID != "event2@example.com" || cal.Event[0].Summary.Text != Text(strings.Repeat("Long Summary ", 6)) {
+		t.Errorf("unexpected events: %v", cal.Event)
+	} else if len(cal.Components) != 1 || cal.Components[0].Name != "X-OTHER" {
+		t.Errorf("unexpected components: %v", cal.Components)
+	} else if p, _ := cal.Extensions.Get("X-WR-CALNAME"); p.Value != "Rooms" {
+		t.Errorf("expecting X-WR-CALNAME Rooms, got %q", p.Value)
+	}
+
+	if _, err := NewEncoder(&buf, &Calendar{Version: "2.0", ProductID: "\x00"}); !errors.Is(err, ErrInvalidText) {
+		t.Errorf("expecting error ErrInvalidText, got %v", err)
+	}
+}
@@ -0,0 +1,327 @@
+package ics
+
+import (
+	"errors"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// ExtensionParam is a property parameter not otherwise handled by this
+// package, such as an X- parameter.
+type ExtensionParam struct {
+	Name   string
+	Values []string
+}
+
+// ExtensionParams is an ordered list of ExtensionParam.
+type ExtensionParams []ExtensionParam
+
+func eachParam(params []parser.Token, fn func(string, []parser.Token)) {
+	for len(params) > 0 {
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		fn(strings.ToUpper(params[0].Data), params[1:i])
+
+		params = params[i:]
+	}
+}
+
+func extensionParams(params []parser.Token) ExtensionParams {
+	var e ExtensionParams
+
+	eachParam(params, e.add)
+
+	return e
+}
+
+func (e *ExtensionParams) add(name string, vs []parser.Token) {
+	values := make([]string, len(vs))
+
+	for n, v := range vs {
+		values[n] = decode6868(v.Data)
+	}
+
+	*e = append(*e, ExtensionParam{Name: name, Values: values})
+}
+
+// decode adds the given param, unless it is one that is used to decode the
+// value of a property.
+func (e *ExtensionParams) decode(name string, vs []parser.Token) {
+	switch name {
+	case "VALUE", "TZID", "ENCODING":
+	default:
+		e.add(name, vs)
+	}
+}
+
+// Get returns the values of the first parameter with the given name.
+func (e ExtensionParams) Get(name string) ([]string, bool) {
+	for _, p := range e {
+		if strings.EqualFold(p.Name, name) {
+			return p.Values, true
+		}
+	}
+
+	return nil, false
+}
+
+// Set replaces the values of the named parameter, adding it if it does not
+// already exist.
+func (e *ExtensionParams) Set(name string, values ...string) {
+	for n := range *e {
+		if strings.EqualFold((*e)[n].Name, name) {
+			(*e)[n].Values = values
+
+			return
+		}
+	}
+
+	*e = append(*e, ExtensionParam{Name: name, Values: values})
+}
+
+// Remove removes all parameters with the given name.
+func (e *ExtensionParams) Remove(name string) {
+	params := (*e)[:0]
+
+	for _, p := range *e {
+		if !strings.EqualFold(p.Name, name) {
+			params = append(params, p)
+		}
+	}
+
+	if len(params) == 0 {
+		params = nil
+	}
+
+	*e = params
+}
+
+func (e ExtensionParams) encode(w writer) {
+	for _, p := range e {
+		w.WriteString(";")
+		w.WriteString(p.Name)
+		w.WriteString("=")
+
+		for n, v := range p.Values {
+			if n > 0 {
+				w.WriteString(",")
+			}
+
+			if strings.ContainsAny(v, nonsafeChars[32:]) {
+				w.WriteString("\"")
+				w.Write(encode6868(v))
+				w.WriteString("\"")
+			} else {
+				w.Write(encode6868(v))
+			}
+		}
+	}
+}
+
+func (e ExtensionParams) valid() error {
+	for _, p := range e {
+		if !validName(p.Name) {
+			return ErrInvalidExtensionName
+		}
+
+		for _, v := range p.Values {
+			if strings.ContainsAny(v, nonsafeChars[:31]) { // LF is encoded per RFC 6868
+				return ErrInvalidExtensionValue
+			}
+		}
+	}
+
+	return nil
+}
+
+// ExtensionProperty is a property not otherwise handled by this package, such
+// as an X- property.
+//
+// The Value is stored as it appears in the content line, without any
+// unescaping.
+type ExtensionProperty struct {
+	Name   string
+	Params ExtensionParams
+	Value  string
+}
+
+// ExtensionProperties is an ordered list of ExtensionProperty.
+type ExtensionProperties []ExtensionProperty
+
+// Get returns the first property with the given name.
+func (e ExtensionProperties) Get(name string) (ExtensionProperty, bool) {
+	for _, p := range e {
+		if strings.EqualFold(p.Name, name) {
+			return p, true
+		}
+	}
+
+	return ExtensionProperty{}, false
+}
+
+// GetAll returns all properties with the given name.
+func (e ExtensionProperties) GetAll(name string) []ExtensionProperty {
+	var props []ExtensionProperty
+
+	for _, p := range e {
+		if strings.EqualFold(p.Name, name) {
+			props = append(props, p)
+		}
+	}
+
+	return props
+}
+
+// Add appends a property to the list.
+func (e *ExtensionProperties) Add(name, value string, params ...ExtensionParam) {
+	*e = append(*e, ExtensionProperty{Name: name, Params: params, Value: value})
+}
+
+// Set replaces all properties with the given name with a single property,
+// which is added to the end of the list if no such property already exists.
+func (e *ExtensionProperties) Set(name, value string, params ...ExtensionParam) {
+	prop := ExtensionProperty{Name: name, Params: params, Value: value}
+
+	for n := range *e {
+		if strings.EqualFold((*e)[n].Name, name) {
+			*e = append(append((*e)[:n:n], prop), (*e)[n+1:].without(name)...)
+
+			return
+		}
+	}
+
+	*e = append(*e, prop)
+}
+
+// Remove removes all properties with the given name.
+func (e *ExtensionProperties) Remove(name string) {
+	props := e.without(name)
+
+	if len(props) == 0 {
+		props = nil
+	}
+
+	*e = props
+}
+
+func (e ExtensionProperties) without(name string) ExtensionProperties {
+	var props ExtensionProperties
+
+	for _, p := range e {
+		if !strings.EqualFold(p.Name, name) {
+			props = append(props, p)
+		}
+	}
+
+	return props
+}
+
+func (e ExtensionProperties) encode(w writer) {
+	for _, p := range e {
+		w.WriteString(p.Name)
+		p.Params.encode(w)
+		w.WriteString(":")
+		w.WriteString(p.Value)
+		w.WriteString("\r\n")
+	}
+}
+
+func (e ExtensionProperties) valid() error {
+	for _, p := range e {
+		if !validName(p.Name) {
+			return ErrInvalidExtensionName
+		} else if strings.ContainsAny(p.Value, nonsafeChars[:32]) {
+			return ErrInvalidExtensionValue
+		}
+
+		if err := p.Params.valid(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PropertyParams contains the ExtensionParams of properties whose types have no
+// parameters of their own, keyed by property name.
+type PropertyParams map[string]ExtensionParams
+
+func (p *PropertyParams) decode(name string, params []parser.Token) {
+	var e ExtensionParams
+
+	eachParam(params, e.decode)
+
+	if len(e) > 0 {
+		if *p == nil {
+			*p = make(PropertyParams)
+		}
+
+		(*p)[name] = append((*p)[name], e...)
+	}
+}
+
+// writer returns a writer that inserts the ExtensionParams for the named
+// property after the property name written by an encoder.
+func (p PropertyParams) writer(w writer, name string) writer {
+	if len(p[name]) == 0 {
+		return w
+	}
+
+	return &paramWriter{writer: w, params: p[name]}
+}
+
+func (p PropertyParams) valid() error {
+	for name, params := range p {
+		if !validName(name) {
+			return ErrInvalidExtensionName
+		}
+
+		if err := params.valid(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type paramWriter struct {
+	writer
+	params ExtensionParams
+}
+
+func (p *paramWriter) WriteString(s string) (int, error) {
+	n, err := p.writer.WriteString(s)
+
+	if p.params != nil {
+		p.params.encode(p.writer)
+
+		p.params = nil
+	}
+
+	return n, err
+}
+
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, c := range name {
+		if !strings.ContainsRune(ianaToken, c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Errors.
+var (
+	ErrInvalidExtensionName  = errors.New("invalid extension name")
+	ErrInvalidExtensionValue = errors.New("invalid extension value")
+)
@@ -0,0 +1,108 @@
+package ics
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtensions(t *testing.T) {
+	const input = "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID;X-BUILD=42:TEST\r\n" +
+		"X-WR-CALNAME:Test Calendar\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID;X-SOURCE=\"a:b\":event@example.com\r\n" +
+		"DTSTART:20200106T100000Z\r\n" +
+		"STATUS;X-REASON=moved:CONFIRMED\r\n" +
+		"ATTENDEE;RSVP=TRUE;X-NUM-GUESTS=0;X-RESPONSE-COMMENT=\"^'Yes^'\",see you:mail\r\n" +
+		" to:a@example.com\r\n" +
+		"X-MICROSOFT-CDO-BUSYSTATUS:BUSY\r\n" +
+		"X-APPLE-TRAVEL-ADVISORY-BEHAVIOR;X-A=1,2:AUTOMATIC\r\n" +
+		"X-MICROSOFT-CDO-BUSYSTATUS:OOF\r\n" +
+		"BEGIN:VALARM\r\n" +
+		"ACTION:DISPLAY\r\n" +
+		"DESCRIPTION:Reminder\r\n" +
+		"TRIGGER:-PT15M\r\n" +
+		"X-WR-ALARMUID:alarm@example.com\r\n" +
+		"END:VALARM\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	cal, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	}
+
+	ev := &cal.Event[0]
+
+	if p, ok := cal.Extensions.Get("x-wr-calname"); !ok || p.Value != "Test Calendar" {
+		t.Errorf("expecting X-WR-CALNAME property, got %v", p)
+	}
+
+	if ps := ev.Extensions.GetAll("X-MICROSOFT-CDO-BUSYSTATUS"); len(ps) != 2 || ps[0].Value != "BUSY" || ps[1].Value != "OOF" {
+		t.Errorf("expecting two X-MICROSOFT-CDO-BUSYSTATUS properties, got %v", ps)
+	}
+
+	if p, _ := ev.Extensions.Get("X-APPLE-TRAVEL-ADVISORY-BEHAVIOR"); !reflect.DeepEqual(p.Params, ExtensionParams{{Name: "X-A", Values: []string{"1", "2"}}}) {
+		t.Errorf("expecting X-A param, got %v", p.Params)
+	}
+
+	if v, ok := ev.Attendee[0].Extensions.Get("X-RESPONSE-COMMENT"); !ok || !reflect.DeepEqual(v, []string{"\"Yes\"", "see you"}) {
+		t.Errorf("expecting X-RESPONSE-COMMENT param, got %q", v)
+	}
+
+	if v, ok := ev.PropertyParams["UID"].Get("X-SOURCE"); !ok || !reflect.DeepEqual(v, []string{"a:b"}) {
+		t.Errorf("expecting X-SOURCE param, got %q", v)
+	}
+
+	if p, ok := ev.Alarm[0].AlarmType.(*AlarmDisplay).Extensions.Get("X-WR-ALARMUID"); !ok || p.Value != "alarm@example.com" {
+		t.Errorf("expecting X-WR-ALARMUID property, got %v", p)
+	}
+
+	var buf bytes.Buffer
+
+	if err := Encode(&buf, cal); err != nil {
+		t.Fatalf("unexpected error encoding calendar: %s", err)
+	} else if output := buf.String(); output != input {
+		t.Errorf("expecting output:\n%s\ngot:\n%s", input, output)
+	}
+
+	ev.Extensions.Set("X-MICROSOFT-CDO-BUSYSTATUS", "FREE")
+	ev.Extensions.Remove("X-APPLE-TRAVEL-ADVISORY-BEHAVIOR")
+	ev.Extensions.Add("X-NEW", "value", ExtensionParam{Name: "X-P", Values: []string{"q"}})
+	ev.Attendee[0].Extensions.Set("X-NUM-GUESTS", "2")
+
+	if !reflect.DeepEqual(ev.Extensions, ExtensionProperties{
+		{Name: "X-MICROSOFT-CDO-BUSYSTATUS", Value: "FREE"},
+		{Name: "X-NEW", Params: ExtensionParams{{Name: "X-P", Values: []string{"q"}}}, Value: "value"},
+	}) {
+		t.Errorf("unexpected extensions after modification: %v", ev.Extensions)
+	} else if v, _ := ev.Attendee[0].Extensions.Get("X-NUM-GUESTS"); !reflect.DeepEqual(v, []string{"2"}) {
+		t.Errorf("expecting X-NUM-GUESTS of 2, got %q", v)
+	}
+
+	for n, value := range [...]string{"a\nBEGIN:VEVENT", "a\rb"} {
+		ev.Extensions.Set("X-NEW", value)
+
+		if err := Encode(&buf, cal); !errors.Is(err, ErrInvalidExtensionValue) {
+			t.Errorf("test %d: expecting error %v, got %v", n+1, ErrInvalidExtensionValue, err)
+		}
+	}
+
+	ev.Extensions.Set("X-NEW", "value", ExtensionParam{Name: "X-P", Values: []string{"a\rb"}})
+
+	if err := Encode(&buf, cal); !errors.Is(err, ErrInvalidExtensionValue) {
+		t.Errorf("expecting error %v, got %v", ErrInvalidExtensionValue, err)
+	}
+
+	ev.Extensions.Set("X-NEW", "value")
+	ev.Extensions.Add("X BAD", "value")
+
+	if err := Encode(&buf, cal); !errors.Is(err, ErrInvalidExtensionName) {
+		t.Errorf("expecting error %v, got %v", ErrInvalidExtensionName, err)
+	}
+}
@@ -0,0 +1,249 @@
+package ics
+
+import (
+	"sort"
+	"time"
+)
+
+type busyPeriod struct {
+	start, end time.Time
+	busy       ParamFreeBusyType
+}
+
+// NewFreeBusy returns a FreeBusy component describing the busy time, within the
+// given time range, of the Events in the given Calendars. The DateTimeStamp and
+// UID of the returned component are not set.
+//
+// Recurring Events are expanded, and each instance is BusyTentative when its
+// Status is Tentative, and Busy otherwise. Instances that are Cancelled or
+// Transparent are skipped. The busy time of any Availability components of the
+// Calendars is also included.
+//
+// Where periods of different types overlap, BusyUnavailable takes precedence
+// over Busy, which takes precedence over BusyTentative. The periods are in UTC,
+// sorted, and do not overlap.
+func NewFreeBusy(start, end time.Time, cals ...*Calendar) FreeBusy {
+	var periods []busyPeriod
+
+	for _, c := range cals {
+		for _, s := range c.EventSeries() {
+			periods = s.busyPeriods(periods, start, end)
+		}
+
+		for _, p := range busyPeriodsOf(c.EffectiveAvailability(start, end)) {
+			if p.busy != FreeBusyTypeFree {
+				periods = append(periods, p)
+			}
+		}
+	}
+
+	return FreeBusy{
+		DateTimeStart: &PropDateTimeStart{DateTime: &DateTime{start.In(time.UTC)}},
+		DateTimeEnd:   &PropDateTimeEnd{DateTime: &DateTime{end.In(time.UTC)}},
+		FreeBusy:      mergeBusyPeriods(periods),
+	}
+}
+
+// busyPeriods appends the busy time, within the given range, of the instances
+// of the series.
+func (s *EventSeries) busyPeriods(periods []busyPeriod, start, end time.Time) []busyPeriod {
+	it := s.Occurrences()
+
+	for {
+		o, ok := it.Next()
+		if !ok || !o.RecurrenceID.Before(end) && !o.Start.Before(end) {
+			return periods
+		}
+
+		e := o.Event
+
+		if e.DateTimeStart == nil || !o.Start.Before(end) || !o.End.After(start) || !o.Start.Before(o.End) {
+			continue
+		} else if e.Status != nil && *e.Status == StatusCancelled {
+			continue
+		} else if e.TimeTransparency != nil && *e.TimeTransparency == TimeTransparencyTransparent {
+			continue
+		}
+
+		p := busyPeriod{start: o.Start, end: o.End, busy: FreeBusyTypeBusy}
+
+		if e.Status != nil && *e.Status == StatusTentative {
+			p.busy = FreeBusyTypeBusyTentative
+		}
+
+		if p.start.Before(start) {
+			p.start = start
+		}
+
+		if p.end.After(end) {
+			p.end = end
+		}
+
+		periods = append(periods, p)
+	}
+}
+
+func busyPrecedence(t ParamFreeBusyType) int {
+	switch t {
+	case FreeBusyTypeBusyUnavailable:
+		return 3
+	case FreeBusyTypeBusy:
+		return 2
+	case FreeBusyTypeBusyTentative:
+		return 1
+	}
+
+	return 0
+}
+
+// mergeBusyPeriods returns the given periods as sorted, non-overlapping UTC
+// periods, with the type of the period taking precedence being used where they
+// overlap.
+func mergeBusyPeriods(periods []busyPeriod) []PropFreeBusy {
+	return combineBusyPeriods(periods, nil, func(inA, _ bool) bool { return inA })
+}
+
+// combineBusyPeriods returns the time, as sorted, non-overlapping UTC periods,
+// for which keep returns true when given whether the time is covered by a
+// period in a and in b. Where periods overlap, the type taking precedence is
+// used.
+func combineBusyPeriods(a, b []busyPeriod, keep func(inA, inB bool) bool) []PropFreeBusy {
+	bounds := make([]time.Time, 0, (len(a)+len(b))*2)
+
+	for _, ps := range [2][]busyPeriod{a, b} {
+		for _, p := range ps {
+			bounds = append(bounds, p.start, p.end)
+		}
+	}
+
+	sort.Slice(bounds, func(i, j int) bool {
+		return bounds[i].Before(bounds[j])
+	})
+
+	var merged []PropFreeBusy
+
+	for n := 1; n < len(bounds); n++ {
+		from, to := bounds[n-1], bounds[n]
+		if !from.Before(to) {
+			continue
+		}
+
+		typ, inA := busyAt(a, from)
+		typB, inB := busyAt(b, from)
+
+		if !keep(inA, inB) {
+			continue
+		} else if inB && (!inA || busyPrecedence(typB) > busyPrecedence(typ)) {
+			typ = typB
+		}
+
+		merged = appendFreeBusy(merged, from, to, typ)
+	}
+
+	return merged
+}
+
+// busyAt returns the type, of those periods covering the given time, that
+// takes precedence. The bool will be false if no period covers the time.
+func busyAt(periods []busyPeriod, t time.Time) (ParamFreeBusyType, bool) {
+	var (
+		typ   ParamFreeBusyType
+		found bool
+	)
+
+	for _, p := range periods {
+		if !t.Before(p.start) && t.Before(p.end) && (!found || busyPrecedence(p.busy) > busyPrecedence(typ)) {
+			typ, found = p.busy, true
+		}
+	}
+
+	return typ, found
+}
+
+// appendFreeBusy adds the given period, which must not be before the last
+// period in the list, to the list, extending the last period, or adding to the
+// last property, when it has the same type.
+func appendFreeBusy(fbs []PropFreeBusy, start, end time.Time, typ ParamFreeBusyType) []PropFreeBusy {
+	p := Period{
+		Start: DateTime{start.In(time.UTC)},
+		End:   DateTime{end.In(time.UTC)},
+	}
+
+	if l := len(fbs) - 1; l >= 0 && *fbs[l].FreeBusyType == typ {
+		last := &fbs[l].MPeriod[len(fbs[l].MPeriod)-1]
+
+		if last.End.Equal(start) {
+			last.End = p.End
+		} else {
+			fbs[l].MPeriod = append(fbs[l].MPeriod, p)
+		}
+
+		return fbs
+	}
+
+	return append(fbs, PropFreeBusy{
+		FreeBusyType: typ.New(),
+		MPeriod:      MPeriod{p},
+	})
+}
+
+// busyPeriodsOf returns the periods of the given FreeBusy properties, with an
+// undefined or unrecognised FreeBusyType being treated as Busy.
+func busyPeriodsOf(fbs []PropFreeBusy) []busyPeriod {
+	var periods []busyPeriod
+
+	for _, fb := range fbs {
+		typ := FreeBusyTypeBusy
+
+		if fb.FreeBusyType != nil && *fb.FreeBusyType != FreeBusyTypeUnknown {
+			typ = *fb.FreeBusyType
+		}
+
+		for n := range fb.MPeriod {
+			p := &fb.MPeriod[n]
+
+			if end := p.end(); p.Start.Before(end) {
+				periods = append(periods, busyPeriod{start: p.Start.Time, end: end, busy: typ})
+			}
+		}
+	}
+
+	return periods
+}
+
+// NormaliseFreeBusy returns the given periods in the form recommended by
+// RFC 5545: in UTC, sorted, and without overlaps.
+//
+// Where periods of different types overlap, BusyUnavailable takes precedence
+// over Busy, which takes precedence over BusyTentative, which takes
+// precedence over Free. Consecutive periods of the same type are combined into
+// a single property.
+func NormaliseFreeBusy(fbs []PropFreeBusy) []PropFreeBusy {
+	return mergeBusyPeriods(busyPeriodsOf(fbs))
+}
+
+// UnionFreeBusy returns the normalised time covered by either set of periods,
+// with the type taking precedence used where they overlap.
+func UnionFreeBusy(a, b []PropFreeBusy) []PropFreeBusy {
+	return combineBusyPeriods(busyPeriodsOf(a), busyPeriodsOf(b), func(inA, inB bool) bool { return inA || inB })
+}
+
+// IntersectFreeBusy returns the normalised time covered by both sets of
+// periods, with the type taking precedence used.
+func IntersectFreeBusy(a, b []PropFreeBusy) []PropFreeBusy {
+	return combineBusyPeriods(busyPeriodsOf(a), busyPeriodsOf(b), func(inA, inB bool) bool { return inA && inB })
+}
+
+// SubtractFreeBusy returns the normalised time covered by the first set of
+// periods but not by the second, keeping the types of the first set.
+func SubtractFreeBusy(a, b []PropFreeBusy) []PropFreeBusy {
+	return combineBusyPeriods(busyPeriodsOf(a), busyPeriodsOf(b), func(inA, inB bool) bool { return inA && !inB })
+}
+
+// ClipFreeBusy returns the normalised periods restricted to the given time
+// range.
+func ClipFreeBusy(fbs []PropFreeBusy, start, end time.Time) []PropFreeBusy {
+	window := []busyPeriod{{start: start, end: end, busy: FreeBusyTypeFree}}
+
+	return combineBusyPeriods(busyPeriodsOf(fbs), window, func(inA, inB bool) bool { return inA && inB })
+}
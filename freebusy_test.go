@@ -0,0 +1,234 @@
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewFreeBusy(t *testing.T) {
+	const (
+		cal1 = "BEGIN:VCALENDAR\r\n" +
+			"VERSION:2.0\r\n" +
+			"PRODID:TEST\r\n" +
+			"BEGIN:VEVENT\r\n" +
+			"DTSTAMP:20200101T000000Z\r\n" +
+			"UID:a\r\n" +
+			"DTSTART:20200106T100000Z\r\n" +
+			"DTEND:20200106T110000Z\r\n" +
+			"RRULE:FREQ=WEEKLY;COUNT=3\r\n" +
+			"END:VEVENT\r\n" +
+			"BEGIN:VEVENT\r\n" +
+			"DTSTAMP:20200101T000000Z\r\n" +
+			"UID:a\r\n" +
+			"RECURRENCE-ID:20200113T100000Z\r\n" +
+			"DTSTART:20200113T140000Z\r\n" +
+			"DTEND:20200113T150000Z\r\n" +
+			"STATUS:TENTATIVE\r\n" +
+			"END:VEVENT\r\n" +
+			"BEGIN:VEVENT\r\n" +
+			"DTSTAMP:20200101T000000Z\r\n" +
+			"UID:b\r\n" +
+			"DTSTART:20200106T103000Z\r\n" +
+			"DTEND:20200106T120000Z\r\n" +
+			"END:VEVENT\r\n" +
+			"BEGIN:VEVENT\r\n" +
+			"DTSTAMP:20200101T000000Z\r\n" +
+			"UID:c\r\n" +
+			"DTSTART:20200107T100000Z\r\n" +
+			"DTEND:20200107T110000Z\r\n" +
+			"TRANSP:TRANSPARENT\r\n" +
+			"END:VEVENT\r\n" +
+			"BEGIN:VEVENT\r\n" +
+			"DTSTAMP:20200101T000000Z\r\n" +
+			"UID:d\r\n" +
+			"DTSTART:20200107T120000Z\r\n" +
+			"DURATION:PT1H\r\n" +
+			"STATUS:CANCELLED\r\n" +
+			"END:VEVENT\r\n" +
+			"BEGIN:VEVENT\r\n" +
+			"DTSTAMP:20200101T000000Z\r\n" +
+			"UID:e\r\n" +
+			"DTSTART:20200105T230000Z\r\n" +
+			"DTEND:20200106T010000Z\r\n" +
+			"END:VEVENT\r\n" +
+			"END:VCALENDAR\r\n"
+		cal2 = "BEGIN:VCALENDAR\r\n" +
+			"VERSION:2.0\r\n" +
+			"PRODID:TEST\r\n" +
+			"BEGIN:VEVENT\r\n" +
+			"DTSTAMP:20200101T000000Z\r\n" +
+			"UID:f\r\n" +
+			"DTSTART:20200113T143000Z\r\n" +
+			"DTEND:20200113T160000Z\r\n" +
+			"STATUS:CONFIRMED\r\n" +
+			"END:VEVENT\r\n" +
+			"BEGIN:VEVENT\r\n" +
+			"DTSTAMP:20200101T000000Z\r\n" +
+			"UID:g\r\n" +
+			"DTSTART:20200108T090000Z\r\n" +
+			"DTEND:20200108T100000Z\r\n" +
+			"END:VEVENT\r\n" +
+			"BEGIN:VAVAILABILITY\r\n" +
+			"DTSTAMP:20200101T000000Z\r\n" +
+			"UID:h\r\n" +
+			"DTSTART:20200108T000000Z\r\n" +
+			"DTEND:20200109T000000Z\r\n" +
+			"END:VAVAILABILITY\r\n" +
+			"END:VCALENDAR\r\n"
+	)
+
+	var cals []*Calendar
+
+	for _, data := range [...]string{cal1, cal2} {
+		c, err := Decode(strings.NewReader(data))
+		if err != nil {
+			t.Fatalf("unexpected error decoding calendar: %s", err)
+		}
+
+		cals = append(cals, c)
+	}
+
+	day := func(d, h, m int) time.Time {
+		return time.Date(2020, 1, d, h, m, 0, 0, time.UTC)
+	}
+
+	fb := NewFreeBusy(day(6, 0, 0), day(20, 0, 0), cals...)
+
+	if !fb.DateTimeStart.DateTime.Equal(day(6, 0, 0)) || !fb.DateTimeEnd.DateTime.Equal(day(20, 0, 0)) {
+		t.Errorf("expecting range to match the requested window, got %s to %s", fb.DateTimeStart.DateTime, fb.DateTimeEnd.DateTime)
+	}
+
+	if len(fb.FreeBusy) != 4 || len(fb.FreeBusy[0].MPeriod) != 2 {
+		t.Errorf("expecting consecutive periods of the same type to share a property, got %v", fb.FreeBusy)
+	}
+
+	checkFreeBusy(t, 1, fb.FreeBusy, []typedPeriod{
+		{day(6, 0, 0), day(6, 1, 0), FreeBusyTypeBusy},
+		{day(6, 10, 0), day(6, 12, 0), FreeBusyTypeBusy},
+		{day(8, 0, 0), day(9, 0, 0), FreeBusyTypeBusyUnavailable},
+		{day(13, 14, 0), day(13, 14, 30), FreeBusyTypeBusyTentative},
+		{day(13, 14, 30), day(13, 16, 0), FreeBusyTypeBusy},
+	})
+}
+
+func TestFreeBusyOperations(t *testing.T) {
+	at := func(h int) time.Time {
+		return time.Date(2020, 1, 6, h, 0, 0, 0, time.UTC)
+	}
+
+	fb := func(typ *ParamFreeBusyType, hours ...int) PropFreeBusy {
+		p := PropFreeBusy{FreeBusyType: typ}
+
+		for n := 0; n < len(hours); n += 2 {
+			p.MPeriod = append(p.MPeriod, Period{Start: DateTime{at(hours[n])}, End: DateTime{at(hours[n+1])}})
+		}
+
+		return p
+	}
+
+	est := time.FixedZone("EST", -5*3600)
+	a := []PropFreeBusy{
+		fb(nil, 13, 15, 9, 11),
+		fb(FreeBusyTypeBusyTentative.New(), 10, 12),
+		{MPeriod: MPeriod{{Start: DateTime{time.Date(2020, 1, 6, 11, 0, 0, 0, est)}, Duration: Duration{Hours: 1}}}},
+	}
+	b := []PropFreeBusy{
+		fb(FreeBusyTypeBusyUnavailable.New(), 14, 18),
+		fb(FreeBusyTypeFree.New(), 8, 10),
+	}
+
+	for n, test := range [...]struct {
+		Result   []PropFreeBusy
+		Expected []typedPeriod
+	}{
+		{
+			Result: NormaliseFreeBusy(a),
+			Expected: []typedPeriod{
+				{at(9), at(11), FreeBusyTypeBusy},
+				{at(11), at(12), FreeBusyTypeBusyTentative},
+				{at(13), at(15), FreeBusyTypeBusy},
+				{at(16), at(17), FreeBusyTypeBusy},
+			},
+		},
+		{
+			Result: UnionFreeBusy(a, b),
+			Expected: []typedPeriod{
+				{at(8), at(9), FreeBusyTypeFree},
+				{at(9), at(11), FreeBusyTypeBusy},
+				{at(11), at(12), FreeBusyTypeBusyTentative},
+				{at(13), at(14), FreeBusyTypeBusy},
+				{at(14), at(18), FreeBusyTypeBusyUnavailable},
+			},
+		},
+		{
+			Result: IntersectFreeBusy(a, b),
+			Expected: []typedPeriod{
+				{at(9), at(10), FreeBusyTypeBusy},
+				{at(14), at(15), FreeBusyTypeBusyUnavailable},
+				{at(16), at(17), FreeBusyTypeBusyUnavailable},
+			},
+		},
+		{
+			Result: SubtractFreeBusy(a, b),
+			Expected: []typedPeriod{
+				{at(10), at(11), FreeBusyTypeBusy},
+				{at(11), at(12), FreeBusyTypeBusyTentative},
+				{at(13), at(14), FreeBusyTypeBusy},
+			},
+		},
+		{
+			Result: ClipFreeBusy(a, at(10), at(14)),
+			Expected: []typedPeriod{
+				{at(10), at(11), FreeBusyTypeBusy},
+				{at(11), at(12), FreeBusyTypeBusyTentative},
+				{at(13), at(14), FreeBusyTypeBusy},
+			},
+		},
+	} {
+		checkFreeBusy(t, n+1, test.Result, test.Expected)
+	}
+}
+
+type typedPeriod struct {
+	Start, End time.Time
+	Type       ParamFreeBusyType
+}
+
+// flattenFreeBusy returns each period of the properties, checking that they
+// are in UTC.
+func flattenFreeBusy(fbs []PropFreeBusy) []typedPeriod {
+	var periods []typedPeriod
+
+	for _, fb := range fbs {
+		for _, p := range fb.MPeriod {
+			if p.Start.Location() != time.UTC || p.End.Location() != time.UTC {
+				return nil
+			}
+
+			periods = append(periods, typedPeriod{p.Start.Time, p.End.Time, *fb.FreeBusyType})
+		}
+	}
+
+	return periods
+}
+
+func checkFreeBusy(t *testing.T, test int, fbs []PropFreeBusy, expected []typedPeriod) {
+	t.Helper()
+
+	periods := flattenFreeBusy(fbs)
+
+	if len(periods) != len(expected) {
+		t.Errorf("test %d: expecting %d UTC periods, got %d: %v", test, len(expected), len(periods), fbs)
+
+		return
+	}
+
+	for n, e := range expected {
+		if p := periods[n]; !p.Start.Equal(e.Start) || !p.End.Equal(e.End) {
+			t.Errorf("test %d.%d: expecting period %s to %s, got %s to %s", test, n+1, e.Start, e.End, p.Start, p.End)
+		} else if p.Type != e.Type {
+			t.Errorf("test %d.%d: expecting type %d, got %d", test, n+1, e.Type, p.Type)
+		}
+	}
+}
@@ -95,6 +95,14 @@ source "comments.sh";
 				1)
 					echo "struct{}";;
 				*)
+					declare cType="Param$type";
+					if $multiple; then
+						cType="$type";
+						echo "$type";
+						echo;
+						getComment "$type";
+						echo -n "type $type ";
+					fi;
 					echo "uint8";
 					echo;
 					echo "// $type constant values.";
@@ -103,7 +111,7 @@ source "comments.sh";
 					for choice in ${choices[@]}; do
 						echo -n "	$type$(getName "$choice")";
 						if $first; then
-							echo -n " Param$type = iota";
+							echo -n " $cType = iota";
 							first=false;
 						fi;
 						echo;
@@ -112,7 +120,7 @@ source "comments.sh";
 					echo;
 					echo "// New returns a pointer to the type (used with constants for ease of use with";
 					echo "// optional values).";
-					echo "func (t Param$type) New() *Param$type {";
+					echo "func (t $cType) New() *$cType {";
 					echo "	return &t";
 					echo "}";;
 				esac;
@@ -161,7 +169,7 @@ source "comments.sh";
 				for choice in ${choices[@]}; do
 					echo "$indent	case \"$choice\":";
 					if $multiple; then
-						echo "		*t = append(*t, $type$(getName "$choice")";
+						echo "$indent		*t = append(*t, $type$(getName "$choice"))";
 					else
 						echo "		*t = $type$(getName "$choice")";
 					fi;
@@ -169,7 +177,7 @@ source "comments.sh";
 				echo "$indent	default:";
 				if $freeChoice; then
 					if $multiple; then
-						echo "		*t = append(*t, {$type}Unknown)";
+						echo "$indent		*t = append(*t, ${type}Unknown)";
 					else
 						echo "		*t = ${type}Unknown";
 					fi;
@@ -202,7 +210,7 @@ source "comments.sh";
 
 			#encoder
 			echo "func (t Param$type) encode(w writer) {";
-			if [ ${#choices} -eq 0 ] || $multiple; then
+			if [ "$vType" != "Duration" ] && { [ ${#choices} -eq 0 ] || $multiple; }; then
 				if [ "$vType" = "CALADDRESS" -o "$vType" = "URI" ]; then
 					echo "	if len(t.String()) == 0 {";
 					echo "		return";
@@ -232,7 +240,13 @@ source "comments.sh";
 			if [ ! -z "$vType" ]; then
 				echo "$indent	q := $vType($vName)";
 				echo;
-				echo "$indent	q.encode(w)";
+				if $doubleQuote; then
+					echo "$indent	w.WriteString(\"\\\"\")";
+					echo "$indent	q.encode(w)";
+					echo "$indent	w.WriteString(\"\\\"\")";
+				else
+					echo "$indent	q.encode(w)";
+				fi;
 			elif [ ${#choices[@]} -eq 1 ]; then
 				echo "$indent	w.WriteString(\"${choices[0]}\")";
 				freeChoice=true;
@@ -343,7 +357,7 @@ Loop:
 			break Loop
 		case '^':
 			t.Accept("^")
-			switch t.Next() {
+			switch n := t.Next(); n {
 			case -1:
 				d = append(d, '^')
 				break Loop
@@ -355,9 +369,10 @@ Loop:
 				d = append(d, '^')
 			default:
 				d = append(d, '^')
-				l := utf8.EncodeRune(ru[:], c)
+				l := utf8.EncodeRune(ru[:], n)
 				d = append(d, ru[:l]...)
 			}
+			t.Get()
 		}
 	}
 	return string(d)
@@ -380,6 +395,8 @@ Loop:
 		case '"':
 			d = append(d, '^', '\'')
 		}
+		t.Next()
+		t.Get()
 	}
 	return d
 }
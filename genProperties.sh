@@ -3,10 +3,38 @@
 source "names.sh";
 source "comments.sh";
 
+declare -A multipleParams;
+
+while read line; do
+	if [ "$(echo "$line" | cut -d'=' -f2 | cut -c1)" = "*" ]; then
+		multipleParams["$(echo "$line" | cut -d'=' -f1)"]=true;
+	fi;
+done < "params.gen";
+
+# requiredValue prints the VALUE param that must be encoded with the property.
+function requiredValue() {
+	case "$1" in
+	"CONFERENCE"|"IMAGE"|"LINK") echo -n "URI";;
+	"REFRESH-INTERVAL") echo -n "DURATION";;
+	"STRUCTURED-DATA"|"STYLED-DESCRIPTION") echo -n "TEXT";;
+	esac;
+}
+
+# embeddedValue succeeds when the first value of the property is embedded, and
+# used unless another value is set.
+function embeddedValue() {
+	case "$1" in
+	"RELATED-TO") return 0;;
+	esac;
+
+	return 1;
+}
+
 declare currProperty="";
 declare valueType=false;
 declare -a values;
 declare -a params;
+declare -A requiredParams;
 function printProperty() {
 	if [ -z "$currProperty" ]; then
 		return;
@@ -73,7 +101,7 @@ function printProperty() {
 			for i in $(seq $(( $longest - ${#n} ))); do
 				echo -n " ";
 			done;
-			if [ "$param" = "DELEGATED-FROM" -o "$param" = "DELEGATED-TO" -o "$param" = "MEMBER" ]; then
+			if [ -n "${multipleParams[$param]}" ]; then
 				echo "Param$n";
 			else
 				echo "*Param$n";
@@ -84,6 +112,9 @@ function printProperty() {
 		else
 			for value in ${values[@]}; do
 				local n=$(getName "$value");
+				if [ "$value" = "${values[0]}" ] && embeddedValue "$currProperty"; then
+					continue;
+				fi;
 				echo -n "	$n ";
 				for i in $(seq $(( $longest - ${#n} ))); do
 					echo -n " ";
@@ -94,7 +125,12 @@ function printProperty() {
 					echo "*$n";
 				fi;
 			done;
+			if embeddedValue "$currProperty"; then
+				echo "	$(getName "${values[0]}")";
+			fi;
 		fi;
+		echo;
+		echo "	Extensions ExtensionParams";
 		echo "}";
 	fi;
 	echo;
@@ -129,7 +165,7 @@ function printProperty() {
 			echo "				return fmt.Errorf(errDecodingProp, c$tName, c$tParam, ErrDuplicateParam)";
 			echo "			}";
 			echo;
-			if [ "$param" != "DELEGATED-FROM" -a "$param" != "DELEGATED-TO" -a "$param" != "MEMBER" ]; then
+			if [ -z "${multipleParams[$param]}" ]; then
 				echo "			p.$tParam = new(Param$tParam)";
 				echo;
 			fi;
@@ -159,6 +195,8 @@ function printProperty() {
 			echo "			}";
 		fi;
 		echo "		default:";
+		echo "			p.Extensions.decode(pName, pValues)";
+		echo;
 		echo "			for _, v := range pValues {";
 		echo "				ts = append(ts, v.Data)";
 		echo "			}";
@@ -168,6 +206,14 @@ function printProperty() {
 		echo "		}";
 		echo "	}";
 		echo;
+		for param in ${params[@]}; do
+			if [ -n "${requiredParams[$param]}" ]; then
+				echo "	if p.$(getName "$param") == nil {";
+				echo "		return fmt.Errorf(errDecodingProp, c$tName, c$(getName "$param"), ErrMissingRequired)";
+				echo "	}";
+				echo;
+			fi;
+		done;
 		if [ ${#values[@]} -gt 1 ]; then
 			echo "	if vType == -1 {";
 			echo "		vType = 0";
@@ -178,7 +224,9 @@ function printProperty() {
 			for value in ${values[@]}; do
 				local tValue="$(getName "$value")";
 				echo "	case $i:";
-				if [ "$value" != "Binary" -a "$value" != "MText" ]; then
+				if [ "$value" = "${values[0]}" ] && embeddedValue "$currProperty"; then
+					:;
+				elif [ "$value" != "Binary" -a "$value" != "MText" ]; then
 					echo "		p.$tValue = new($tValue)";
 					echo;
 				fi;
@@ -243,6 +291,9 @@ function printProperty() {
 	case $mode in
 	0)
 		echo "	w.WriteString(\"$currProperty\")";
+		if [ ${#values[@]} -eq 1 -a -n "$(requiredValue "$currProperty")" ]; then
+			echo "	w.WriteString(\";VALUE=$(requiredValue "$currProperty")\")";
+		fi;
 		for param in ${params[@]}; do
 			tParam="$(getName "$param")";
 			echo;
@@ -251,11 +302,25 @@ function printProperty() {
 			echo "	}";
 		done;
 		echo;
-		if [ ${#values[@]} -gt 1 ]; then
+		echo "	p.Extensions.encode(w)";
+		echo;
+		if [ ${#values[@]} -gt 1 ] && embeddedValue "$currProperty"; then
+			echo "	switch {";
+			for value in ${values[@]:1}; do
+				tValue="$(getName "$value")";
+				echo "	case p.$tValue != nil:";
+				echo "		w.WriteString(\";VALUE=$value\")";
+				echo "		p.${tValue}.aencode(w)";
+			done;
+			echo "	default:";
+			echo "		p.$(getName "${values[0]}").aencode(w)";
+			echo "	}";
+			echo;
+		elif [ ${#values[@]} -gt 1 ]; then
 			for value in ${values[@]}; do
 				tValue="$(getName "$value")";
 				echo "	if p.$tValue != nil {";
-				if [ "$value" != "${values[0]}" ]; then
+				if [ "$value" != "${values[0]}" -o -n "$(requiredValue "$currProperty")" ]; then
 					echo "		w.WriteString(\";VALUE=$value\")";
 				fi;
 				echo "		p.${tValue}.aencode(w)";
@@ -266,7 +331,8 @@ function printProperty() {
 			echo "	p.$(getName "${values[0]}").aencode(w)";
 		fi;;
 	1)
-		echo "	w.WriteString(\"$currProperty:\")";
+		echo "	w.WriteString(\"$currProperty\")";
+		echo "	w.WriteString(\":\")";
 		echo;
 		echo "	switch *p {";
 		for value in ${values[@]}; do
@@ -277,6 +343,9 @@ function printProperty() {
 		echo;;
 	2)
 		echo "	w.WriteString(\"$currProperty\")";
+		if [ -n "$(requiredValue "$currProperty")" ]; then
+			echo "	w.WriteString(\";VALUE=$(requiredValue "$currProperty")\")";
+		fi;
 		echo;
 		echo "	t := ${values[0]}(*p)";
 		echo;
@@ -290,16 +359,45 @@ function printProperty() {
 	echo "func (p *Prop$tName) valid() error {";
 	case $mode in
 	0)
+		echo "	if err := p.Extensions.valid(); err != nil {";
+		echo "		return fmt.Errorf(errValidatingType, c$tName, err)";
+		echo "	}";
+		echo;
 		for param in ${params[@]}; do
 			tParam="$(getName "$param")";
 			echo "	if p.$tParam != nil {";
 			echo "		if err := p.${tParam}.valid(); err != nil {";
 			echo "			return fmt.Errorf(errValidatingProp, c$tName, c$tParam, err)";
 			echo "		}";
+			if [ -n "${requiredParams[$param]}" ]; then
+				echo "	} else {";
+				echo "		return fmt.Errorf(errValidatingProp, c$tName, c$tParam, ErrMissingRequired)";
+			fi;
 			echo "	}";
 			echo;
 		done;
-		if [ ${#values[@]} -gt 1 ]; then
+		if [ ${#values[@]} -gt 1 ] && embeddedValue "$currProperty"; then
+			echo "	c := 0";
+			echo;
+			for value in ${values[@]:1}; do
+				tValue="$(getName "$value")";
+				echo "	if p.$tValue != nil {";
+				echo "		if err := p.${tValue}.valid(); err != nil {";
+				echo "			return fmt.Errorf(errValidatingProp, c$tName, c$tValue, err)";
+				echo "		}";
+				echo;
+				echo "		c++";
+				echo "	}";
+				echo;
+			done;
+			echo "	if c > 1 {";
+			echo "		return fmt.Errorf(errValidatingType, c$tName, ErrInvalidValue)";
+			echo "	} else if c == 0 {";
+			echo "		if err := p.$(getName "${values[0]}").valid(); err != nil {";
+			echo "			return fmt.Errorf(errValidatingProp, c$tName, c$(getName "${values[0]}"), err)";
+			echo "		}";
+			echo "	}";
+		elif [ ${#values[@]} -gt 1 ]; then
 			echo "	c := 0";
 			echo;
 			for value in ${values[@]}; do
@@ -357,6 +455,7 @@ function printProperty() {
 	valueType=false;
 	values=();
 	params=();
+	requiredParams=();
 }
 
 (
@@ -375,7 +474,7 @@ function printProperty() {
 	{
 		IFS="
 		";
-		while read line; do
+		while IFS= read -r line; do
 			if [ "${line:0:1}" != "	" ]; then
 				printProperty;
 				currProperty="$(echo "$line" | cut -d':' -f1)";
@@ -385,12 +484,65 @@ function printProperty() {
 					vs="${vs:1}";
 				fi;
 				values=( $(echo -n "$vs" | tr "|" "\n") );
+			elif [ "${line:1:1}" = "!" ]; then
+				params[${#params[@]}]="${line:2}";
+				requiredParams["${line:2}"]=true;
 			else
 				params[${#params[@]}]="${line:1}";
 			fi;
 		done;
 	} < "properties.gen";
 	printProperty;
+	echo "// valueType returns the value type of the named property, when it only has";
+	echo "// one.";
+	echo "func valueType(property string) string {";
+	echo "	switch property {";
+	{
+		declare -A types;
+		declare -a order;
+		while IFS= read -r line; do
+			if [ "${line:0:1}" == "	" ]; then
+				continue;
+			fi;
+			keyword="$(echo "$line" | cut -d':' -f1)";
+			keyword="${keyword##*#}";
+			vs="$(echo "$line" | cut -d':' -f2)";
+			if [ "${vs:0:1}" != "!" ]; then
+				vType="TEXT";
+			elif [ -n "$(echo "$vs" | grep "|")" ]; then
+				continue;
+			else
+				case "${vs:1}" in
+				"Boolean") vType="BOOLEAN";;
+				"CalendarAddress") vType="CAL-ADDRESS";;
+				"DateTime") vType="DATE-TIME";;
+				"Duration") vType="DURATION";;
+				"Integer") vType="INTEGER";;
+				"Period"|"MPeriod") vType="PERIOD";;
+				"Recur") vType="RECUR";;
+				"TFloat") vType="FLOAT";;
+				"URI") vType="URI";;
+				"UTCOffset") vType="UTC-OFFSET";;
+				*) vType="TEXT";;
+				esac;
+			fi;
+			if [ -z "${types[$vType]}" ]; then
+				order[${#order[@]}]="$vType";
+				types[$vType]="\"$keyword\"";
+			elif [ -z "$(echo "${types[$vType]}" | grep "\"$keyword\"")" ]; then
+				types[$vType]="${types[$vType]}, \"$keyword\"";
+			fi;
+		done;
+		for vType in "${order[@]}"; do
+			echo "	case ${types[$vType]}:";
+			echo "		return \"$vType\"";
+		done;
+	} < "properties.gen";
+	echo "	}";
+	echo;
+	echo "	return \"\"";
+	echo "}";
+	echo;
 	echo "// Errors..";
 	echo "var (";
 	echo "	ErrDuplicateParam = errors.New(\"duplicate param\")";
@@ -400,12 +552,12 @@ function printProperty() {
 	echo "	errDecodingProp      = \"error decoding %s->%s: %w\"";
 	echo "	errValidatingProp    = \"error validating %s->%s: %w\"";
 	{
-		while read line; do
+		while IFS= read -r line; do
 			if [ "${line:0:1}" == "	" ]; then
 				continue;
 			fi;
 			keyword="$(echo "$line" | cut -d':' -f1 | cut -d'#' -f1)";
-			if [ "$keyword" = "TZID" -o "$keyword" = "URI" ]; then
+			if [ "$keyword" = "TZID" -o "$keyword" = "URI" -o "$keyword" = "CALENDAR-ADDRESS" ]; then
 				continue;
 			fi;
 			type="$(getName "$keyword")";
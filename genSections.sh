@@ -7,6 +7,34 @@ declare -a currSection;
 declare -a requirements;
 declare sectionName;
 declare longest=0;
+declare -A paramless;
+
+{
+	declare property="";
+	declare hasParams=false;
+	while IFS= read -r line; do
+		if [ "${line:0:1}" = "	" ]; then
+			hasParams=true;
+			continue;
+		fi;
+		if [ -n "$property" ] && ! $hasParams; then
+			paramless[$property]=true;
+		fi;
+		property="$(getName "$(echo "$line" | cut -d':' -f1 | cut -d'#' -f1)")";
+		hasParams=false;
+		vs="$(echo "$line" | cut -d':' -f2)";
+		if [ "${vs:0:1}" = "!" ] && [ -n "$(echo "$vs" | grep "|")" ]; then
+			hasParams=true;
+		fi;
+	done;
+	if ! $hasParams; then
+		paramless[$property]=true;
+	fi;
+} < properties.gen;
+
+function isParamless() {
+	[ "${paramless[$1]}" = "true" ];
+}
 
 function addToSection() {
 	declare name="$(getName "${1%#*}")";
@@ -23,20 +51,23 @@ function printSection() {
 	# type declaration
 	getComment "$sName";
 	declare checkRequired=false;
-	if [ ${#currSection[@]} -eq 0 ]; then
-		echo "type $sName struct{}";
-	else
-		echo "type $sName struct {";
+	declare hasParamless=false;
+	echo "type $sName struct {";
+	if [ ${#currSection[@]} -gt 0 ]; then
 		IFS="$OFS";
 		for tline in "${currSection[@]}"; do
 			aline=( $tline ); # 0:name 1:KEYWORD 2:required 3:multiple 4:section 5:requiredAlso 6:requiredInstead
 			name="${aline[0]}";
+			keyword="${aline[1]}";
 			required=${aline[2]};
 			multiple=${aline[3]};
 			section=${aline[4]};
 			if $required; then
 				checkRequired=true;
 			fi;
+			if ! $section && isParamless "$name"; then
+				hasParamless=true;
+			fi;
 			echo -n "	$name ";
 			for i in $(seq $(( $longest - ${#name} ))); do
 				echo -n " ";
@@ -46,13 +77,24 @@ function printSection() {
 			elif ! $required; then
 				echo -n "*";
 			fi;
-			if ! $section; then
-				echo -n "Prop";
+			if $section; then
+				getName "$keyword";
+				echo;
+			else
+				echo "Prop$name";
 			fi;
-			echo "$name";
 		done;
-		echo "}";
+		echo;
 	fi;
+	if $hasParamless; then
+		echo "	Extensions     ExtensionProperties";
+		echo "	PropertyParams PropertyParams";
+		echo "	Components     []Component";
+	else
+		echo "	Extensions ExtensionProperties";
+		echo "	Components []Component";
+	fi;
+	echo "}";
 	echo;
 
 	# decoder
@@ -89,11 +131,11 @@ function printSection() {
 	echo "			return fmt.Errorf(errDecodingType, c$sName, io.ErrUnexpectedEOF)";
 	echo "		}";
 	echo;
-	if [ ${#currSection[@]} -gt 0 ]; then
-		echo "		params := p.Data[1 : len(p.Data)-1]";
-	fi;
+	echo "		params := p.Data[1 : len(p.Data)-1]";
 	echo "		value := p.Data[len(p.Data)-1].Data";
 	echo;
+	echo "		checkProperty(t, p.Data[0].Data, params, value)";
+	echo;
 	echo "		switch strings.ToUpper(p.Data[0].Data) {";
 	echo "		case \"BEGIN\":";
 	echo "			switch n := strings.ToUpper(value); n {";
@@ -109,41 +151,46 @@ function printSection() {
 		if ! $section; then
 			continue;
 		fi;
+		typ="$(getName "$keyword")";
 		echo "			case \"$keyword\":";
 		if $required && ! $multiple; then
 			echo "				if required$name {";
-			echo "					return fmt.Errorf(errMultiple, c$sName, c$name)";
+			echo "					return fmt.Errorf(errMultiple, c$sName, ErrDuplicateProperty, c$typ)";
 			echo "				}";
 			echo;
 			echo "				required$name = true";
 			echo;
 			echo "				if err := s.${name}.decode(t); err != nil {";
-			echo "					return fmt.Errorf(errDecodingProp, c$sName, c$name, err)";
+			echo "					return fmt.Errorf(errDecodingProp, c$sName, c$typ, err)";
 			echo "				}";
 		elif $multiple; then
-			echo "				var e $name";
+			echo "				var e $typ";
 			echo;
 			echo "				if err := e.decode(t); err != nil {";
-			echo "					return fmt.Errorf(errDecodingProp, c$sName, c$name, err)";
+			echo "					return fmt.Errorf(errDecodingProp, c$sName, c$typ, err)";
 			echo "				}";
 			echo;
 			echo "				s.$name = append(s.$name, e)";
 		else
 			echo "				if s.$name != nil {";
-			echo "					return fmt.Errorf(errMultiple, c$sName, c$name)";
+			echo "					return fmt.Errorf(errMultiple, c$sName, ErrDuplicateProperty, c$typ)";
 			echo "				}";
 			echo;
-			echo "				s.$name = new($name)";
+			echo "				s.$name = new($typ)";
 			echo;
 			echo "				if err := s.${name}.decode(t); err != nil {";
-			echo "					return fmt.Errorf(errDecodingProp, c$sName, c$name, err)";
+			echo "					return fmt.Errorf(errDecodingProp, c$sName, c$typ, err)";
 			echo "				}";
 		fi;
 	done;
 	echo "			default:";
-	echo "				if err := decodeDummy(t, n); err != nil {";
+	echo "				var c Component";
+	echo;
+	echo "				if err := c.decode(t, n); err != nil {";
 	echo "					return fmt.Errorf(errDecodingType, c$sName, err)";
 	echo "				}";
+	echo;
+	echo "				s.Components = append(s.Components, c)";
 	echo "			}";
 
 	# non-BEGIN keywords
@@ -160,7 +207,11 @@ function printSection() {
 		echo "		case \"$keyword\":";
 		if $required && ! $multiple; then
 			echo "			if required$name {";
-			echo "				return fmt.Errorf(errMultiple, c$sName, c$name)";
+			echo "				if err := recoverable(t, fmt.Errorf(errMultiple, c$sName, ErrDuplicateProperty, c$name)); err != nil {";
+			echo "					return err";
+			echo "				}";
+			echo;
+			echo "				continue";
 			echo "			}";
 			echo;
 			echo "			required$name = true";
@@ -172,32 +223,57 @@ function printSection() {
 			echo "			var e Prop$name";
 			echo;
 			echo "			if err := e.decode(params, value); err != nil {";
-			echo "				return fmt.Errorf(errDecodingProp, c$sName, c$name, err)";
+			echo "				if err = recoverable(t, fmt.Errorf(errDecodingProp, c$sName, c$name, err)); err != nil {";
+			echo "					return err";
+			echo "				}";
+			echo;
+			echo "				continue";
 			echo "			}";
 			echo;
 			echo "			s.$name = append(s.$name, e)";
 		else
 			echo "			if s.$name != nil {";
-			echo "				return fmt.Errorf(errMultiple, c$sName, c$name)";
+			echo "				if err := recoverable(t, fmt.Errorf(errMultiple, c$sName, ErrDuplicateProperty, c$name)); err != nil {";
+			echo "					return err";
+			echo "				}";
+			echo;
+			echo "				continue";
 			echo "			}";
 			echo;
 			echo "			s.$name = new(Prop$name)";
 			echo;
 			echo "			if err := s.${name}.decode(params, value); err != nil {";
-			echo "				return fmt.Errorf(errDecodingProp, c$sName, c$name, err)";
+			echo "				if err = recoverable(t, fmt.Errorf(errDecodingProp, c$sName, c$name, err)); err != nil {";
+			echo "					return err";
+			echo "				}";
+			echo;
+			echo "				s.$name = nil";
+			if isParamless "$name"; then
+				echo;
+				echo "				continue";
+			fi;
 			echo "			}";
 		fi;
+		if isParamless "$name"; then
+			echo;
+			echo "			s.PropertyParams.decode(\"$keyword\", params)";
+		fi;
 	done;
+	if [ "${sectionName:0:6}" = "VALARM" ]; then
+		echo "		case \"ACTION\":";
+	fi;
 	echo "		case \"END\":";
 	if [ "${sectionName:0:6}" = "VALARM" ]; then
-		echo "			if value != \"VALARM\" {";
+		echo "			if err := endValue(t, value, \"VALARM\"); err != nil {";
 	else
-		echo "			if value != \"$sectionName\" {";
+		echo "			if err := endValue(t, value, \"$sectionName\"); err != nil {";
 	fi;
-	echo "				return fmt.Errorf(errDecodingType, c$sName, ErrInvalidEnd)";
+	echo "				return fmt.Errorf(errDecodingType, c$sName, err)";
 	echo "			}";
 	echo;
 	echo "			break Loop";
+	echo "		default:";
+	echo "			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})";
 	echo "		}";
 	echo "	}";
 
@@ -299,32 +375,52 @@ function printSection() {
 		echo "	w.WriteString(\"BEGIN:$sectionName\r\n\")";
 	fi;
 	declare nl=false;
-	for tline in "${currSection[@]}"; do
-		aline=( $tline ); # 0:name 1:KEYWORD 2:required 3:multiple 4:section 5:requiredAlso 6:requiredInstead
-		name="${aline[0]}";
-		required=${aline[2]};
-		multiple=${aline[3]};
-		if $multiple; then
-			echo;
-			echo "	for n := range s.$name {";
-			echo "		s.$name[n].encode(w)";
-			echo "	}";
-			nl=true;
-		elif $required; then
-			echo "	s.${name}.encode(w)";
-			nl=false;
-		else
-			echo;
-			echo "	if s.$name != nil {";
-			echo "		s.${name}.encode(w)";
-			echo "	}";
+	for pass in false true; do
+		if $pass; then
+			if $nl; then
+				echo;
+			fi;
+			echo "	s.Extensions.encode(w)";
 			nl=true;
 		fi;
+		for tline in "${currSection[@]}"; do
+			aline=( $tline ); # 0:name 1:KEYWORD 2:required 3:multiple 4:section 5:requiredAlso 6:requiredInstead
+			name="${aline[0]}";
+			keyword="${aline[1]}";
+			required=${aline[2]};
+			multiple=${aline[3]};
+			section=${aline[4]};
+			if [ "$section" != "$pass" ]; then
+				continue;
+			fi;
+			declare ew="w";
+			if ! $section && isParamless "$name"; then
+				ew="s.PropertyParams.writer(w, \"$keyword\")";
+			fi;
+			if $multiple; then
+				echo;
+				echo "	for n := range s.$name {";
+				echo "		s.$name[n].encode($ew)";
+				echo "	}";
+				nl=true;
+			elif $required; then
+				echo "	s.${name}.encode($ew)";
+				nl=false;
+			else
+				echo;
+				echo "	if s.$name != nil {";
+				echo "		s.${name}.encode($ew)";
+				echo "	}";
+				nl=true;
+			fi;
+		done;
 	done;
-	if $nl; then
-		echo;
-	fi;
+	echo;
+	echo "	for n := range s.Components {";
+	echo "		s.Components[n].encode(w)";
+	echo "	}";
 	if [ "${sectionName:0:6}" != "VALARM" ]; then
+		echo;
 		echo "	w.WriteString(\"END:$sectionName\r\n\")";
 	fi;
 	echo "}";
@@ -335,29 +431,51 @@ function printSection() {
 	for tline in "${currSection[@]}"; do
 		aline=( $tline ); # 0:name 1:KEYWORD 2:required 3:multiple 4:section 5:requiredAlso 6:requiredInstead
 		name="${aline[0]}";
+		keyword="${aline[1]}";
 		required=${aline[2]};
 		multiple=${aline[3]};
+		section=${aline[4]};
+		typ="$name";
+		if $section; then
+			typ="$(getName "$keyword")";
+		fi;
 		if $multiple; then
 			echo "	for n := range s.$name {";
 			echo "		if err := s.$name[n].valid(); err != nil {";
-			echo "			return fmt.Errorf(errValidatingProp, c$sName, c$name, err)";
+			echo "			return fmt.Errorf(errValidatingProp, c$sName, c$typ, err)";
 			echo "		}";
 			echo "	}";
 		else
 			if $required; then
 				echo "	if err := s.${name}.valid(); err != nil {";
-				echo "		return fmt.Errorf(errValidatingProp, c$sName, c$name, err)";
+				echo "		return fmt.Errorf(errValidatingProp, c$sName, c$typ, err)";
 				echo "	}";
 			else
 				echo "	if s.$name != nil {";
 				echo "		if err := s.${name}.valid(); err != nil {";
-				echo "			return fmt.Errorf(errValidatingProp, c$sName, c$name, err)";
+				echo "			return fmt.Errorf(errValidatingProp, c$sName, c$typ, err)";
 				echo "		}";
 				echo "	}";
 			fi;
 		fi;
 		echo;
 	done;
+	echo "	if err := s.Extensions.valid(); err != nil {";
+	echo "		return fmt.Errorf(errValidatingType, c$sName, err)";
+	echo "	}";
+	echo;
+	if $hasParamless; then
+		echo "	if err := s.PropertyParams.valid(); err != nil {";
+		echo "		return fmt.Errorf(errValidatingType, c$sName, err)";
+		echo "	}";
+		echo;
+	fi;
+	echo "	for n := range s.Components {";
+	echo "		if err := s.Components[n].valid(); err != nil {";
+	echo "			return fmt.Errorf(errValidatingType, c$sName, err)";
+	echo "		}";
+	echo "	}";
+	echo;
 	echo "	return nil";
 	echo "}";
 	echo;
@@ -401,13 +519,13 @@ OFS="$IFS";
 			if [ "$fc" = "?" ]; then
 				field="${field:1}";
 				if [ ! -z "$(echo "$field" | grep "!")" ]; then
-					requirements[${#requirements}]="ONE $(echo "$field" | tr "!" " ")";
+					requirements[${#requirements[@]}]="ONE $(echo "$field" | tr "!" " ")";
 				elif [ ! -z "$(echo "$field" | grep "+")" ]; then
-					requirements[${#requirements}]="AND $(echo "$field" | tr "+" " ")";
+					requirements[${#requirements[@]}]="AND $(echo "$field" | tr "+" " ")";
 				elif [ ! -z "$(echo "$field" | grep ">")" ]; then
-					requirements[${#requirements}]="ERGO $(echo "$field" | tr ">" " ")";
+					requirements[${#requirements[@]}]="ERGO $(echo "$field" | tr ">" " ")";
 				elif [ ! -z "$(echo "$field" | grep "|")" ]; then
-					requirements[${#requirements}]="OR $(echo "$field" | tr "|" " ")";
+					requirements[${#requirements[@]}]="OR $(echo "$field" | tr "|" " ")";
 				fi;
 				continue;
 			elif [ "$fc" = "!" ]; then
@@ -432,43 +550,19 @@ OFS="$IFS";
 	} < sections.gen;
 	printSection;
 	cat <<HEREDOC
-// decodeDummy reads unknown sections, discarding the data.
-func decodeDummy(t tokeniser, n string) error {
-	for {
-		p, err := t.GetPhrase()
-		if err != nil {
-			return err
-		} else if p.Type == parser.PhraseDone {
-			return io.ErrUnexpectedEOF
-		}
-
-		switch strings.ToUpper(p.Data[0].Data) {
-		case "BEGIN":
-			if err := decodeDummy(t, p.Data[len(p.Data)-1].Data); err != nil {
-				return err
-			}
-		case "END":
-			if strings.ToUpper(p.Data[len(p.Data)-1].Data) == n {
-				return nil
-			}
-
-			return ErrInvalidEnd
-		}
-	}
-}
-
 // Errors.
 var (
+	ErrDuplicateProperty = errors.New("duplicate property")
 	ErrInvalidEnd        = errors.New("invalid end of section")
 	ErrMissingRequired   = errors.New("required property missing")
 	ErrRequirementNotMet = errors.New("requirement not met")
 )
 
 const (
-	errMultiple   = "error decoding %s: multiple %s"
+	errMultiple   = "error decoding %s: %w: %s"
 HEREDOC
 	while read line; do
-		if [ "${line:0:1}" = "	" -o "$line" = "VFREEBUSY" ]; then
+		if [ "${line:0:1}" = "	" -o "$line" = "VFREEBUSY" -o "$line" = "VLOCATION" ]; then
 			continue;
 		fi;
 		type="$(getName $line)";
@@ -0,0 +1,253 @@
+package ics
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// IMIP wraps iTIP messages in email messages, as described by iMIP
+// (RFC 6047).
+type IMIP struct {
+	From    *mail.Address
+	To      []*mail.Address
+	Subject string
+
+	// Text is the plain-text alternative to the calendar. When empty, a
+	// summary of the first Event or Todo is used.
+	Text string
+
+	// Now returns the time used for the Date header. When nil, time.Now is
+	// used.
+	Now func() time.Time
+
+	// Boundary, when set, is used as the multipart boundary instead of a
+	// randomly generated one.
+	Boundary string
+}
+
+// Encode writes a complete email message to the given writer, with a
+// multipart/alternative body containing a plain-text part and a text/calendar
+// part holding the given Calendar, which must have a Method.
+func (i *IMIP) Encode(w io.Writer, c *Calendar) error {
+	if c.Method == nil {
+		return ErrInvalidMethod
+	}
+
+	var cal bytes.Buffer
+
+	if err := Encode(&cal, c); err != nil {
+		return err
+	}
+
+	text := i.Text
+	if text == "" {
+		text = imipText(c)
+	}
+
+	now := time.Now
+
+	if i.Now != nil {
+		now = i.Now
+	}
+
+	var (
+		buf bytes.Buffer
+		mw  = multipart.NewWriter(&buf)
+	)
+
+	if i.Boundary != "" {
+		if err := mw.SetBoundary(i.Boundary); err != nil {
+			return err
+		}
+	}
+
+	if err := writeIMIPPart(mw, "text/plain; charset=UTF-8", []byte(text)); err != nil {
+		return err
+	} else if err := writeIMIPPart(mw, "text/calendar; method="+string(*c.Method)+"; charset=UTF-8", cal.Bytes()); err != nil {
+		return err
+	} else if err := mw.Close(); err != nil {
+		return err
+	}
+
+	var header strings.Builder
+
+	if i.From != nil {
+		header.WriteString("From: " + i.From.String() + "\r\n")
+	}
+
+	if len(i.To) > 0 {
+		to := make([]string, len(i.To))
+
+		for n, a := range i.To {
+			to[n] = a.String()
+		}
+
+		header.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	}
+
+	header.WriteString("Subject: " + mime.QEncoding.Encode("UTF-8", i.Subject) + "\r\n")
+	header.WriteString("Date: " + now().Format(time.RFC1123Z) + "\r\n")
+	header.WriteString("MIME-Version: 1.0\r\n")
+	header.WriteString("Content-Type: multipart/alternative; boundary=\"" + mw.Boundary() + "\"\r\n\r\n")
+
+	if _, err := io.WriteString(w, header.String()); err != nil {
+		return err
+	}
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+func writeIMIPPart(mw *multipart.Writer, contentType string, data []byte) error {
+	encoding := transferEncoding(data)
+
+	p, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {encoding},
+	})
+	if err != nil {
+		return err
+	}
+
+	if encoding == "7bit" {
+		_, err = p.Write(data)
+
+		return err
+	}
+
+	qp := quotedprintable.NewWriter(p)
+
+	if _, err = qp.Write(data); err != nil {
+		return err
+	}
+
+	return qp.Close()
+}
+
+// transferEncoding returns 7bit when the data is ASCII with no line longer
+// than allowed by RFC 5322, and quoted-printable otherwise.
+func transferEncoding(data []byte) string {
+	var line int
+
+	for _, b := range data {
+		if b == '\n' {
+			line = 0
+		} else if line++; b >= 0x80 || b == 0 || line > 998 {
+			return "quoted-printable"
+		}
+	}
+
+	return "7bit"
+}
+
+// imipText returns a plain-text summary of the first Event or Todo of the
+// Calendar.
+func imipText(c *Calendar) string {
+	var (
+		sb        strings.Builder
+		summary   *PropSummary
+		location  *PropLocation
+		start     *PropDateTimeStart
+		organizer *PropOrganizer
+	)
+
+	if len(c.Event) > 0 {
+		e := &c.Event[0]
+		summary, location, start, organizer = e.Summary, e.Location, e.DateTimeStart, e.Organizer
+	} else if len(c.Todo) > 0 {
+		t := &c.Todo[0]
+		summary, location, start, organizer = t.Summary, t.Location, t.DateTimeStart, t.Organizer
+	}
+
+	if summary != nil {
+		sb.WriteString("Summary: " + string(summary.Text) + "\r\n")
+	}
+
+	if start != nil {
+		if t, date := startTime(start.DateTime, start.Date); date {
+			sb.WriteString("Start: " + t.Format("Mon, 02 Jan 2006") + "\r\n")
+		} else {
+			sb.WriteString("Start: " + t.Format(time.RFC1123) + "\r\n")
+		}
+	}
+
+	if location != nil {
+		sb.WriteString("Location: " + string(location.Text) + "\r\n")
+	}
+
+	if organizer != nil {
+		if organizer.CommonName != nil {
+			sb.WriteString("Organizer: " + string(*organizer.CommonName) + " <" + organizer.URL.Opaque + ">\r\n")
+		} else {
+			sb.WriteString("Organizer: " + organizer.URL.Opaque + "\r\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// DecodeIMIP reads an email message, such as an .eml file, and decodes every
+// text/calendar part found within it.
+func DecodeIMIP(r io.Reader) ([]*Calendar, error) {
+	m, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var cals []*Calendar
+
+	if err := decodeIMIPPart(textproto.MIMEHeader(m.Header), m.Body, &cals); err != nil {
+		return nil, err
+	}
+
+	return cals, nil
+}
+
+func decodeIMIPPart(h textproto.MIMEHeader, body io.Reader, cals *[]*Calendar) error {
+	mediaType, params, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		return nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(h.Get("Content-Transfer-Encoding"))) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+
+		for {
+			p, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+
+			if err := decodeIMIPPart(p.Header, p, cals); err != nil {
+				return err
+			}
+		}
+	} else if mediaType == "text/calendar" {
+		c, err := Decode(body)
+		if err != nil {
+			return err
+		}
+
+		*cals = append(*cals, c)
+	}
+
+	return nil
+}
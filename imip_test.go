@@ -0,0 +1,128 @@
+package ics
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIMIP(t *testing.T) {
+	cal, err := Decode(strings.NewReader(strings.Replace(itipEvent, "SUMMARY:Meeting", "SUMMARY:Café", 1)))
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	}
+
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	m, err := (&ITIP{ProductID: "TEST", Now: func() time.Time { return now }}).Request(&cal.Event[0], false)
+	if err != nil {
+		t.Fatalf("unexpected error building message: %s", err)
+	}
+
+	i := IMIP{
+		From:     &mail.Address{Name: "Alice", Address: "alice@example.com"},
+		To:       []*mail.Address{{Address: "bob@example.com"}, {Address: "carol@example.com"}},
+		Subject:  "Invitation: Café",
+		Now:      func() time.Time { return now },
+		Boundary: "BOUNDARY",
+	}
+
+	var sb strings.Builder
+
+	if err := i.Encode(&sb, m); err != nil {
+		t.Fatalf("unexpected error encoding message: %s", err)
+	}
+
+	eml := sb.String()
+
+	for n, expected := range [...]string{
+		"From: \"Alice\" <alice@example.com>\r\n",
+		"To: <bob@example.com>, <carol@example.com>\r\n",
+		"Subject: =?UTF-8?q?Invitation:_Caf=C3=A9?=\r\n",
+		"Date: Thu, 02 Jan 2020 03:04:05 +0000\r\n",
+		"MIME-Version: 1.0\r\n",
+		"Content-Type: multipart/alternative; boundary=\"BOUNDARY\"\r\n\r\n",
+		"Content-Type: text/plain; charset=UTF-8\r\n",
+		"Summary: Caf=C3=A9\r\nStart: Mon, 06 Jan 2020 10:00:00 UTC\r\nOrganizer: alice@example.com\r\n",
+		"Content-Type: text/calendar; method=REQUEST; charset=UTF-8\r\n",
+		"Content-Transfer-Encoding: quoted-printable\r\n",
+		"SUMMARY:Caf=C3=A9\r\n",
+		"--BOUNDARY--",
+	} {
+		if !strings.Contains(eml, expected) {
+			t.Errorf("test %d: expecting message to contain %q, got:\n%s", n+1, expected, eml)
+		}
+	}
+
+	cals, err := DecodeIMIP(strings.NewReader(eml))
+	if err != nil {
+		t.Fatalf("unexpected error decoding message: %s", err)
+	} else if len(cals) != 1 {
+		t.Fatalf("expecting 1 calendar, got %d", len(cals))
+	} else if *cals[0].Method != MethodRequest {
+		t.Errorf("expecting method REQUEST, got %s", *cals[0].Method)
+	} else if s := cals[0].Event[0].Summary.Text; s != "Café" {
+		t.Errorf("expecting summary %q, got %q", "Café", s)
+	}
+
+	m.Method = nil
+
+	if err := i.Encode(&sb, m); !errors.Is(err, ErrInvalidMethod) {
+		t.Errorf("expecting error ErrInvalidMethod, got %v", err)
+	}
+}
+
+func TestDecodeIMIP(t *testing.T) {
+	cancel := strings.Replace(strings.Replace(itipEvent, "VERSION:2.0\r\n", "VERSION:2.0\r\nMETHOD:CANCEL\r\n", 1), "SEQUENCE:1", "SEQUENCE:2", 1)
+
+	eml := "From: alice@example.com\r\n" +
+		"To: bob@example.com\r\n" +
+		"Subject: Updates\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/mixed; boundary=outer\r\n" +
+		"\r\n" +
+		"--outer\r\n" +
+		"Content-Type: multipart/alternative; boundary=inner\r\n" +
+		"\r\n" +
+		"--inner\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"The meeting has been cancelled.\r\n" +
+		"--inner\r\n" +
+		"Content-Type: text/calendar; method=CANCEL; charset=UTF-8\r\n" +
+		"Content-Transfer-Encoding: base64\r\n" +
+		"\r\n" +
+		base64.StdEncoding.EncodeToString([]byte(cancel)) + "\r\n" +
+		"--inner--\r\n" +
+		"--outer\r\n" +
+		"Content-Type: application/pdf\r\n" +
+		"Content-Transfer-Encoding: base64\r\n" +
+		"\r\n" +
+		"JVBERi0xLjQK\r\n" +
+		"--outer\r\n" +
+		"Content-Type: TEXT/CALENDAR; charset=UTF-8\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		strings.Replace(strings.ReplaceAll(itipEvent, "=", "=3D"), "SUMMARY:Meeting", "SUMMARY:Caf=C3=A9", 1) +
+		"--outer--\r\n"
+
+	cals, err := DecodeIMIP(strings.NewReader(eml))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if len(cals) != 2 {
+		t.Fatalf("expecting 2 calendars, got %d", len(cals))
+	} else if cals[0].Method == nil || *cals[0].Method != MethodCancel {
+		t.Errorf("expecting first calendar to have method CANCEL")
+	} else if *cals[0].Event[0].Sequence != 2 {
+		t.Errorf("expecting SEQUENCE 2, got %d", *cals[0].Event[0].Sequence)
+	} else if s := cals[1].Event[0].Summary.Text; s != "Café" {
+		t.Errorf("expecting summary %q, got %q", "Café", s)
+	}
+
+	if _, err := DecodeIMIP(strings.NewReader("Content-Type: text/calendar\r\n\r\nBEGIN:VCALENDAR\r\n")); err == nil {
+		t.Errorf("expecting error decoding invalid calendar")
+	}
+}
@@ -0,0 +1,408 @@
+package ics
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// iTIP (RFC 5546) methods.
+const (
+	MethodPublish        PropMethod = "PUBLISH"
+	MethodRequest        PropMethod = "REQUEST"
+	MethodReply          PropMethod = "REPLY"
+	MethodAdd            PropMethod = "ADD"
+	MethodCancel         PropMethod = "CANCEL"
+	MethodRefresh        PropMethod = "REFRESH"
+	MethodCounter        PropMethod = "COUNTER"
+	MethodDeclineCounter PropMethod = "DECLINECOUNTER"
+)
+
+// ITIP creates iTIP (RFC 5546) scheduling messages from an *Event or *Todo.
+//
+// Each message is a new Calendar, with the Method set, containing a copy of
+// the component, and so the component passed in is not modified unless stated
+// otherwise.
+type ITIP struct {
+	ProductID PropProductID
+
+	// Timezone contains the Timezones that will be added to each message.
+	Timezone []Timezone
+
+	// Now returns the time used for the DateTimeStamp of each message. When
+	// nil, time.Now is used.
+	Now func() time.Time
+}
+
+// scheduling provides access to the properties of an Event or Todo used by
+// iTIP.
+type scheduling struct {
+	component     section
+	name          string
+	uid           *PropUID
+	stamp         *PropDateTimeStamp
+	organizer     **PropOrganizer
+	attendee      *[]PropAttendee
+	sequence      **PropSequence
+	recurrenceID  **PropRecurrenceID
+	status        **PropStatus
+	requestStatus *[]PropRequestStatus
+	alarm         *[]Alarm
+	start         **PropDateTimeStart
+	rrule         **PropRecurrenceRule
+	rdates        *[]PropRecurrenceDateTimes
+	exdates       *[]PropExceptionDateTime
+}
+
+func schedulingOf(c interface{}) (scheduling, bool) {
+	switch c := c.(type) {
+	case *Event:
+		return scheduling{
+			component:     c,
+			name:          cEvent,
+			uid:           &c.UID,
+			stamp:         &c.DateTimeStamp,
+			organizer:     &c.Organizer,
+			attendee:      &c.Attendee,
+			sequence:      &c.Sequence,
+			recurrenceID:  &c.RecurrenceID,
+			status:        &c.Status,
+			requestStatus: &c.RequestStatus,
+			alarm:         &c.Alarm,
+			start:         &c.DateTimeStart,
+			rrule:         &c.RecurrenceRule,
+			rdates:        &c.RecurrenceDateTimes,
+			exdates:       &c.ExceptionDateTime,
+		}, true
+	case *Todo:
+		return scheduling{
+			component:     c,
+			name:          cTodo,
+			uid:           &c.UID,
+			stamp:         &c.DateTimeStamp,
+			organizer:     &c.Organizer,
+			attendee:      &c.Attendee,
+			sequence:      &c.Sequence,
+			recurrenceID:  &c.RecurrenceID,
+			status:        &c.Status,
+			requestStatus: &c.RequestStatus,
+			alarm:         &c.Alarm,
+			start:         &c.DateTimeStart,
+			rrule:         &c.RecurrenceRule,
+			rdates:        &c.RecurrenceDateTimes,
+			exdates:       &c.ExceptionDateTime,
+		}, true
+	}
+
+	return scheduling{}, false
+}
+
+// copySchedulingOf returns a shallow copy of the given *Event or *Todo.
+func copySchedulingOf(c interface{}) (scheduling, bool) {
+	switch c := c.(type) {
+	case *Event:
+		e := *c
+
+		return schedulingOf(&e)
+	case *Todo:
+		t := *c
+
+		return schedulingOf(&t)
+	}
+
+	return scheduling{}, false
+}
+
+// emptySchedulingOf returns a new component, of the same type as the given
+// *Event or *Todo, with only its UID, Organizer, Sequence and RecurrenceID
+// set.
+func emptySchedulingOf(c interface{}) (scheduling, bool) {
+	from, ok := schedulingOf(c)
+	if !ok {
+		return scheduling{}, false
+	}
+
+	var s scheduling
+
+	switch c.(type) {
+	case *Event:
+		s, _ = schedulingOf(new(Event))
+	case *Todo:
+		s, _ = schedulingOf(new(Todo))
+	}
+
+	*s.uid = *from.uid
+	*s.organizer = *from.organizer
+	*s.sequence = *from.sequence
+	*s.recurrenceID = *from.recurrenceID
+
+	return s, true
+}
+
+// findAttendee returns the index of the attendee with the given calendar
+// address, or -1 if there is no such attendee.
+func (s scheduling) findAttendee(address string) int {
+	for n, a := range *s.attendee {
+		if sameAddress(a.CalendarAddress, address) {
+			return n
+		}
+	}
+
+	return -1
+}
+
+func sameAddress(c CalendarAddress, address string) bool {
+	return strings.EqualFold(c.URL.String(), address)
+}
+
+func (s scheduling) bumpSequence() {
+	var seq PropSequence
+
+	if *s.sequence != nil {
+		seq = **s.sequence
+	}
+
+	seq++
+
+	*s.sequence = &seq
+}
+
+func (i *ITIP) message(method PropMethod, s scheduling) *Calendar {
+	now := time.Now
+
+	if i.Now != nil {
+		now = i.Now
+	}
+
+	*s.stamp = PropDateTimeStamp{now().UTC().Truncate(time.Second)}
+
+	cal := &Calendar{
+		Version:   "2.0",
+		ProductID: i.ProductID,
+		Method:    &method,
+		Timezone:  append([]Timezone(nil), i.Timezone...),
+	}
+
+	switch c := s.component.(type) {
+	case *Event:
+		cal.Event = []Event{*c}
+	case *Todo:
+		cal.Todo = []Todo{*c}
+	}
+
+	return cal
+}
+
+// Publish creates a PUBLISH message for the given *Event or *Todo, which must
+// have an Organizer. Any Attendees and Alarms are removed.
+func (i *ITIP) Publish(c interface{}) (*Calendar, error) {
+	s, ok := copySchedulingOf(c)
+	if !ok {
+		return nil, ErrInvalidComponent
+	} else if *s.organizer == nil {
+		return nil, ErrMissingOrganizer
+	}
+
+	*s.attendee = nil
+	*s.alarm = nil
+
+	return i.message(MethodPublish, s), nil
+}
+
+// Request creates a REQUEST message for the given *Event or *Todo, which must
+// have an Organizer and at least one Attendee. Any Alarms are removed.
+//
+// When reschedule is true, the Sequence of the given component is incremented
+// before the message is created, as is required when the timing of the
+// component has changed.
+func (i *ITIP) Request(c interface{}, reschedule bool) (*Calendar, error) {
+	orig, ok := schedulingOf(c)
+	if !ok {
+		return nil, ErrInvalidComponent
+	} else if *orig.organizer == nil {
+		return nil, ErrMissingOrganizer
+	} else if len(*orig.attendee) == 0 {
+		return nil, ErrMissingAttendee
+	}
+
+	if reschedule {
+		orig.bumpSequence()
+	}
+
+	s, _ := copySchedulingOf(c)
+	*s.alarm = nil
+
+	return i.message(MethodRequest, s), nil
+}
+
+// Reply creates a REPLY message, from the attendee with the given calendar
+// address, for the given *Event or *Todo, setting the ParticipationStatus of
+// the attendee to the given status.
+//
+// All other Attendees, and any Alarms, are removed.
+func (i *ITIP) Reply(c interface{}, attendee string, status ParamParticipationStatus) (*Calendar, error) {
+	s, ok := copySchedulingOf(c)
+	if !ok {
+		return nil, ErrInvalidComponent
+	} else if *s.organizer == nil {
+		return nil, ErrMissingOrganizer
+	}
+
+	n := s.findAttendee(attendee)
+	if n == -1 {
+		return nil, ErrUnknownAttendee
+	}
+
+	a := (*s.attendee)[n]
+	a.ParticipationStatus = status.New()
+	a.RSVP = nil
+	*s.attendee = []PropAttendee{a}
+	*s.alarm = nil
+	*s.requestStatus = nil
+
+	return i.message(MethodReply, s), nil
+}
+
+// Add creates an ADD message, adding the given *Event or *Todo as new
+// instances of an existing recurring component. Any recurrence properties and
+// Alarms are removed.
+//
+// The Sequence of the given component is incremented before the message is
+// created.
+func (i *ITIP) Add(c interface{}) (*Calendar, error) {
+	orig, ok := schedulingOf(c)
+	if !ok {
+		return nil, ErrInvalidComponent
+	} else if *orig.organizer == nil {
+		return nil, ErrMissingOrganizer
+	} else if len(*orig.attendee) == 0 {
+		return nil, ErrMissingAttendee
+	}
+
+	orig.bumpSequence()
+
+	s, _ := copySchedulingOf(c)
+	*s.alarm = nil
+	*s.recurrenceID = nil
+
+	switch c := s.component.(type) {
+	case *Event:
+		c.RecurrenceRule = nil
+		c.RecurrenceDateTimes = nil
+		c.ExceptionDateTime = nil
+	case *Todo:
+		c.RecurrenceRule = nil
+		c.RecurrenceDateTimes = nil
+		c.ExceptionDateTime = nil
+	}
+
+	return i.message(MethodAdd, s), nil
+}
+
+// Cancel creates a CANCEL message for the given *Event or *Todo.
+//
+// When no attendees are given, the entire component (or instance, if it has a
+// RecurrenceID) is cancelled, and the message is sent to all Attendees with
+// its Status set to Cancelled. Otherwise, the message is only for the given
+// attendees.
+//
+// The Sequence of the given component is incremented, and any given attendees
+// are removed from it, before the message is created.
+func (i *ITIP) Cancel(c interface{}, attendees ...string) (*Calendar, error) {
+	orig, ok := schedulingOf(c)
+	if !ok {
+		return nil, ErrInvalidComponent
+	} else if *orig.organizer == nil {
+		return nil, ErrMissingOrganizer
+	}
+
+	var cancelled []PropAttendee
+
+	for _, address := range attendees {
+		n := orig.findAttendee(address)
+		if n == -1 {
+			return nil, ErrUnknownAttendee
+		}
+
+		cancelled = append(cancelled, (*orig.attendee)[n])
+	}
+
+	orig.bumpSequence()
+
+	s, _ := copySchedulingOf(c)
+	*s.alarm = nil
+
+	if len(attendees) == 0 {
+		*s.status = StatusCancelled.New()
+	} else {
+		*s.attendee = cancelled
+
+		for _, address := range attendees {
+			if n := orig.findAttendee(address); n != -1 {
+				*orig.attendee = append((*orig.attendee)[:n:n], (*orig.attendee)[n+1:]...)
+			}
+		}
+	}
+
+	return i.message(MethodCancel, s), nil
+}
+
+// Refresh creates a REFRESH message, from the attendee with the given calendar
+// address, requesting the latest version of the given *Event or *Todo.
+func (i *ITIP) Refresh(c interface{}, attendee string) (*Calendar, error) {
+	orig, ok := schedulingOf(c)
+	if !ok {
+		return nil, ErrInvalidComponent
+	}
+
+	n := orig.findAttendee(attendee)
+	if n == -1 {
+		return nil, ErrUnknownAttendee
+	}
+
+	s, _ := emptySchedulingOf(c)
+	*s.attendee = []PropAttendee{(*orig.attendee)[n]}
+	*s.sequence = nil
+
+	return i.message(MethodRefresh, s), nil
+}
+
+// Counter creates a COUNTER message, proposing the given *Event or *Todo, as
+// modified by an attendee, to the Organizer. Any Alarms are removed.
+func (i *ITIP) Counter(c interface{}) (*Calendar, error) {
+	s, ok := copySchedulingOf(c)
+	if !ok {
+		return nil, ErrInvalidComponent
+	} else if *s.organizer == nil {
+		return nil, ErrMissingOrganizer
+	} else if len(*s.attendee) == 0 {
+		return nil, ErrMissingAttendee
+	}
+
+	*s.alarm = nil
+
+	return i.message(MethodCounter, s), nil
+}
+
+// DeclineCounter creates a DECLINECOUNTER message, rejecting the given *Event
+// or *Todo received in a COUNTER message.
+func (i *ITIP) DeclineCounter(c interface{}) (*Calendar, error) {
+	orig, ok := schedulingOf(c)
+	if !ok {
+		return nil, ErrInvalidComponent
+	} else if len(*orig.attendee) == 0 {
+		return nil, ErrMissingAttendee
+	}
+
+	s, _ := emptySchedulingOf(c)
+	*s.attendee = append([]PropAttendee(nil), *orig.attendee...)
+
+	return i.message(MethodDeclineCounter, s), nil
+}
+
+// Errors.
+var (
+	ErrMissingOrganizer = errors.New("missing organizer")
+	ErrMissingAttendee  = errors.New("missing attendee")
+	ErrUnknownAttendee  = errors.New("unknown attendee")
+)
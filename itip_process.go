@@ -0,0 +1,436 @@
+package ics
+
+import (
+	"errors"
+	"time"
+)
+
+// iTIP (RFC 5546) request statuses.
+const (
+	RequestStatusSuccess          PropRequestStatus = "2.0;Success"
+	RequestStatusInvalidSequence  PropRequestStatus = "3.4;Invalid calendar component sequence"
+	RequestStatusInvalidUser      PropRequestStatus = "3.7;Invalid calendar user"
+	RequestStatusMissing          PropRequestStatus = "3.11;Required component or property missing"
+	RequestStatusUnknownComponent PropRequestStatus = "3.12;Unknown component or property found"
+)
+
+// ITIPAction is the type of change made to a Calendar when processing an iTIP
+// message.
+type ITIPAction uint8
+
+// ITIPAction values.
+const (
+	// ITIPAdded is a new component, or instance, added to the Calendar.
+	ITIPAdded ITIPAction = iota
+
+	// ITIPUpdated is a component replaced by a later revision.
+	ITIPUpdated
+
+	// ITIPRemoved is a component removed from the Calendar.
+	ITIPRemoved
+
+	// ITIPExcluded is an instance excluded from a recurring component.
+	ITIPExcluded
+
+	// ITIPParticipationStatus is an Attendee whose ParticipationStatus has
+	// been updated.
+	ITIPParticipationStatus
+)
+
+// ITIPChange is a single change made to a Calendar when processing an iTIP
+// message.
+type ITIPChange struct {
+	Action       ITIPAction
+	Component    string
+	UID          PropUID
+	RecurrenceID *PropRecurrenceID
+
+	// Attendee is the updated Attendee for an ITIPParticipationStatus change.
+	Attendee *PropAttendee
+}
+
+// ITIPResult is the outcome of processing an iTIP message.
+type ITIPResult struct {
+	Changes []ITIPChange
+
+	// RequestStatus contains a status for each Event or Todo in the message,
+	// in order, suitable for sending back to the sender of the message.
+	RequestStatus []PropRequestStatus
+}
+
+// ProcessITIP applies the given iTIP message to the Events and Todos stored in
+// the Calendar, which is either the copy kept by the Organizer, when
+// processing a REPLY, or the copy kept by an Attendee otherwise.
+//
+// Components are matched by UID and RecurrenceID. A PUBLISH, REQUEST, ADD, or
+// CANCEL message is out-of-date, and ignored, unless it has a higher Sequence
+// than the stored component, or the same Sequence and a later DateTimeStamp. A
+// REPLY is out-of-date when it has a lower Sequence than the stored component.
+//
+// PUBLISH and REQUEST messages add or replace components, keeping any stored
+// Alarms. ADD messages add new instances to a stored recurring component.
+// CANCEL messages remove the component, or exclude the single instance given
+// by the RecurrenceID. REPLY messages update the ParticipationStatus of the
+// replying Attendees, creating an instance of a recurring component when the
+// reply is for a single instance.
+//
+// REFRESH, COUNTER, and DECLINECOUNTER messages require a response from the
+// user, and so make no changes.
+func (c *Calendar) ProcessITIP(msg *Calendar) (*ITIPResult, error) {
+	if msg.Method == nil {
+		return nil, ErrInvalidMethod
+	}
+
+	var process func(*ITIPResult, scheduling) PropRequestStatus
+
+	switch *msg.Method {
+	case MethodPublish, MethodRequest:
+		process = c.processRequest
+	case MethodReply:
+		process = c.processReply
+	case MethodAdd:
+		process = c.processAdd
+	case MethodCancel:
+		process = c.processCancel
+	case MethodRefresh, MethodCounter, MethodDeclineCounter:
+		return new(ITIPResult), nil
+	default:
+		return nil, ErrInvalidMethod
+	}
+
+	r := new(ITIPResult)
+
+	for n := range msg.Event {
+		s, _ := schedulingOf(&msg.Event[n])
+		r.RequestStatus = append(r.RequestStatus, process(r, s))
+	}
+
+	for n := range msg.Todo {
+		s, _ := schedulingOf(&msg.Todo[n])
+		r.RequestStatus = append(r.RequestStatus, process(r, s))
+	}
+
+	return r, nil
+}
+
+func (c *Calendar) processRequest(r *ITIPResult, m scheduling) PropRequestStatus {
+	stored, ok := c.findScheduled(m.name, *m.uid, *m.recurrenceID)
+	if !ok {
+		c.appendScheduled(m)
+		r.change(ITIPAdded, m)
+
+		return RequestStatusSuccess
+	} else if !m.newer(stored) {
+		return RequestStatusInvalidSequence
+	}
+
+	alarms := *stored.alarm
+
+	stored.replace(m)
+
+	*stored.alarm = alarms
+
+	r.change(ITIPUpdated, m)
+
+	if *m.recurrenceID == nil {
+		c.filterScheduled(m.name, func(s scheduling) bool {
+			if *s.uid != *m.uid || *s.recurrenceID == nil || s.seq() >= m.seq() {
+				return true
+			}
+
+			r.change(ITIPRemoved, s)
+
+			return false
+		})
+	}
+
+	return RequestStatusSuccess
+}
+
+func (c *Calendar) processReply(r *ITIPResult, m scheduling) PropRequestStatus {
+	stored, ok := c.findScheduled(m.name, *m.uid, *m.recurrenceID)
+	if !ok && *m.recurrenceID != nil {
+		stored, ok = c.findScheduled(m.name, *m.uid, nil)
+	}
+
+	if !ok {
+		return RequestStatusUnknownComponent
+	} else if m.seq() < stored.seq() {
+		return RequestStatusInvalidSequence
+	}
+
+	for _, a := range *m.attendee {
+		if stored.findAttendee(a.CalendarAddress.URL.String()) == -1 {
+			return RequestStatusInvalidUser
+		}
+	}
+
+	if *stored.recurrenceID == nil && *m.recurrenceID != nil {
+		stored = c.appendScheduled(stored.instance(*m.recurrenceID))
+
+		r.change(ITIPAdded, stored)
+	}
+
+	*stored.attendee = append([]PropAttendee(nil), *stored.attendee...)
+
+	for _, a := range *m.attendee {
+		attendee := &(*stored.attendee)[stored.findAttendee(a.CalendarAddress.URL.String())]
+
+		if a.ParticipationStatus != nil {
+			attendee.ParticipationStatus = a.ParticipationStatus.New()
+		}
+
+		if a.Delagatee != nil {
+			attendee.Delagatee = a.Delagatee
+		}
+
+		changed := *attendee
+
+		r.change(ITIPParticipationStatus, stored).Attendee = &changed
+	}
+
+	return RequestStatusSuccess
+}
+
+func (c *Calendar) processAdd(r *ITIPResult, m scheduling) PropRequestStatus {
+	master, ok := c.findScheduled(m.name, *m.uid, nil)
+	if !ok {
+		return RequestStatusUnknownComponent
+	} else if !m.newer(master) {
+		return RequestStatusInvalidSequence
+	} else if *m.start == nil {
+		return RequestStatusMissing
+	}
+
+	start, date := startTime((*m.start).DateTime, (*m.start).Date)
+	rdate := PropRecurrenceDateTimes{}
+
+	if date {
+		rdate.Date = &Date{start}
+	} else {
+		rdate.DateTime = &DateTime{start}
+	}
+
+	*master.rdates = append((*master.rdates)[:len(*master.rdates):len(*master.rdates)], rdate)
+	seq := m.seq()
+	*master.sequence = &seq
+	*master.stamp = *m.stamp
+
+	s, _ := copySchedulingOf(m.component)
+	*s.recurrenceID = instanceRecurrenceID(start, date)
+	*s.rrule = nil
+	*s.rdates = nil
+	*s.exdates = nil
+
+	r.change(ITIPAdded, c.appendScheduled(s))
+
+	return RequestStatusSuccess
+}
+
+func (c *Calendar) processCancel(r *ITIPResult, m scheduling) PropRequestStatus {
+	rid := *m.recurrenceID
+	stored, ok := c.findScheduled(m.name, *m.uid, rid)
+	master, hasMaster := c.findScheduled(m.name, *m.uid, nil)
+
+	if !ok && !hasMaster {
+		return RequestStatusUnknownComponent
+	} else if !ok {
+		stored = master
+	}
+
+	if !m.newer(stored) {
+		return RequestStatusInvalidSequence
+	}
+
+	if rid != nil && hasMaster {
+		t, _ := startTime(rid.DateTime, rid.Date)
+		exdate := PropExceptionDateTime{}
+
+		if rid.Date != nil {
+			exdate.Date = &Date{t}
+		} else {
+			exdate.DateTime = &DateTime{t}
+		}
+
+		*master.exdates = append((*master.exdates)[:len(*master.exdates):len(*master.exdates)], exdate)
+
+		r.change(ITIPExcluded, master).RecurrenceID = rid
+	}
+
+	c.filterScheduled(m.name, func(s scheduling) bool {
+		if *s.uid != *m.uid || rid != nil && !sameRecurrenceID(*s.recurrenceID, rid) {
+			return true
+		}
+
+		r.change(ITIPRemoved, s)
+
+		return false
+	})
+
+	return RequestStatusSuccess
+}
+
+func (r *ITIPResult) change(action ITIPAction, s scheduling) *ITIPChange {
+	r.Changes = append(r.Changes, ITIPChange{
+		Action:       action,
+		Component:    s.name,
+		UID:          *s.uid,
+		RecurrenceID: *s.recurrenceID,
+	})
+
+	return &r.Changes[len(r.Changes)-1]
+}
+
+func (s scheduling) seq() PropSequence {
+	if *s.sequence == nil {
+		return 0
+	}
+
+	return **s.sequence
+}
+
+// newer reports whether s is a later revision than o, comparing first their
+// Sequence and then their DateTimeStamp.
+func (s scheduling) newer(o scheduling) bool {
+	if sq, oq := s.seq(), o.seq(); sq != oq {
+		return sq > oq
+	}
+
+	return s.stamp.After(o.stamp.Time)
+}
+
+// replace overwrites the component of s with that of o, which must be of the
+// same type.
+func (s scheduling) replace(o scheduling) {
+	switch c := s.component.(type) {
+	case *Event:
+		*c = *o.component.(*Event)
+	case *Todo:
+		*c = *o.component.(*Todo)
+	}
+}
+
+// instance returns a copy of the recurring component s as the instance with the
+// given RecurrenceID.
+func (s scheduling) instance(rid *PropRecurrenceID) scheduling {
+	i, _ := copySchedulingOf(s.component)
+	t, _ := startTime(rid.DateTime, rid.Date)
+
+	var offset time.Duration
+
+	if *s.start != nil {
+		start, _ := startTime((*s.start).DateTime, (*s.start).Date)
+		offset = t.Sub(start)
+		*i.start = instanceStart(t, rid.Date != nil)
+	}
+
+	switch c := i.component.(type) {
+	case *Event:
+		if c.DateTimeEnd != nil {
+			end, date := startTime(c.DateTimeEnd.DateTime, c.DateTimeEnd.Date)
+
+			if date {
+				c.DateTimeEnd = &PropDateTimeEnd{Date: &Date{end.Add(offset)}}
+			} else {
+				c.DateTimeEnd = &PropDateTimeEnd{DateTime: &DateTime{end.Add(offset)}}
+			}
+		}
+	case *Todo:
+		if c.Due != nil {
+			due, date := startTime(c.Due.DateTime, c.Due.Date)
+
+			if date {
+				c.Due = &PropDue{Date: &Date{due.Add(offset)}}
+			} else {
+				c.Due = &PropDue{DateTime: &DateTime{due.Add(offset)}}
+			}
+		}
+	}
+
+	*i.recurrenceID = instanceRecurrenceID(t, rid.Date != nil)
+	*i.rrule = nil
+	*i.rdates = nil
+	*i.exdates = nil
+	*i.attendee = append([]PropAttendee(nil), *i.attendee...)
+
+	return i
+}
+
+func sameRecurrenceID(a, b *PropRecurrenceID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	at, _ := startTime(a.DateTime, a.Date)
+	bt, _ := startTime(b.DateTime, b.Date)
+
+	return at.Equal(bt)
+}
+
+// findScheduled returns the stored Event or Todo, with the given component
+// name, that has the given UID and RecurrenceID.
+func (c *Calendar) findScheduled(name string, uid PropUID, rid *PropRecurrenceID) (scheduling, bool) {
+	switch name {
+	case cEvent:
+		for n := range c.Event {
+			if c.Event[n].UID == uid && sameRecurrenceID(c.Event[n].RecurrenceID, rid) {
+				return schedulingOf(&c.Event[n])
+			}
+		}
+	case cTodo:
+		for n := range c.Todo {
+			if c.Todo[n].UID == uid && sameRecurrenceID(c.Todo[n].RecurrenceID, rid) {
+				return schedulingOf(&c.Todo[n])
+			}
+		}
+	}
+
+	return scheduling{}, false
+}
+
+// appendScheduled adds a copy of the component of s to the Calendar, returning
+// the stored copy.
+func (c *Calendar) appendScheduled(s scheduling) scheduling {
+	switch comp := s.component.(type) {
+	case *Event:
+		c.Event = append(c.Event, *comp)
+		s, _ = schedulingOf(&c.Event[len(c.Event)-1])
+	case *Todo:
+		c.Todo = append(c.Todo, *comp)
+		s, _ = schedulingOf(&c.Todo[len(c.Todo)-1])
+	}
+
+	return s
+}
+
+// filterScheduled removes the stored Events or Todos, with the given component
+// name, for which keep returns false.
+func (c *Calendar) filterScheduled(name string, keep func(scheduling) bool) {
+	switch name {
+	case cEvent:
+		events := c.Event[:0]
+
+		for n := range c.Event {
+			if s, _ := schedulingOf(&c.Event[n]); keep(s) {
+				events = append(events, c.Event[n])
+			}
+		}
+
+		c.Event = events
+	case cTodo:
+		todos := c.Todo[:0]
+
+		for n := range c.Todo {
+			if s, _ := schedulingOf(&c.Todo[n]); keep(s) {
+				todos = append(todos, c.Todo[n])
+			}
+		}
+
+		c.Todo = todos
+	}
+}
+
+// Errors.
+var (
+	ErrInvalidMethod = errors.New("invalid method")
+)
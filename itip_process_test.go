@@ -0,0 +1,187 @@
+package ics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProcessITIP(t *testing.T) {
+	org, err := Decode(strings.NewReader(strings.Replace(itipEvent, "SEQUENCE:1\r\n", "SEQUENCE:1\r\nRRULE:FREQ=WEEKLY;COUNT=4\r\n", 1)))
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	}
+
+	var (
+		att   = new(Calendar)
+		now   = time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+		i     = ITIP{ProductID: "TEST", Now: func() time.Time { now = now.Add(time.Minute); return now }}
+		first *Calendar
+		jan13 = time.Date(2020, 1, 13, 10, 0, 0, 0, time.UTC)
+		feb1  = time.Date(2020, 2, 1, 10, 0, 0, 0, time.UTC)
+	)
+
+	partStat := func(e *Event, n int) ParamParticipationStatus {
+		return *e.Attendee[n].ParticipationStatus
+	}
+
+	tests := []struct {
+		Build   func() (*Calendar, error)
+		Target  *Calendar
+		Status  PropRequestStatus
+		Changes []ITIPAction
+		Check   func() bool
+	}{
+		{
+			Build: func() (*Calendar, error) {
+				first, err = i.Request(&org.Event[0], false)
+
+				return first, err
+			},
+			Target:  att,
+			Status:  RequestStatusSuccess,
+			Changes: []ITIPAction{ITIPAdded},
+			Check: func() bool {
+				return len(att.Event) == 1 && att.Event[0].RecurrenceRule != nil
+			},
+		},
+		{
+			Build:  func() (*Calendar, error) { return first, nil },
+			Target: att,
+			Status: RequestStatusInvalidSequence,
+		},
+		{
+			Build: func() (*Calendar, error) {
+				return i.Reply(&att.Event[0], "mailto:bob@example.com", ParticipationStatusAccepted)
+			},
+			Target:  org,
+			Status:  RequestStatusSuccess,
+			Changes: []ITIPAction{ITIPParticipationStatus},
+			Check: func() bool {
+				return partStat(&org.Event[0], 1) == ParticipationStatusAccepted && partStat(&org.Event[0], 2) == ParticipationStatusNeedsAction
+			},
+		},
+		{
+			Build: func() (*Calendar, error) {
+				e := att.Event[0]
+				e.RecurrenceID = &PropRecurrenceID{DateTime: &DateTime{jan13}}
+
+				return i.Reply(&e, "mailto:bob@example.com", ParticipationStatusDeclined)
+			},
+			Target:  org,
+			Status:  RequestStatusSuccess,
+			Changes: []ITIPAction{ITIPAdded, ITIPParticipationStatus},
+			Check: func() bool {
+				return len(org.Event) == 2 &&
+					partStat(&org.Event[0], 1) == ParticipationStatusAccepted &&
+					partStat(&org.Event[1], 1) == ParticipationStatusDeclined &&
+					org.Event[1].RecurrenceRule == nil &&
+					org.Event[1].DateTimeStart.DateTime.Equal(jan13) &&
+					org.Event[1].DateTimeEnd.DateTime.Equal(jan13.Add(time.Hour))
+			},
+		},
+		{
+			Build:   func() (*Calendar, error) { return i.Cancel(&org.Event[1]) },
+			Target:  att,
+			Status:  RequestStatusSuccess,
+			Changes: []ITIPAction{ITIPExcluded},
+			Check: func() bool {
+				return len(att.Event) == 1 && len(att.Event[0].ExceptionDateTime) == 1 && att.Event[0].ExceptionDateTime[0].DateTime.Equal(jan13)
+			},
+		},
+		{
+			Build: func() (*Calendar, error) {
+				org.Event[0].Summary = &PropSummary{Text: "Rescheduled"}
+
+				return i.Request(&org.Event[0], true)
+			},
+			Target:  att,
+			Status:  RequestStatusSuccess,
+			Changes: []ITIPAction{ITIPUpdated},
+			Check: func() bool {
+				return len(att.Event) == 1 && att.Event[0].Summary.Text == "Rescheduled" && *att.Event[0].Sequence == 2
+			},
+		},
+		{
+			Build:  func() (*Calendar, error) { return first, nil },
+			Target: att,
+			Status: RequestStatusInvalidSequence,
+		},
+		{
+			Build: func() (*Calendar, error) {
+				e := org.Event[0]
+				e.DateTimeStart = &PropDateTimeStart{DateTime: &DateTime{feb1}}
+				e.DateTimeEnd = &PropDateTimeEnd{DateTime: &DateTime{feb1.Add(time.Hour)}}
+
+				msg, err := i.Add(&e)
+				if err == nil {
+					msg.Event[0].RecurrenceRule = org.Event[0].RecurrenceRule
+					msg.Event[0].RecurrenceDateTimes = []PropRecurrenceDateTimes{{DateTime: &DateTime{feb1.AddDate(0, 0, 7)}}}
+					msg.Event[0].ExceptionDateTime = []PropExceptionDateTime{{DateTime: &DateTime{jan13}}}
+				}
+
+				return msg, err
+			},
+			Target:  att,
+			Status:  RequestStatusSuccess,
+			Changes: []ITIPAction{ITIPAdded},
+			Check: func() bool {
+				return len(att.Event) == 2 &&
+					len(att.Event[0].RecurrenceDateTimes) == 1 &&
+					att.Event[0].RecurrenceDateTimes[0].DateTime.Equal(feb1) &&
+					*att.Event[0].Sequence == 3 &&
+					att.Event[1].RecurrenceID.DateTime.Equal(feb1) &&
+					att.Event[1].RecurrenceRule == nil &&
+					len(att.Event[1].RecurrenceDateTimes) == 0 &&
+					len(att.Event[1].ExceptionDateTime) == 0
+			},
+		},
+		{
+			Build:   func() (*Calendar, error) { return i.Cancel(&org.Event[0]) },
+			Target:  att,
+			Status:  RequestStatusSuccess,
+			Changes: []ITIPAction{ITIPRemoved, ITIPRemoved},
+			Check: func() bool {
+				return len(att.Event) == 0
+			},
+		},
+		{
+			Build:  func() (*Calendar, error) { return i.Cancel(&org.Event[0]) },
+			Target: att,
+			Status: RequestStatusUnknownComponent,
+		},
+	}
+
+	for n, test := range tests {
+		msg, err := test.Build()
+		if err != nil {
+			t.Fatalf("test %d: unexpected error building message: %s", n+1, err)
+		}
+
+		r, err := test.Target.ProcessITIP(msg)
+		if err != nil {
+			t.Fatalf("test %d: unexpected error: %s", n+1, err)
+		} else if len(r.RequestStatus) != 1 || r.RequestStatus[0] != test.Status {
+			t.Errorf("test %d: expecting status %v, got %v", n+1, test.Status, r.RequestStatus)
+		} else if len(r.Changes) != len(test.Changes) {
+			t.Errorf("test %d: expecting %d changes, got %d", n+1, len(test.Changes), len(r.Changes))
+		} else {
+			for m, c := range r.Changes {
+				if c.Action != test.Changes[m] {
+					t.Errorf("test %d.%d: expecting action %d, got %d", n+1, m+1, test.Changes[m], c.Action)
+				} else if c.UID != "meeting@example.com" || c.Component != cEvent {
+					t.Errorf("test %d.%d: unexpected change %v", n+1, m+1, c)
+				}
+			}
+
+			if test.Check != nil && !test.Check() {
+				t.Errorf("test %d: stored calendar not as expected", n+1)
+			}
+		}
+	}
+
+	if _, err := org.ProcessITIP(new(Calendar)); !errors.Is(err, ErrInvalidMethod) {
+		t.Errorf("expecting error ErrInvalidMethod, got %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package ics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const itipEvent = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:TEST\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTAMP:20200101T000000Z\r\n" +
+	"UID:meeting@example.com\r\n" +
+	"DTSTART:20200106T100000Z\r\n" +
+	"DTEND:20200106T110000Z\r\n" +
+	"SUMMARY:Meeting\r\n" +
+	"SEQUENCE:1\r\n" +
+	"ORGANIZER:mailto:alice@example.com\r\n" +
+	"ATTENDEE;PARTSTAT=ACCEPTED:mailto:alice@example.com\r\n" +
+	"ATTENDEE;RSVP=TRUE;PARTSTAT=NEEDS-ACTION:mailto:bob@example.com\r\n" +
+	"ATTENDEE;RSVP=TRUE;PARTSTAT=NEEDS-ACTION:mailto:carol@example.com\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"DESCRIPTION:Reminder\r\n" +
+	"TRIGGER:-PT15M\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestITIP(t *testing.T) {
+	cal, err := Decode(strings.NewReader(itipEvent))
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	}
+
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	i := ITIP{ProductID: "TEST", Now: func() time.Time { return now }}
+	e := &cal.Event[0]
+
+	tests := []struct {
+		Build     func() (*Calendar, error)
+		Method    PropMethod
+		Sequence  PropSequence
+		Attendees []string
+		PartStat  ParamParticipationStatus
+		Status    *PropStatus
+	}{
+		{
+			Build:    func() (*Calendar, error) { return i.Publish(e) },
+			Method:   MethodPublish,
+			Sequence: 1,
+		},
+		{
+			Build:     func() (*Calendar, error) { return i.Request(e, false) },
+			Method:    MethodRequest,
+			Sequence:  1,
+			Attendees: []string{"mailto:alice@example.com", "mailto:bob@example.com", "mailto:carol@example.com"},
+		},
+		{
+			Build:     func() (*Calendar, error) { return i.Request(e, true) },
+			Method:    MethodRequest,
+			Sequence:  2,
+			Attendees: []string{"mailto:alice@example.com", "mailto:bob@example.com", "mailto:carol@example.com"},
+		},
+		{
+			Build:     func() (*Calendar, error) { return i.Reply(e, "MAILTO:bob@example.com", ParticipationStatusDeclined) },
+			Method:    MethodReply,
+			Sequence:  2,
+			Attendees: []string{"mailto:bob@example.com"},
+			PartStat:  ParticipationStatusDeclined,
+		},
+		{
+			Build:     func() (*Calendar, error) { return i.Cancel(e, "mailto:carol@example.com") },
+			Method:    MethodCancel,
+			Sequence:  3,
+			Attendees: []string{"mailto:carol@example.com"},
+			PartStat:  ParticipationStatusNeedsAction,
+		},
+		{
+			Build:     func() (*Calendar, error) { return i.Cancel(e) },
+			Method:    MethodCancel,
+			Sequence:  4,
+			Attendees: []string{"mailto:alice@example.com", "mailto:bob@example.com"},
+			Status:    StatusCancelled.New(),
+		},
+		{
+			Build:     func() (*Calendar, error) { return i.Add(e) },
+			Method:    MethodAdd,
+			Sequence:  5,
+			Attendees: []string{"mailto:alice@example.com", "mailto:bob@example.com"},
+		},
+		{
+			Build:     func() (*Calendar, error) { return i.Refresh(e, "mailto:bob@example.com") },
+			Method:    MethodRefresh,
+			Attendees: []string{"mailto:bob@example.com"},
+			PartStat:  ParticipationStatusNeedsAction,
+		},
+		{
+			Build:     func() (*Calendar, error) { return i.Counter(e) },
+			Method:    MethodCounter,
+			Sequence:  5,
+			Attendees: []string{"mailto:alice@example.com", "mailto:bob@example.com"},
+		},
+		{
+			Build:     func() (*Calendar, error) { return i.DeclineCounter(e) },
+			Method:    MethodDeclineCounter,
+			Sequence:  5,
+			Attendees: []string{"mailto:alice@example.com", "mailto:bob@example.com"},
+		},
+	}
+
+	for n, test := range tests {
+		msg, err := test.Build()
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+
+			continue
+		} else if err := msg.valid(); err != nil {
+			t.Errorf("test %d: unexpected validation error: %s", n+1, err)
+
+			continue
+		}
+
+		m := msg.Event[0]
+
+		var seq PropSequence
+
+		if m.Sequence != nil {
+			seq = *m.Sequence
+		}
+
+		if *msg.Method != test.Method {
+			t.Errorf("test %d: expecting method %s, got %s", n+1, test.Method, *msg.Method)
+		} else if !m.DateTimeStamp.Equal(now) {
+			t.Errorf("test %d: expecting DTSTAMP %s, got %s", n+1, now, m.DateTimeStamp.Time)
+		} else if seq != test.Sequence {
+			t.Errorf("test %d: expecting SEQUENCE %d, got %d", n+1, test.Sequence, seq)
+		} else if len(m.Alarm) != 0 {
+			t.Errorf("test %d: expecting no alarms", n+1)
+		} else if len(m.Attendee) != len(test.Attendees) {
+			t.Errorf("test %d: expecting %d attendees, got %d", n+1, len(test.Attendees), len(m.Attendee))
+		} else if (m.Status == nil) != (test.Status == nil) || m.Status != nil && *m.Status != *test.Status {
+			t.Errorf("test %d: expecting status %v, got %v", n+1, test.Status, m.Status)
+		} else {
+			for a, address := range test.Attendees {
+				if got := m.Attendee[a].URL.String(); got != address {
+					t.Errorf("test %d.%d: expecting attendee %s, got %s", n+1, a+1, address, got)
+				} else if test.PartStat != ParticipationStatusUnknown && *m.Attendee[a].ParticipationStatus != test.PartStat {
+					t.Errorf("test %d.%d: expecting PARTSTAT %d, got %d", n+1, a+1, test.PartStat, *m.Attendee[a].ParticipationStatus)
+				}
+			}
+		}
+	}
+
+	if *e.Sequence != 5 {
+		t.Errorf("expecting organizer SEQUENCE 5, got %d", *e.Sequence)
+	} else if *e.Attendee[1].ParticipationStatus != ParticipationStatusNeedsAction {
+		t.Errorf("expecting organizer copy to be unchanged by REPLY")
+	} else if len(e.Alarm) != 1 {
+		t.Errorf("expecting organizer copy to keep its alarm")
+	}
+
+	if _, err := i.Reply(e, "mailto:dave@example.com", ParticipationStatusAccepted); !errors.Is(err, ErrUnknownAttendee) {
+		t.Errorf("expecting error ErrUnknownAttendee, got %v", err)
+	}
+
+	e.Organizer = nil
+
+	if _, err := i.Request(e, false); !errors.Is(err, ErrMissingOrganizer) {
+		t.Errorf("expecting error ErrMissingOrganizer, got %v", err)
+	} else if _, err := i.Publish(&cal.Journal); !errors.Is(err, ErrInvalidComponent) {
+		t.Errorf("expecting error ErrInvalidComponent, got %v", err)
+	}
+}
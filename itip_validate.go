@@ -0,0 +1,278 @@
+package ics
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ITIPError is a restriction, placed by iTIP (RFC 5546) on the components of a
+// message, that a Calendar does not meet.
+type ITIPError struct {
+	Method PropMethod
+
+	// Path lists the offending component and those containing it, with the
+	// index of each among its siblings of the same name, e.g.
+	// ["VCALENDAR", "VEVENT[0]"].
+	Path []string
+
+	// Property is the name of the offending property or subcomponent, if any.
+	Property string
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (i *ITIPError) Error() string {
+	var sb strings.Builder
+
+	if i.Method != "" {
+		sb.WriteString(string(i.Method))
+		sb.WriteString(": ")
+	}
+
+	sb.WriteString(strings.Join(i.Path, " > "))
+
+	if i.Property != "" {
+		sb.WriteString(": ")
+		sb.WriteString(i.Property)
+	}
+
+	sb.WriteString(": ")
+	sb.WriteString(i.Err.Error())
+
+	return sb.String()
+}
+
+// Unwrap returns the underlying error.
+func (i *ITIPError) Unwrap() error {
+	return i.Err
+}
+
+type itipRule struct {
+	name     string
+	min, max int
+	todoOnly bool
+}
+
+const itipMany = -1
+
+// itipRules lists, for each method, the number of times each property or
+// subcomponent may appear in the Events and Todos of a message. Those not
+// listed are unrestricted.
+var itipRules = map[PropMethod][]itipRule{
+	MethodPublish: {
+		{name: "ATTENDEE"},
+		{name: "DTSTAMP", min: 1, max: 1},
+		{name: "DTSTART", min: 1, max: 1},
+		{name: "ORGANIZER", min: 1, max: 1},
+		{name: "PRIORITY", min: 1, max: 1, todoOnly: true},
+		{name: "REQUEST-STATUS"},
+		{name: "SUMMARY", min: 1, max: 1},
+		{name: "UID", min: 1, max: 1},
+	},
+	MethodRequest: {
+		{name: "ATTENDEE", min: 1, max: itipMany},
+		{name: "DTSTAMP", min: 1, max: 1},
+		{name: "DTSTART", min: 1, max: 1},
+		{name: "ORGANIZER", min: 1, max: 1},
+		{name: "PRIORITY", min: 1, max: 1, todoOnly: true},
+		{name: "REQUEST-STATUS"},
+		{name: "SUMMARY", min: 1, max: 1},
+		{name: "UID", min: 1, max: 1},
+	},
+	MethodReply: {
+		{name: "ATTENDEE", min: 1, max: 1},
+		{name: "DTSTAMP", min: 1, max: 1},
+		{name: "ORGANIZER", min: 1, max: 1},
+		{name: "UID", min: 1, max: 1},
+		{name: "VALARM"},
+	},
+	MethodAdd: {
+		{name: "DTSTAMP", min: 1, max: 1},
+		{name: "DTSTART", min: 1, max: 1},
+		{name: "EXDATE"},
+		{name: "ORGANIZER", min: 1, max: 1},
+		{name: "PRIORITY", min: 1, max: 1, todoOnly: true},
+		{name: "RECURRENCE-ID"},
+		{name: "REQUEST-STATUS"},
+		{name: "RRULE"},
+		{name: "SEQUENCE", min: 1, max: 1},
+		{name: "SUMMARY", min: 1, max: 1},
+		{name: "UID", min: 1, max: 1},
+	},
+	MethodCancel: {
+		{name: "DTSTAMP", min: 1, max: 1},
+		{name: "ORGANIZER", min: 1, max: 1},
+		{name: "REQUEST-STATUS"},
+		{name: "SEQUENCE", min: 1, max: 1},
+		{name: "UID", min: 1, max: 1},
+		{name: "VALARM"},
+	},
+	MethodRefresh: {
+		{name: "ATTENDEE", min: 1, max: 1},
+		{name: "DTEND"},
+		{name: "DTSTAMP", min: 1, max: 1},
+		{name: "DTSTART"},
+		{name: "DURATION"},
+		{name: "EXDATE"},
+		{name: "ORGANIZER", min: 1, max: 1},
+		{name: "RDATE"},
+		{name: "REQUEST-STATUS"},
+		{name: "RRULE"},
+		{name: "SEQUENCE"},
+		{name: "SUMMARY"},
+		{name: "UID", min: 1, max: 1},
+		{name: "VALARM"},
+	},
+	MethodCounter: {
+		{name: "DTSTAMP", min: 1, max: 1},
+		{name: "DTSTART", min: 1, max: 1},
+		{name: "ORGANIZER", min: 1, max: 1},
+		{name: "PRIORITY", min: 1, max: 1, todoOnly: true},
+		{name: "SUMMARY", min: 1, max: 1},
+		{name: "UID", min: 1, max: 1},
+	},
+	MethodDeclineCounter: {
+		{name: "DTEND"},
+		{name: "DTSTAMP", min: 1, max: 1},
+		{name: "DTSTART"},
+		{name: "DURATION"},
+		{name: "EXDATE"},
+		{name: "ORGANIZER", min: 1, max: 1},
+		{name: "RDATE"},
+		{name: "RRULE"},
+		{name: "SUMMARY"},
+		{name: "UID", min: 1, max: 1},
+		{name: "VALARM"},
+	},
+}
+
+// ValidateITIP checks the Calendar against the restrictions that iTIP
+// (RFC 5546) places on a message with its Method, returning every violation
+// found.
+//
+// Only the Events and Todos of the Calendar are checked, and the Calendar must
+// contain one or more of either, but not both. Unless the Method is PUBLISH,
+// every component must have the same UID.
+//
+// This does not repeat the checks made when encoding a Calendar.
+func ValidateITIP(c *Calendar) []*ITIPError {
+	if c.Method == nil {
+		return []*ITIPError{{Path: []string{"VCALENDAR"}, Property: "METHOD", Err: ErrMissingRequired}}
+	}
+
+	method := *c.Method
+
+	rules, ok := itipRules[method]
+	if !ok {
+		return []*ITIPError{{Method: method, Path: []string{"VCALENDAR"}, Property: "METHOD", Err: ErrInvalidMethod}}
+	}
+
+	var (
+		errs       []*ITIPError
+		components []scheduling
+		paths      [][]string
+	)
+
+	for n := range c.Event {
+		s, _ := schedulingOf(&c.Event[n])
+		components = append(components, s)
+		paths = append(paths, []string{"VCALENDAR", "VEVENT[" + strconv.Itoa(n) + "]"})
+	}
+
+	for n := range c.Todo {
+		s, _ := schedulingOf(&c.Todo[n])
+		components = append(components, s)
+		paths = append(paths, []string{"VCALENDAR", "VTODO[" + strconv.Itoa(n) + "]"})
+	}
+
+	if len(components) == 0 {
+		return []*ITIPError{{Method: method, Path: []string{"VCALENDAR"}, Err: ErrMissingComponent}}
+	}
+
+	for n, s := range components {
+		path := paths[n]
+
+		if s.name != components[0].name {
+			errs = append(errs, &ITIPError{Method: method, Path: path, Err: ErrInvalidComponent})
+		}
+
+		if method != MethodPublish && *s.uid != "" && *s.uid != *components[0].uid {
+			errs = append(errs, &ITIPError{Method: method, Path: path, Property: "UID", Err: ErrUIDMismatch})
+		}
+
+		counts := s.itipProperties()
+
+		for _, r := range rules {
+			if r.todoOnly && s.name != cTodo {
+				continue
+			}
+
+			var err error
+
+			switch count := counts[r.name]; {
+			case count < r.min:
+				err = ErrMissingRequired
+			case r.max == 0 && count > 0:
+				err = ErrPropertyNotAllowed
+			case r.max != itipMany && count > r.max:
+				err = ErrDuplicateProperty
+			default:
+				continue
+			}
+
+			errs = append(errs, &ITIPError{Method: method, Path: path, Property: r.name, Err: err})
+		}
+	}
+
+	return errs
+}
+
+// itipProperties returns the number of each property, and subcomponent,
+// restricted by iTIP.
+func (s scheduling) itipProperties() map[string]int {
+	props := map[string]int{
+		"ATTENDEE":       len(*s.attendee),
+		"DTSTAMP":        present(!s.stamp.IsZero()),
+		"DTSTART":        present(*s.start != nil),
+		"EXDATE":         len(*s.exdates),
+		"ORGANIZER":      present(*s.organizer != nil),
+		"RDATE":          len(*s.rdates),
+		"RECURRENCE-ID":  present(*s.recurrenceID != nil),
+		"REQUEST-STATUS": len(*s.requestStatus),
+		"SEQUENCE":       present(*s.sequence != nil),
+		"UID":            present(*s.uid != ""),
+		"VALARM":         len(*s.alarm),
+	}
+
+	switch c := s.component.(type) {
+	case *Event:
+		props["DTEND"] = present(c.DateTimeEnd != nil)
+		props["DURATION"] = present(c.Duration != nil)
+		props["RRULE"] = present(c.RecurrenceRule != nil)
+		props["SUMMARY"] = present(c.Summary != nil)
+	case *Todo:
+		props["DURATION"] = present(c.Duration != nil)
+		props["PRIORITY"] = present(c.Priority != nil)
+		props["RRULE"] = present(c.RecurrenceRule != nil)
+		props["SUMMARY"] = present(c.Summary != nil)
+	}
+
+	return props
+}
+
+func present(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// Errors.
+var (
+	ErrMissingComponent   = errors.New("missing component")
+	ErrPropertyNotAllowed = errors.New("property not allowed")
+	ErrUIDMismatch        = errors.New("UID does not match")
+)
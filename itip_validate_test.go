@@ -0,0 +1,188 @@
+package ics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateITIP(t *testing.T) {
+	cal, err := Decode(strings.NewReader(itipEvent))
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	}
+
+	i := ITIP{ProductID: "TEST", Now: func() time.Time { return time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC) }}
+	e := &cal.Event[0]
+	priority := PropPriority(1)
+	todo := &Todo{
+		DateTimeStamp: e.DateTimeStamp,
+		UID:           e.UID,
+		DateTimeStart: e.DateTimeStart,
+		Organizer:     e.Organizer,
+		Priority:      &priority,
+		Summary:       e.Summary,
+		Attendee:      e.Attendee,
+	}
+
+	type violation struct {
+		Path     string
+		Property string
+		Err      error
+	}
+
+	tests := []struct {
+		Build      func() (*Calendar, error)
+		Violations []violation
+	}{
+		{
+			Build: func() (*Calendar, error) { return i.Publish(e) },
+		},
+		{
+			Build: func() (*Calendar, error) { return i.Request(e, false) },
+		},
+		{
+			Build: func() (*Calendar, error) { return i.Reply(e, "mailto:bob@example.com", ParticipationStatusAccepted) },
+		},
+		{
+			Build: func() (*Calendar, error) { return i.Add(e) },
+		},
+		{
+			Build: func() (*Calendar, error) { return i.Cancel(e) },
+		},
+		{
+			Build: func() (*Calendar, error) { return i.Refresh(e, "mailto:bob@example.com") },
+		},
+		{
+			Build: func() (*Calendar, error) { return i.Counter(e) },
+		},
+		{
+			Build: func() (*Calendar, error) { return i.DeclineCounter(e) },
+		},
+		{
+			Build: func() (*Calendar, error) {
+				m, err := i.Publish(e)
+				if err == nil {
+					m.Event[0].Attendee = e.Attendee
+				}
+
+				return m, err
+			},
+			Violations: []violation{
+				{Path: "VCALENDAR > VEVENT[0]", Property: "ATTENDEE", Err: ErrPropertyNotAllowed},
+			},
+		},
+		{
+			Build: func() (*Calendar, error) {
+				m, err := i.Reply(e, "mailto:bob@example.com", ParticipationStatusAccepted)
+				if err == nil {
+					m.Event[0].Attendee = e.Attendee
+					m.Event[0].Alarm = e.Alarm
+				}
+
+				return m, err
+			},
+			Violations: []violation{
+				{Path: "VCALENDAR > VEVENT[0]", Property: "ATTENDEE", Err: ErrDuplicateProperty},
+				{Path: "VCALENDAR > VEVENT[0]", Property: "VALARM", Err: ErrPropertyNotAllowed},
+			},
+		},
+		{
+			Build: func() (*Calendar, error) {
+				m, err := i.Cancel(e)
+				if err == nil {
+					m.Event[0].Sequence = nil
+					m.Event[0].Organizer = nil
+				}
+
+				return m, err
+			},
+			Violations: []violation{
+				{Path: "VCALENDAR > VEVENT[0]", Property: "ORGANIZER", Err: ErrMissingRequired},
+				{Path: "VCALENDAR > VEVENT[0]", Property: "SEQUENCE", Err: ErrMissingRequired},
+			},
+		},
+		{
+			Build: func() (*Calendar, error) {
+				m, err := i.Request(e, false)
+				if err == nil {
+					m.Todo = []Todo{*todo}
+					m.Todo[0].UID = "other@example.com"
+				}
+
+				return m, err
+			},
+			Violations: []violation{
+				{Path: "VCALENDAR > VTODO[0]", Err: ErrInvalidComponent},
+				{Path: "VCALENDAR > VTODO[0]", Property: "UID", Err: ErrUIDMismatch},
+			},
+		},
+		{
+			Build: func() (*Calendar, error) {
+				m, err := i.Request(e, false)
+				if err == nil {
+					m.Event = nil
+				}
+
+				return m, err
+			},
+			Violations: []violation{
+				{Path: "VCALENDAR", Err: ErrMissingComponent},
+			},
+		},
+		{
+			Build: func() (*Calendar, error) { return i.Publish(todo) },
+		},
+		{
+			Build: func() (*Calendar, error) { return i.Request(todo, false) },
+		},
+		{
+			Build: func() (*Calendar, error) {
+				m, err := i.Publish(todo)
+				if err == nil {
+					m.Todo[0].DateTimeStart = nil
+					m.Todo[0].Priority = nil
+				}
+
+				return m, err
+			},
+			Violations: []violation{
+				{Path: "VCALENDAR > VTODO[0]", Property: "DTSTART", Err: ErrMissingRequired},
+				{Path: "VCALENDAR > VTODO[0]", Property: "PRIORITY", Err: ErrMissingRequired},
+			},
+		},
+		{
+			Build: func() (*Calendar, error) { return cal, nil },
+			Violations: []violation{
+				{Path: "VCALENDAR", Property: "METHOD", Err: ErrMissingRequired},
+			},
+		},
+	}
+
+	for n, test := range tests {
+		m, err := test.Build()
+		if err != nil {
+			t.Fatalf("test %d: unexpected error building message: %s", n+1, err)
+		}
+
+		errs := ValidateITIP(m)
+		if len(errs) != len(test.Violations) {
+			t.Errorf("test %d: expecting %d violations, got %d: %v", n+1, len(test.Violations), len(errs), errs)
+
+			continue
+		}
+
+		for v, err := range errs {
+			expected := test.Violations[v]
+
+			if path := strings.Join(err.Path, " > "); path != expected.Path {
+				t.Errorf("test %d.%d: expecting path %q, got %q", n+1, v+1, expected.Path, path)
+			} else if err.Property != expected.Property {
+				t.Errorf("test %d.%d: expecting property %q, got %q", n+1, v+1, expected.Property, err.Property)
+			} else if !errors.Is(err, expected.Err) {
+				t.Errorf("test %d.%d: expecting error %v, got %v", n+1, v+1, expected.Err, err.Err)
+			}
+		}
+	}
+}
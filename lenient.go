@@ -0,0 +1,81 @@
+package ics
+
+import (
+	"errors"
+	"io"
+)
+
+// lenientReader normalises the line endings and folding of its input so that
+// it can be read by the unfolder and tokeniser, reporting the first instance
+// of each problem.
+type lenientReader struct {
+	r         io.Reader
+	buf       [4096]byte
+	out, norm []byte
+	err       error
+	line      int
+	lastCR    bool
+	lineStart bool
+	warned    [2]bool
+	warn      func(int, error)
+}
+
+func (l *lenientReader) Read(p []byte) (int, error) {
+	for len(l.out) == 0 {
+		if l.err != nil {
+			return 0, l.err
+		}
+
+		var n int
+
+		n, l.err = l.r.Read(l.buf[:])
+		l.norm = l.normalise(l.norm[:0], l.buf[:n])
+		l.out = l.norm
+	}
+
+	n := copy(p, l.out)
+	l.out = l.out[n:]
+
+	return n, nil
+}
+
+func (l *lenientReader) normalise(out, in []byte) []byte {
+	for _, b := range in {
+		switch b {
+		case '\n':
+			if !l.lastCR {
+				l.problem(0, ErrInvalidLineEnding)
+
+				out = append(out, '\r')
+			}
+
+			l.line++
+		case '\t':
+			if l.lineStart {
+				l.problem(1, ErrInvalidFolding)
+
+				b = ' '
+			}
+		}
+
+		out = append(out, b)
+		l.lineStart = b == '\n'
+		l.lastCR = b == '\r'
+	}
+
+	return out
+}
+
+func (l *lenientReader) problem(n int, err error) {
+	if !l.warned[n] {
+		l.warned[n] = true
+
+		l.warn(l.line, err)
+	}
+}
+
+// Errors.
+var (
+	ErrInvalidLineEnding = errors.New("line ending without carriage return")
+	ErrInvalidFolding    = errors.New("line folded with tab")
+)
@@ -0,0 +1,737 @@
+package ics
+
+import (
+	"sort"
+	"time"
+)
+
+// Occurrence contains the timing of a single instance of a recurring
+// component.
+//
+// RecurrenceID is the original start of the instance, as generated by the
+// recurrence rules, whereas Start and End have had any overrides applied.
+type Occurrence struct {
+	RecurrenceID time.Time
+	Start, End   time.Time
+}
+
+type recurrence struct {
+	start   time.Time
+	end     func(time.Time) time.Time
+	rule    *Recur
+	rdates  []PropRecurrenceDateTimes
+	exdates []PropExceptionDateTime
+}
+
+type override struct {
+	id     time.Time
+	future bool
+	start  time.Time
+	end    func(time.Time) time.Time
+	index  int
+}
+
+type instanceDate struct {
+	start, end time.Time
+}
+
+type occurrenceIterator struct {
+	rule      *RecurIterator
+	ruleNext  time.Time
+	ruleOK    bool
+	dates     []instanceDate
+	exdates   []PropExceptionDateTime
+	end       func(time.Time) time.Time
+	overrides []override
+}
+
+func newOccurrenceIterator(r *recurrence, overrides []override) *occurrenceIterator {
+	o := &occurrenceIterator{
+		overrides: overrides,
+	}
+
+	sort.SliceStable(o.overrides, func(i, j int) bool {
+		return o.overrides[i].id.Before(o.overrides[j].id)
+	})
+
+	for _, ov := range o.overrides {
+		o.dates = append(o.dates, instanceDate{start: ov.id})
+	}
+
+	if r != nil {
+		o.end = r.end
+		o.exdates = r.exdates
+
+		if !r.start.IsZero() {
+			o.dates = append(o.dates, instanceDate{start: r.start})
+		}
+
+		if r.rule != nil {
+			o.rule = r.rule.Iterate(r.start)
+			o.ruleNext, o.ruleOK = o.rule.Next()
+
+			// DTSTART is the first instance, and so counts towards the
+			// COUNT even when the rule does not match it.
+			if o.rule.count > 0 && (!o.ruleOK || !o.ruleNext.Equal(r.start)) {
+				if o.rule.count == 1 {
+					o.ruleOK = false
+					o.rule.done = true
+				} else {
+					o.rule.count--
+				}
+			}
+		}
+
+		for _, rd := range r.rdates {
+			switch {
+			case rd.DateTime != nil:
+				o.dates = append(o.dates, instanceDate{start: rd.DateTime.Time})
+			case rd.Date != nil:
+				o.dates = append(o.dates, instanceDate{start: rd.Date.Time})
+			case rd.Period != nil:
+				o.dates = append(o.dates, instanceDate{start: rd.Period.Start.Time, end: rd.Period.end()})
+			}
+		}
+	}
+
+	sort.SliceStable(o.dates, func(i, j int) bool {
+		return o.dates[i].start.Before(o.dates[j].start)
+	})
+
+	return o
+}
+
+func (o *occurrenceIterator) next() (Occurrence, int, bool) {
+	for {
+		var (
+			t, end time.Time
+			ok     bool
+		)
+
+		if o.ruleOK {
+			t, ok = o.ruleNext, true
+		}
+
+		if len(o.dates) > 0 && (!ok || o.dates[0].start.Before(t)) {
+			t, ok = o.dates[0].start, true
+		}
+
+		if !ok {
+			return Occurrence{}, -1, false
+		}
+
+		for o.ruleOK && o.ruleNext.Equal(t) {
+			o.ruleNext, o.ruleOK = o.rule.Next()
+		}
+
+		for len(o.dates) > 0 && o.dates[0].start.Equal(t) {
+			if end.IsZero() {
+				end = o.dates[0].end
+			}
+
+			o.dates = o.dates[1:]
+		}
+
+		occ := Occurrence{
+			RecurrenceID: t,
+			Start:        t,
+		}
+
+		idx := -1
+		exact := false
+
+		for n, ov := range o.overrides {
+			if ov.id.After(t) {
+				break
+			} else if ov.id.Equal(t) {
+				idx, exact = n, true
+			} else if ov.future {
+				idx = n
+			}
+		}
+
+		if !exact && o.excluded(t) {
+			continue
+		} else if idx == -1 {
+			if end.IsZero() {
+				end = o.end(t)
+			}
+
+			occ.End = end
+
+			return occ, -1, true
+		}
+
+		ov := o.overrides[idx]
+
+		if exact {
+			occ.Start = ov.start
+		} else {
+			occ.Start = t.Add(ov.start.Sub(ov.id))
+		}
+
+		occ.End = ov.end(occ.Start)
+
+		return occ, ov.index, true
+	}
+}
+
+func (o *occurrenceIterator) excluded(t time.Time) bool {
+	for _, ex := range o.exdates {
+		if ex.DateTime != nil && ex.DateTime.Equal(t) {
+			return true
+		} else if ex.Date != nil {
+			y, m, d := t.Date()
+
+			if ey, em, ed := ex.Date.Date(); y == ey && m == em && d == ed {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (p *Period) end() time.Time {
+	if !p.End.IsZero() {
+		return p.End.Time
+	}
+
+	return p.Duration.add(p.Start.Time)
+}
+
+// add adds the duration to the given time, treating weeks and days as nominal
+// durations, as per RFC 5545 Section 3.3.6.
+func (d Duration) add(t time.Time) time.Time {
+	days := int(d.Weeks*7 + d.Days)
+	exact := time.Duration(d.Hours)*time.Hour + time.Duration(d.Minutes)*time.Minute + time.Duration(d.Seconds)*time.Second
+
+	if d.Negative {
+		return t.AddDate(0, 0, -days).Add(-exact)
+	}
+
+	return t.AddDate(0, 0, days).Add(exact)
+}
+
+func startTime(dt *DateTime, d *Date) (time.Time, bool) {
+	if dt != nil {
+		return dt.Time, false
+	} else if d != nil {
+		return d.Time, true
+	}
+
+	return time.Time{}, false
+}
+
+func endFunc(start time.Time, date bool, end time.Time, duration *PropDuration) func(time.Time) time.Time {
+	if !end.IsZero() {
+		diff := end.Sub(start)
+
+		return func(t time.Time) time.Time {
+			return t.Add(diff)
+		}
+	} else if duration != nil {
+		return func(t time.Time) time.Time {
+			return Duration(*duration).add(t)
+		}
+	} else if date {
+		return func(t time.Time) time.Time {
+			return t.AddDate(0, 0, 1)
+		}
+	}
+
+	return func(t time.Time) time.Time {
+		return t
+	}
+}
+
+func recurrenceID(r *PropRecurrenceID) (time.Time, bool) {
+	t, _ := startTime(r.DateTime, r.Date)
+
+	return t, r.Range != nil
+}
+
+func instanceStart(t time.Time, date bool) *PropDateTimeStart {
+	if date {
+		return &PropDateTimeStart{Date: &Date{t}}
+	}
+
+	return &PropDateTimeStart{DateTime: &DateTime{t}}
+}
+
+func instanceRecurrenceID(t time.Time, date bool) *PropRecurrenceID {
+	if date {
+		return &PropRecurrenceID{Date: &Date{t}}
+	}
+
+	return &PropRecurrenceID{DateTime: &DateTime{t}}
+}
+
+// EventSeries is an Event along with any Events, sharing its UID, that
+// override instances of it.
+//
+// Master will be nil if the Calendar only contains overriding instances.
+type EventSeries struct {
+	Master    *Event
+	Overrides []*Event
+}
+
+// EventSeries groups the Events in the Calendar into series by UID.
+func (c *Calendar) EventSeries() []EventSeries {
+	var (
+		series []EventSeries
+		uids   = make(map[PropUID]int)
+	)
+
+	for n := range c.Event {
+		e := &c.Event[n]
+		pos, ok := uids[e.UID]
+
+		if !ok || e.RecurrenceID == nil && series[pos].Master != nil {
+			pos = len(series)
+			uids[e.UID] = pos
+			series = append(series, EventSeries{})
+		}
+
+		if e.RecurrenceID == nil {
+			series[pos].Master = e
+		} else {
+			series[pos].Overrides = append(series[pos].Overrides, e)
+		}
+	}
+
+	return series
+}
+
+func newSeriesIterator(r *recurrence, ids []*PropRecurrenceID, rs []*recurrence) *occurrenceIterator {
+	ovs := make([]override, len(ids))
+
+	for n, id := range ids {
+		ovs[n].id, ovs[n].future = recurrenceID(id)
+		ovs[n].start, ovs[n].end, ovs[n].index = rs[n].start, rs[n].end, n
+	}
+
+	return newOccurrenceIterator(r, ovs)
+}
+
+func (e *Event) recurrence() (*recurrence, bool) {
+	var (
+		start, date = time.Time{}, false
+		end         time.Time
+	)
+
+	if e.DateTimeStart != nil {
+		start, date = startTime(e.DateTimeStart.DateTime, e.DateTimeStart.Date)
+	}
+
+	if e.DateTimeEnd != nil {
+		end, _ = startTime(e.DateTimeEnd.DateTime, e.DateTimeEnd.Date)
+	}
+
+	r := &recurrence{
+		start:   start,
+		end:     endFunc(start, date, end, e.Duration),
+		rdates:  e.RecurrenceDateTimes,
+		exdates: e.ExceptionDateTime,
+	}
+
+	if e.RecurrenceRule != nil && e.DateTimeStart != nil {
+		r.rule = (*Recur)(e.RecurrenceRule)
+	}
+
+	return r, date
+}
+
+// Occurrences returns an iterator over the instances of the series, ordered by
+// their RecurrenceID.
+//
+// The instances are generated from the DateTimeStart, RecurrenceRule and
+// RecurrenceDateTimes of the Master, with those listed in ExceptionDateTime
+// removed. Any instance matched by the RecurrenceID of an override is replaced
+// by that override, and overrides with a RANGE of THISANDFUTURE are applied to
+// all subsequent instances.
+func (s *EventSeries) Occurrences() *EventIterator {
+	var (
+		r    *recurrence
+		date bool
+		ids  = make([]*PropRecurrenceID, len(s.Overrides))
+		rs   = make([]*recurrence, len(s.Overrides))
+	)
+
+	if s.Master != nil {
+		r, date = s.Master.recurrence()
+	}
+
+	for n, o := range s.Overrides {
+		ids[n] = o.RecurrenceID
+		rs[n], _ = o.recurrence()
+	}
+
+	return &EventIterator{
+		series: s,
+		date:   date,
+		iter:   newSeriesIterator(r, ids, rs),
+	}
+}
+
+// EventOccurrence is a single instance of an EventSeries.
+//
+// Event is a copy of either the Master or the applicable override, with its
+// DateTimeStart, DateTimeEnd and RecurrenceID set for the instance and its
+// recurrence properties removed.
+type EventOccurrence struct {
+	Occurrence
+	Event *Event
+}
+
+// EventIterator iterates over the instances of an EventSeries.
+type EventIterator struct {
+	series *EventSeries
+	date   bool
+	iter   *occurrenceIterator
+}
+
+// Next returns the next instance of the series. The bool will be false when
+// there are no more instances.
+func (e *EventIterator) Next() (EventOccurrence, bool) {
+	o, idx, ok := e.iter.next()
+	if !ok {
+		return EventOccurrence{}, false
+	}
+
+	var ev Event
+
+	if idx == -1 {
+		ev = *e.series.Master
+
+		if ev.RecurrenceRule != nil || len(ev.RecurrenceDateTimes) > 0 {
+			ev.RecurrenceID = instanceRecurrenceID(o.RecurrenceID, e.date)
+		}
+	} else {
+		ev = *e.series.Overrides[idx]
+
+		if id, _ := recurrenceID(ev.RecurrenceID); !id.Equal(o.RecurrenceID) {
+			ev.RecurrenceID = instanceRecurrenceID(o.RecurrenceID, ev.RecurrenceID.Date != nil)
+		}
+	}
+
+	if ev.DateTimeStart != nil {
+		ev.DateTimeStart = instanceStart(o.Start, ev.DateTimeStart.Date != nil)
+	}
+
+	if ev.DateTimeEnd != nil {
+		if ev.DateTimeEnd.Date != nil {
+			ev.DateTimeEnd = &PropDateTimeEnd{Date: &Date{o.End}}
+		} else {
+			ev.DateTimeEnd = &PropDateTimeEnd{DateTime: &DateTime{o.End}}
+		}
+	}
+
+	ev.RecurrenceRule = nil
+	ev.RecurrenceDateTimes = nil
+	ev.ExceptionDateTime = nil
+
+	return EventOccurrence{
+		Occurrence: o,
+		Event:      &ev,
+	}, true
+}
+
+// TodoSeries is a Todo along with any Todos, sharing its UID, that override
+// instances of it.
+//
+// Master will be nil if the Calendar only contains overriding instances.
+type TodoSeries struct {
+	Master    *Todo
+	Overrides []*Todo
+}
+
+// TodoSeries groups the Todos in the Calendar into series by UID.
+func (c *Calendar) TodoSeries() []TodoSeries {
+	var (
+		series []TodoSeries
+		uids   = make(map[PropUID]int)
+	)
+
+	for n := range c.Todo {
+		t := &c.Todo[n]
+		pos, ok := uids[t.UID]
+
+		if !ok || t.RecurrenceID == nil && series[pos].Master != nil {
+			pos = len(series)
+			uids[t.UID] = pos
+			series = append(series, TodoSeries{})
+		}
+
+		if t.RecurrenceID == nil {
+			series[pos].Master = t
+		} else {
+			series[pos].Overrides = append(series[pos].Overrides, t)
+		}
+	}
+
+	return series
+}
+
+func (t *Todo) recurrence() (*recurrence, bool) {
+	var (
+		start, date = time.Time{}, false
+		due         time.Time
+	)
+
+	if t.DateTimeStart != nil {
+		start, date = startTime(t.DateTimeStart.DateTime, t.DateTimeStart.Date)
+	}
+
+	if t.Due != nil {
+		due, _ = startTime(t.Due.DateTime, t.Due.Date)
+	}
+
+	r := &recurrence{
+		start:   start,
+		end:     endFunc(start, date, due, t.Duration),
+		rdates:  t.RecurrenceDateTimes,
+		exdates: t.ExceptionDateTime,
+	}
+
+	if t.RecurrenceRule != nil && t.DateTimeStart != nil {
+		r.rule = (*Recur)(t.RecurrenceRule)
+	}
+
+	return r, date
+}
+
+// Occurrences returns an iterator over the instances of the series, ordered by
+// their RecurrenceID.
+//
+// The instances are generated in the same way as EventSeries.Occurrences, with
+// the Due time taking the place of the DateTimeEnd.
+func (s *TodoSeries) Occurrences() *TodoIterator {
+	var (
+		r    *recurrence
+		date bool
+		ids  = make([]*PropRecurrenceID, len(s.Overrides))
+		rs   = make([]*recurrence, len(s.Overrides))
+	)
+
+	if s.Master != nil {
+		r, date = s.Master.recurrence()
+	}
+
+	for n, o := range s.Overrides {
+		ids[n] = o.RecurrenceID
+		rs[n], _ = o.recurrence()
+	}
+
+	return &TodoIterator{
+		series: s,
+		date:   date,
+		iter:   newSeriesIterator(r, ids, rs),
+	}
+}
+
+// TodoOccurrence is a single instance of a TodoSeries.
+//
+// Todo is a copy of either the Master or the applicable override, with its
+// DateTimeStart, Due and RecurrenceID set for the instance and its recurrence
+// properties removed.
+type TodoOccurrence struct {
+	Occurrence
+	Todo *Todo
+}
+
+// TodoIterator iterates over the instances of a TodoSeries.
+type TodoIterator struct {
+	series *TodoSeries
+	date   bool
+	iter   *occurrenceIterator
+}
+
+// Next returns the next instance of the series. The bool will be false when
+// there are no more instances.
+func (t *TodoIterator) Next() (TodoOccurrence, bool) {
+	o, idx, ok := t.iter.next()
+	if !ok {
+		return TodoOccurrence{}, false
+	}
+
+	var td Todo
+
+	if idx == -1 {
+		td = *t.series.Master
+
+		if td.RecurrenceRule != nil || len(td.RecurrenceDateTimes) > 0 {
+			td.RecurrenceID = instanceRecurrenceID(o.RecurrenceID, t.date)
+		}
+	} else {
+		td = *t.series.Overrides[idx]
+
+		if id, _ := recurrenceID(td.RecurrenceID); !id.Equal(o.RecurrenceID) {
+			td.RecurrenceID = instanceRecurrenceID(o.RecurrenceID, td.RecurrenceID.Date != nil)
+		}
+	}
+
+	if td.DateTimeStart != nil {
+		td.DateTimeStart = instanceStart(o.Start, td.DateTimeStart.Date != nil)
+	}
+
+	if td.Due != nil {
+		if td.Due.Date != nil {
+			td.Due = &PropDue{Date: &Date{o.End}}
+		} else {
+			td.Due = &PropDue{DateTime: &DateTime{o.End}}
+		}
+	}
+
+	td.RecurrenceRule = nil
+	td.RecurrenceDateTimes = nil
+	td.ExceptionDateTime = nil
+
+	return TodoOccurrence{
+		Occurrence: o,
+		Todo:       &td,
+	}, true
+}
+
+// JournalSeries is a Journal along with any Journals, sharing its UID, that
+// override instances of it.
+//
+// Master will be nil if the Calendar only contains overriding instances.
+type JournalSeries struct {
+	Master    *Journal
+	Overrides []*Journal
+}
+
+// JournalSeries groups the Journals in the Calendar into series by UID.
+func (c *Calendar) JournalSeries() []JournalSeries {
+	var (
+		series []JournalSeries
+		uids   = make(map[PropUID]int)
+	)
+
+	for n := range c.Journal {
+		j := &c.Journal[n]
+		pos, ok := uids[j.UID]
+
+		if !ok || j.RecurrenceID == nil && series[pos].Master != nil {
+			pos = len(series)
+			uids[j.UID] = pos
+			series = append(series, JournalSeries{})
+		}
+
+		if j.RecurrenceID == nil {
+			series[pos].Master = j
+		} else {
+			series[pos].Overrides = append(series[pos].Overrides, j)
+		}
+	}
+
+	return series
+}
+
+func (j *Journal) recurrence() (*recurrence, bool) {
+	var start, date = time.Time{}, false
+
+	if j.DateTimeStart != nil {
+		start, date = startTime(j.DateTimeStart.DateTime, j.DateTimeStart.Date)
+	}
+
+	r := &recurrence{
+		start:   start,
+		end:     endFunc(start, date, time.Time{}, nil),
+		rdates:  j.RecurrenceDateTimes,
+		exdates: j.ExceptionDateTime,
+	}
+
+	if j.RecurrenceRule != nil && j.DateTimeStart != nil {
+		r.rule = (*Recur)(j.RecurrenceRule)
+	}
+
+	return r, date
+}
+
+// Occurrences returns an iterator over the instances of the series, ordered by
+// their RecurrenceID.
+//
+// The instances are generated in the same way as EventSeries.Occurrences.
+// Journals have no duration, so the End of each Occurrence will be the same as
+// its Start, unless the DateTimeStart is a Date, in which case it will be the
+// following day.
+func (s *JournalSeries) Occurrences() *JournalIterator {
+	var (
+		r    *recurrence
+		date bool
+		ids  = make([]*PropRecurrenceID, len(s.Overrides))
+		rs   = make([]*recurrence, len(s.Overrides))
+	)
+
+	if s.Master != nil {
+		r, date = s.Master.recurrence()
+	}
+
+	for n, o := range s.Overrides {
+		ids[n] = o.RecurrenceID
+		rs[n], _ = o.recurrence()
+	}
+
+	return &JournalIterator{
+		series: s,
+		date:   date,
+		iter:   newSeriesIterator(r, ids, rs),
+	}
+}
+
+// JournalOccurrence is a single instance of a JournalSeries.
+//
+// Journal is a copy of either the Master or the applicable override, with its
+// DateTimeStart and RecurrenceID set for the instance and its recurrence
+// properties removed.
+type JournalOccurrence struct {
+	Occurrence
+	Journal *Journal
+}
+
+// JournalIterator iterates over the instances of a JournalSeries.
+type JournalIterator struct {
+	series *JournalSeries
+	date   bool
+	iter   *occurrenceIterator
+}
+
+// Next returns the next instance of the series. The bool will be false when
+// there are no more instances.
+func (j *JournalIterator) Next() (JournalOccurrence, bool) {
+	o, idx, ok := j.iter.next()
+	if !ok {
+		return JournalOccurrence{}, false
+	}
+
+	var jn Journal
+
+	if idx == -1 {
+		jn = *j.series.Master
+
+		if jn.RecurrenceRule != nil || len(jn.RecurrenceDateTimes) > 0 {
+			jn.RecurrenceID = instanceRecurrenceID(o.RecurrenceID, j.date)
+		}
+	} else {
+		jn = *j.series.Overrides[idx]
+
+		if id, _ := recurrenceID(jn.RecurrenceID); !id.Equal(o.RecurrenceID) {
+			jn.RecurrenceID = instanceRecurrenceID(o.RecurrenceID, jn.RecurrenceID.Date != nil)
+		}
+	}
+
+	if jn.DateTimeStart != nil {
+		jn.DateTimeStart = instanceStart(o.Start, jn.DateTimeStart.Date != nil)
+	}
+
+	jn.RecurrenceRule = nil
+	jn.RecurrenceDateTimes = nil
+	jn.ExceptionDateTime = nil
+
+	return JournalOccurrence{
+		Occurrence: o,
+		Journal:    &jn,
+	}, true
+}
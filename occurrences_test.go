@@ -0,0 +1,217 @@
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventOccurrences(t *testing.T) {
+	cal, err := Decode(strings.NewReader("BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:TEST\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:series@example.com\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"DTSTART:20200106T100000Z\r\n" +
+		"DTEND:20200106T110000Z\r\n" +
+		"SUMMARY:Weekly\r\n" +
+		"RRULE:FREQ=WEEKLY;COUNT=6\r\n" +
+		"EXDATE:20200120T100000Z\r\n" +
+		"RDATE;VALUE=PERIOD:20200108T150000Z/PT30M\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:series@example.com\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"RECURRENCE-ID:20200113T100000Z\r\n" +
+		"DTSTART:20200114T120000Z\r\n" +
+		"DTEND:20200114T130000Z\r\n" +
+		"SUMMARY:Moved\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:series@example.com\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"RECURRENCE-ID;RANGE=THISANDFUTURE:20200203T100000Z\r\n" +
+		"DTSTART:20200203T090000Z\r\n" +
+		"DURATION:PT2H\r\n" +
+		"SUMMARY:Earlier\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:single@example.com\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"DTSTART;VALUE=DATE:20200301\r\n" +
+		"SUMMARY:Single\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"))
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	}
+
+	series := cal.EventSeries()
+
+	if len(series) != 2 {
+		t.Fatalf("expecting 2 series, got %d", len(series))
+	} else if len(series[0].Overrides) != 2 {
+		t.Fatalf("expecting 2 overrides, got %d", len(series[0].Overrides))
+	}
+
+	tests := []struct {
+		Series                   int
+		RecurrenceID, Start, End time.Time
+		Summary                  PropSummary
+	}{
+		{0, time.Date(2020, 1, 6, 10, 0, 0, 0, time.UTC), time.Date(2020, 1, 6, 10, 0, 0, 0, time.UTC), time.Date(2020, 1, 6, 11, 0, 0, 0, time.UTC), PropSummary{Text: "Weekly"}},
+		{0, time.Date(2020, 1, 8, 15, 0, 0, 0, time.UTC), time.Date(2020, 1, 8, 15, 0, 0, 0, time.UTC), time.Date(2020, 1, 8, 15, 30, 0, 0, time.UTC), PropSummary{Text: "Weekly"}},
+		{0, time.Date(2020, 1, 13, 10, 0, 0, 0, time.UTC), time.Date(2020, 1, 14, 12, 0, 0, 0, time.UTC), time.Date(2020, 1, 14, 13, 0, 0, 0, time.UTC), PropSummary{Text: "Moved"}},
+		{0, time.Date(2020, 1, 27, 10, 0, 0, 0, time.UTC), time.Date(2020, 1, 27, 10, 0, 0, 0, time.UTC), time.Date(2020, 1, 27, 11, 0, 0, 0, time.UTC), PropSummary{Text: "Weekly"}},
+		{0, time.Date(2020, 2, 3, 10, 0, 0, 0, time.UTC), time.Date(2020, 2, 3, 9, 0, 0, 0, time.UTC), time.Date(2020, 2, 3, 11, 0, 0, 0, time.UTC), PropSummary{Text: "Earlier"}},
+		{0, time.Date(2020, 2, 10, 10, 0, 0, 0, time.UTC), time.Date(2020, 2, 10, 9, 0, 0, 0, time.UTC), time.Date(2020, 2, 10, 11, 0, 0, 0, time.UTC), PropSummary{Text: "Earlier"}},
+		{1, time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC), PropSummary{Text: "Single"}},
+	}
+
+	var n int
+
+	for s := range series {
+		it := series[s].Occurrences()
+
+		for {
+			o, ok := it.Next()
+			if !ok {
+				break
+			}
+
+			if n >= len(tests) {
+				t.Errorf("test %d: unexpected occurrence: %v", n+1, o.Occurrence)
+			} else if test := tests[n]; test.Series != s {
+				t.Errorf("test %d: expecting occurrence in series %d, got %d", n+1, test.Series, s)
+			} else if !o.RecurrenceID.Equal(test.RecurrenceID) {
+				t.Errorf("test %d: expecting recurrence id %s, got %s", n+1, test.RecurrenceID, o.RecurrenceID)
+			} else if !o.Start.Equal(test.Start) {
+				t.Errorf("test %d: expecting start %s, got %s", n+1, test.Start, o.Start)
+			} else if !o.End.Equal(test.End) {
+				t.Errorf("test %d: expecting end %s, got %s", n+1, test.End, o.End)
+			} else if o.Event.Summary.Text != test.Summary.Text {
+				t.Errorf("test %d: expecting summary %q, got %q", n+1, test.Summary.Text, o.Event.Summary.Text)
+			} else if o.Event.RecurrenceRule != nil {
+				t.Errorf("test %d: expecting no recurrence rule", n+1)
+			}
+
+			n++
+		}
+	}
+
+	if n != len(tests) {
+		t.Errorf("expecting %d occurrences, got %d", len(tests), n)
+	}
+}
+
+func TestTodoOccurrences(t *testing.T) {
+	cal, err := Decode(strings.NewReader("BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:TEST\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:series@example.com\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"DTSTART:20200105T090000Z\r\n" +
+		"DUE:20200105T170000Z\r\n" +
+		"SUMMARY:Report\r\n" +
+		"RRULE:FREQ=WEEKLY;BYDAY=MO;COUNT=3\r\n" +
+		"END:VTODO\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:series@example.com\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"RECURRENCE-ID:20200106T090000Z\r\n" +
+		"DTSTART:20200107T090000Z\r\n" +
+		"DUE:20200107T120000Z\r\n" +
+		"SUMMARY:Late report\r\n" +
+		"END:VTODO\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:undated@example.com\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"SUMMARY:Someday\r\n" +
+		"END:VTODO\r\n" +
+		"END:VCALENDAR\r\n"))
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	}
+
+	series := cal.TodoSeries()
+	if len(series) != 2 {
+		t.Fatalf("expecting 2 series, got %d", len(series))
+	}
+
+	at := func(d, h int) time.Time {
+		return time.Date(2020, 1, d, h, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		RecurrenceID, Start, End time.Time
+		Summary                  Text
+	}{
+		{at(5, 9), at(5, 9), at(5, 17), "Report"},
+		{at(6, 9), at(7, 9), at(7, 12), "Late report"},
+		{at(13, 9), at(13, 9), at(13, 17), "Report"},
+	}
+
+	it := series[0].Occurrences()
+
+	for n, test := range tests {
+		o, ok := it.Next()
+		if !ok {
+			t.Fatalf("test %d: expecting occurrence", n+1)
+		} else if !o.RecurrenceID.Equal(test.RecurrenceID) || !o.Start.Equal(test.Start) || !o.End.Equal(test.End) {
+			t.Errorf("test %d: expecting occurrence %s (%s to %s), got %s (%s to %s)", n+1, test.RecurrenceID, test.Start, test.End, o.RecurrenceID, o.Start, o.End)
+		} else if o.Todo.Summary.Text != test.Summary {
+			t.Errorf("test %d: expecting summary %q, got %q", n+1, test.Summary, o.Todo.Summary.Text)
+		}
+	}
+
+	if o, ok := it.Next(); ok {
+		t.Errorf("expecting DTSTART to count towards COUNT, got extra occurrence %s", o.RecurrenceID)
+	}
+
+	if o, ok := series[1].Occurrences().Next(); ok {
+		t.Errorf("expecting no occurrences without DTSTART, got %s", o.RecurrenceID)
+	}
+}
+
+func TestJournalOccurrences(t *testing.T) {
+	cal, err := Decode(strings.NewReader("BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:TEST\r\n" +
+		"BEGIN:VJOURNAL\r\n" +
+		"UID:series@example.com\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"DTSTART;VALUE=DATE:20200101\r\n" +
+		"SUMMARY:Notes\r\n" +
+		"RRULE:FREQ=MONTHLY;COUNT=3\r\n" +
+		"EXDATE;VALUE=DATE:20200201\r\n" +
+		"END:VJOURNAL\r\n" +
+		"END:VCALENDAR\r\n"))
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	}
+
+	series := cal.JournalSeries()
+	if len(series) != 1 {
+		t.Fatalf("expecting 1 series, got %d", len(series))
+	}
+
+	it := series[0].Occurrences()
+
+	for n, month := range [...]time.Month{time.January, time.March} {
+		start := time.Date(2020, month, 1, 0, 0, 0, 0, time.UTC)
+
+		if o, ok := it.Next(); !ok {
+			t.Fatalf("test %d: expecting occurrence", n+1)
+		} else if !o.Start.Equal(start) || !o.End.Equal(start.AddDate(0, 0, 1)) {
+			t.Errorf("test %d: expecting occurrence on %s, got %s to %s", n+1, start, o.Start, o.End)
+		} else if o.Journal.Summary.Text != "Notes" {
+			t.Errorf("test %d: expecting summary %q, got %q", n+1, "Notes", o.Journal.Summary.Text)
+		}
+	}
+
+	if o, ok := it.Next(); ok {
+		t.Errorf("expecting no more occurrences, got %s", o.RecurrenceID)
+	}
+}
@@ -44,7 +44,9 @@ func (t ParamAlternativeRepresentation) encode(w writer) {
 
 	q := URI(t)
 
+	w.WriteString("\"")
 	q.encode(w)
+	w.WriteString("\"")
 }
 
 func (t ParamAlternativeRepresentation) valid() error {
@@ -194,7 +196,9 @@ func (t ParamDelegator) encode(w writer) {
 
 		q := CalendarAddress(v)
 
+		w.WriteString("\"")
 		q.encode(w)
+		w.WriteString("\"")
 	}
 }
 
@@ -243,7 +247,9 @@ func (t ParamDelagatee) encode(w writer) {
 
 		q := CalendarAddress(v)
 
+		w.WriteString("\"")
 		q.encode(w)
+		w.WriteString("\"")
 	}
 }
 
@@ -704,20 +710,11 @@ func (t ParamRelated) valid() error {
 }
 
 // ParamRelationshipType.
-type ParamRelationshipType uint8
-
-// RelationshipType constant values.
-const (
-	RelationshipTypeUnknown ParamRelationshipType = iota
-	RelationshipTypeParent
-	RelationshipTypeChild
-	RelationshipTypeSibling
-)
+type ParamRelationshipType string
 
-// New returns a pointer to the type (used with constants for ease of use with
-// optional values).
-func (t ParamRelationshipType) New() *ParamRelationshipType {
-	return &t
+// NewRelationshipType returns a *ParamRelationshipType for ease of use with optional values.
+func NewRelationshipType(v ParamRelationshipType) *ParamRelationshipType {
+	return &v
 }
 
 func (t *ParamRelationshipType) decode(vs []parser.Token) error {
@@ -725,36 +722,32 @@ func (t *ParamRelationshipType) decode(vs []parser.Token) error {
 		return fmt.Errorf(errDecodingType, cRelationshipType, ErrInvalidParam)
 	}
 
-	switch strings.ToUpper(vs[0].Data) {
-	case "PARENT":
-		*t = RelationshipTypeParent
-	case "CHILD":
-		*t = RelationshipTypeChild
-	case "SIBLING":
-		*t = RelationshipTypeSibling
-	default:
-		*t = RelationshipTypeUnknown
-	}
+	*t = ParamRelationshipType(decode6868(vs[0].Data))
 
 	return nil
 }
 
 func (t ParamRelationshipType) encode(w writer) {
+	if len(t) == 0 {
+		return
+	}
+
 	w.WriteString(";RELTYPE=")
 
-	switch t {
-	case RelationshipTypeParent:
-		w.WriteString("PARENT")
-	case RelationshipTypeChild:
-		w.WriteString("CHILD")
-	case RelationshipTypeSibling:
-		w.WriteString("SIBLING")
-	default:
-		w.WriteString("UNKNOWN")
+	if strings.ContainsAny(string(t), nonsafeChars[32:]) {
+		w.WriteString("\"")
+		w.Write(encode6868(string(t)))
+		w.WriteString("\"")
+	} else {
+		w.Write(encode6868(string(t)))
 	}
 }
 
 func (t ParamRelationshipType) valid() error {
+	if strings.ContainsAny(string(t), nonsafeChars[:31]) {
+		return fmt.Errorf(errValidatingType, cRelationshipType, ErrInvalidText)
+	}
+
 	return nil
 }
 
@@ -1084,7 +1077,9 @@ func (t ParamURI) encode(w writer) {
 
 	q := URI(t)
 
+	w.WriteString("\"")
 	q.encode(w)
+	w.WriteString("\"")
 }
 
 func (t ParamURI) valid() error {
@@ -1181,6 +1176,310 @@ func (t ParamAgentID) valid() error {
 	return nil
 }
 
+// ParamDisplay is a list of the ways in which an image can be displayed.
+type ParamDisplay []string
+
+// NewDisplay returns a *ParamDisplay for ease of use with optional values.
+func NewDisplay(v ParamDisplay) *ParamDisplay {
+	return &v
+}
+
+func (t *ParamDisplay) decode(vs []parser.Token) error {
+	for _, v := range vs {
+		*t = append(*t, decode6868(v.Data))
+	}
+
+	return nil
+}
+
+func (t ParamDisplay) encode(w writer) {
+	if len(t) == 0 {
+		return
+	}
+
+	w.WriteString(";DISPLAY=")
+
+	for n, v := range t {
+		if n > 0 {
+			w.WriteString(",")
+		}
+
+		if strings.ContainsAny(string(v), nonsafeChars[32:]) {
+			w.WriteString("\"")
+			w.Write(encode6868(string(v)))
+			w.WriteString("\"")
+		} else {
+			w.Write(encode6868(string(v)))
+		}
+	}
+}
+
+func (t ParamDisplay) valid() error {
+	for _, v := range t {
+		if strings.ContainsAny(string(v), nonsafeChars[:31]) {
+			return fmt.Errorf(errValidatingType, cDisplay, ErrInvalidText)
+		}
+	}
+
+	return nil
+}
+
+// ParamFeature is a list of the features of a conferencing system.
+type ParamFeature []string
+
+// NewFeature returns a *ParamFeature for ease of use with optional values.
+func NewFeature(v ParamFeature) *ParamFeature {
+	return &v
+}
+
+func (t *ParamFeature) decode(vs []parser.Token) error {
+	for _, v := range vs {
+		*t = append(*t, decode6868(v.Data))
+	}
+
+	return nil
+}
+
+func (t ParamFeature) encode(w writer) {
+	if len(t) == 0 {
+		return
+	}
+
+	w.WriteString(";FEATURE=")
+
+	for n, v := range t {
+		if n > 0 {
+			w.WriteString(",")
+		}
+
+		if strings.ContainsAny(string(v), nonsafeChars[32:]) {
+			w.WriteString("\"")
+			w.Write(encode6868(string(v)))
+			w.WriteString("\"")
+		} else {
+			w.Write(encode6868(string(v)))
+		}
+	}
+}
+
+func (t ParamFeature) valid() error {
+	for _, v := range t {
+		if strings.ContainsAny(string(v), nonsafeChars[:31]) {
+			return fmt.Errorf(errValidatingType, cFeature, ErrInvalidText)
+		}
+	}
+
+	return nil
+}
+
+// ParamLabel.
+type ParamLabel string
+
+// NewLabel returns a *ParamLabel for ease of use with optional values.
+func NewLabel(v ParamLabel) *ParamLabel {
+	return &v
+}
+
+func (t *ParamLabel) decode(vs []parser.Token) error {
+	if len(vs) != 1 {
+		return fmt.Errorf(errDecodingType, cLabel, ErrInvalidParam)
+	}
+
+	*t = ParamLabel(decode6868(vs[0].Data))
+
+	return nil
+}
+
+func (t ParamLabel) encode(w writer) {
+	if len(t) == 0 {
+		return
+	}
+
+	w.WriteString(";LABEL=")
+
+	if strings.ContainsAny(string(t), nonsafeChars[32:]) {
+		w.WriteString("\"")
+		w.Write(encode6868(string(t)))
+		w.WriteString("\"")
+	} else {
+		w.Write(encode6868(string(t)))
+	}
+}
+
+func (t ParamLabel) valid() error {
+	if strings.ContainsAny(string(t), nonsafeChars[:31]) {
+		return fmt.Errorf(errValidatingType, cLabel, ErrInvalidText)
+	}
+
+	return nil
+}
+
+// ParamDerived.
+type ParamDerived Boolean
+
+// NewDerived returns a *ParamDerived for ease of use with optional values.
+func NewDerived(v ParamDerived) *ParamDerived {
+	return &v
+}
+
+func (t *ParamDerived) decode(vs []parser.Token) error {
+	if len(vs) != 1 {
+		return fmt.Errorf(errDecodingType, cDerived, ErrInvalidParam)
+	}
+
+	var q Boolean
+
+	if err := q.decode(nil, vs[0].Data); err != nil {
+		return fmt.Errorf(errDecodingType, cDerived, err)
+	}
+
+	*t = ParamDerived(q)
+
+	return nil
+}
+
+func (t ParamDerived) encode(w writer) {
+	if !t {
+		return
+	}
+
+	w.WriteString(";DERIVED=")
+
+	q := Boolean(t)
+
+	q.encode(w)
+}
+
+func (t ParamDerived) valid() error {
+	return nil
+}
+
+// ParamSchema.
+type ParamSchema URI
+
+func (t *ParamSchema) decode(vs []parser.Token) error {
+	if len(vs) != 1 {
+		return fmt.Errorf(errDecodingType, cSchema, ErrInvalidParam)
+	}
+
+	if vs[0].Type != tokenParamQuotedValue {
+		return fmt.Errorf(errDecodingType, cSchema, ErrInvalidParam)
+	}
+
+	var q URI
+
+	if err := q.decode(nil, vs[0].Data); err != nil {
+		return fmt.Errorf(errDecodingType, cSchema, err)
+	}
+
+	*t = ParamSchema(q)
+
+	return nil
+}
+
+func (t ParamSchema) encode(w writer) {
+	if len(t.String()) == 0 {
+		return
+	}
+
+	w.WriteString(";SCHEMA=")
+
+	q := URI(t)
+
+	w.WriteString("\"")
+	q.encode(w)
+	w.WriteString("\"")
+}
+
+func (t ParamSchema) valid() error {
+	q := URI(t)
+
+	if err := q.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cSchema, err)
+	}
+
+	return nil
+}
+
+// ParamGap.
+type ParamGap Duration
+
+func (t *ParamGap) decode(vs []parser.Token) error {
+	if len(vs) != 1 {
+		return fmt.Errorf(errDecodingType, cGap, ErrInvalidParam)
+	}
+
+	var q Duration
+
+	if err := q.decode(nil, vs[0].Data); err != nil {
+		return fmt.Errorf(errDecodingType, cGap, err)
+	}
+
+	*t = ParamGap(q)
+
+	return nil
+}
+
+func (t ParamGap) encode(w writer) {
+	w.WriteString(";GAP=")
+
+	q := Duration(t)
+
+	q.encode(w)
+}
+
+func (t ParamGap) valid() error {
+	q := Duration(t)
+
+	if err := q.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cGap, err)
+	}
+
+	return nil
+}
+
+// ParamLinkRelation.
+type ParamLinkRelation string
+
+// NewLinkRelation returns a *ParamLinkRelation for ease of use with optional values.
+func NewLinkRelation(v ParamLinkRelation) *ParamLinkRelation {
+	return &v
+}
+
+func (t *ParamLinkRelation) decode(vs []parser.Token) error {
+	if len(vs) != 1 {
+		return fmt.Errorf(errDecodingType, cLinkRelation, ErrInvalidParam)
+	}
+
+	*t = ParamLinkRelation(decode6868(vs[0].Data))
+
+	return nil
+}
+
+func (t ParamLinkRelation) encode(w writer) {
+	if len(t) == 0 {
+		return
+	}
+
+	w.WriteString(";LINKREL=")
+
+	if strings.ContainsAny(string(t), nonsafeChars[32:]) {
+		w.WriteString("\"")
+		w.Write(encode6868(string(t)))
+		w.WriteString("\"")
+	} else {
+		w.Write(encode6868(string(t)))
+	}
+}
+
+func (t ParamLinkRelation) valid() error {
+	if strings.ContainsAny(string(t), nonsafeChars[:31]) {
+		return fmt.Errorf(errValidatingType, cLinkRelation, ErrInvalidText)
+	}
+
+	return nil
+}
+
 func decode6868(s string) string {
 	t := parser.NewStringTokeniser(s)
 	d := make([]byte, 0, len(s))
@@ -1194,7 +1493,7 @@ Loop:
 			break Loop
 		case '^':
 			t.Accept("^")
-			switch t.Next() {
+			switch n := t.Next(); n {
 			case -1:
 				d = append(d, '^')
 				break Loop
@@ -1206,9 +1505,10 @@ Loop:
 				d = append(d, '^')
 			default:
 				d = append(d, '^')
-				l := utf8.EncodeRune(ru[:], c)
+				l := utf8.EncodeRune(ru[:], n)
 				d = append(d, ru[:l]...)
 			}
+			t.Get()
 		}
 	}
 	return string(d)
@@ -1231,6 +1531,8 @@ Loop:
 		case '"':
 			d = append(d, '^', '\'')
 		}
+		t.Next()
+		t.Get()
 	}
 	return d
 }
@@ -1271,4 +1573,11 @@ const (
 	cURI                       = "URI"
 	cID                        = "ID"
 	cAgentID                   = "AgentID"
+	cDisplay                   = "Display"
+	cFeature                   = "Feature"
+	cLabel                     = "Label"
+	cDerived                   = "Derived"
+	cSchema                    = "Schema"
+	cGap                       = "Gap"
+	cLinkRelation              = "LinkRelation"
 )
@@ -0,0 +1,25 @@
+package ics
+
+// RFC 9073 participant types. Other values, such as x-names, are retained as
+// they appear.
+const (
+	ParticipantTypeActive           PropParticipantType = "ACTIVE"
+	ParticipantTypeInactive         PropParticipantType = "INACTIVE"
+	ParticipantTypeSponsor          PropParticipantType = "SPONSOR"
+	ParticipantTypeContact          PropParticipantType = "CONTACT"
+	ParticipantTypeBookingContact   PropParticipantType = "BOOKING-CONTACT"
+	ParticipantTypeEmergencyContact PropParticipantType = "EMERGENCY-CONTACT"
+	ParticipantTypePublicityContact PropParticipantType = "PUBLICITY-CONTACT"
+	ParticipantTypePlannerContact   PropParticipantType = "PLANNER-CONTACT"
+	ParticipantTypePerformer        PropParticipantType = "PERFORMER"
+	ParticipantTypeSpeaker          PropParticipantType = "SPEAKER"
+)
+
+// RFC 9073 resource types. Other values, such as x-names, are retained as they
+// appear.
+const (
+	ResourceTypeProjector             PropResourceType = "PROJECTOR"
+	ResourceTypeRoom                  PropResourceType = "ROOM"
+	ResourceTypeRemoteConferenceAudio PropResourceType = "REMOTE-CONFERENCE-AUDIO"
+	ResourceTypeRemoteConferenceVideo PropResourceType = "REMOTE-CONFERENCE-VIDEO"
+)
@@ -0,0 +1,347 @@
+package ics
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+// lineReader counts the physical lines read from the underlying reader,
+// recording the line on which each content line starts.
+type lineReader struct {
+	r        io.Reader
+	line     int
+	newLine  bool
+	lastByte byte
+	starts   []int
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{r: r, line: 1, newLine: true}
+}
+
+func (l *lineReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+
+	for _, b := range p[:n] {
+		if l.newLine {
+			l.newLine = false
+
+			if b != ' ' {
+				l.starts = append(l.starts, l.line)
+			}
+		}
+
+		if b == '\n' {
+			l.line++
+			l.newLine = l.lastByte == '\r'
+		}
+
+		l.lastByte = b
+	}
+
+	return n, err
+}
+
+// next returns the line on which the next unread content line starts.
+func (l *lineReader) next() int {
+	if len(l.starts) == 0 {
+		return l.line
+	}
+
+	line := l.starts[0]
+	l.starts = l.starts[1:]
+
+	return line
+}
+
+type position struct {
+	line   int
+	phrase parser.Phrase
+	path   []string
+}
+
+// lineTokeniser tracks the position of each phrase it reads so that decoding
+// errors can be reported as a DecodeError.
+type lineTokeniser struct {
+	*parser.Parser
+	lines    *lineReader
+	pos      position
+	frames   []map[string]int
+	pop      bool
+	lenient  bool
+	warnings []*Warning
+
+	// timezones and tzids record, by name, the position of the TZID
+	// property of each Timezone and of the first TZID parameter, so that
+	// errors found while resolving times can be reported against them.
+	timezones map[string]position
+	tzids     map[string]position
+}
+
+func newLineTokeniser(r io.Reader, opts DecodeOptions) *lineTokeniser {
+	t := &lineTokeniser{lenient: opts.Lenient}
+
+	if opts.Lenient {
+		r = &lenientReader{r: r, line: 1, warn: t.warnLine}
+	}
+
+	t.lines = newLineReader(r)
+	t.Parser = newTokeniser(&unfolder{r: t.lines})
+
+	return t
+}
+
+func (l *lineTokeniser) GetPhrase() (parser.Phrase, error) {
+	if l.pop {
+		l.frames = l.frames[:len(l.frames)-1]
+		l.pos.path = l.pos.path[:len(l.pos.path)-1]
+		l.pop = false
+	}
+
+	p, err := l.Parser.GetPhrase()
+
+	l.pos.line = l.lines.next()
+	l.pos.phrase = p
+
+	if p.Type == phraseContentLine {
+		switch strings.ToUpper(p.Data[0].Data) {
+		case "BEGIN":
+			l.push(strings.ToUpper(p.Data[len(p.Data)-1].Data))
+		case "END":
+			l.pop = len(l.frames) > 0
+		case "TZID":
+			if n := len(l.pos.path); n > 0 && strings.HasPrefix(l.pos.path[n-1], "VTIMEZONE") {
+				recordPosition(&l.timezones, p.Data[len(p.Data)-1].Data, l.pos)
+			}
+		default:
+			if tzid, ok := tzidParam(p); ok {
+				recordPosition(&l.tzids, tzid, l.pos)
+			}
+		}
+	}
+
+	return p, err
+}
+
+// tzidParam returns the value of the last TZID parameter of the content line.
+func tzidParam(p parser.Phrase) (string, bool) {
+	var (
+		tzid        string
+		found, next bool
+	)
+
+	for _, tk := range p.Data[1 : len(p.Data)-1] {
+		switch tk.Type {
+		case tokenParamName:
+			next = strings.ToUpper(tk.Data) == "TZID"
+		case tokenParamValue, tokenParamQuotedValue:
+			if next {
+				tzid, found, next = tk.Data, true, false
+			}
+		}
+	}
+
+	return tzid, found
+}
+
+func recordPosition(m *map[string]position, name string, pos position) {
+	if *m == nil {
+		*m = make(map[string]position)
+	}
+
+	if _, ok := (*m)[name]; !ok {
+		(*m)[name] = pos
+	}
+}
+
+func (l *lineTokeniser) push(name string) {
+	elem := name
+
+	if len(l.frames) > 0 {
+		parent := &l.frames[len(l.frames)-1]
+
+		if *parent == nil {
+			*parent = make(map[string]int)
+		}
+
+		elem += "[" + strconv.Itoa((*parent)[name]) + "]"
+		(*parent)[name]++
+	}
+
+	l.frames = append(l.frames, nil)
+	l.pos.path = append(l.pos.path[:len(l.pos.path):len(l.pos.path)], elem)
+}
+
+// replay calls the given func with a tokeniser that returns the given phrases,
+// restoring the position at which each was originally read. The current
+// position is restored after a successful call.
+func (l *lineTokeniser) replay(pt psuedoTokeniser, pos []position, fn func(tokeniser) error) error {
+	live := l.pos
+
+	if err := fn(&replayTokeniser{pt: pt, pos: pos, l: l}); err != nil {
+		return err
+	}
+
+	l.pos = live
+
+	return nil
+}
+
+func (l *lineTokeniser) error(err error) error {
+	if err == nil || err == io.EOF {
+		return err
+	} else if de, ok := err.(*DecodeError); ok {
+		return de
+	}
+
+	return l.decodeError(err)
+}
+
+// timezoneError returns the error, produced while resolving timezones, as a
+// DecodeError positioned at the content line responsible for it.
+func (l *lineTokeniser) timezoneError(err error) error {
+	var te *timezoneError
+
+	if errors.As(err, &te) {
+		return l.decodeErrorAt(l.timezonePosition(te.name), err)
+	}
+
+	return l.error(err)
+}
+
+// warnTimezone records a Warning for the named timezone, positioned at its
+// first use.
+func (l *lineTokeniser) warnTimezone(name string, err error) {
+	l.warnings = append(l.warnings, (*Warning)(l.decodeErrorAt(l.timezonePosition(name), err)))
+}
+
+// timezonePosition returns the position of the definition of the named
+// timezone, falling back to its first use, and then the current position.
+func (l *lineTokeniser) timezonePosition(name string) position {
+	if pos, ok := l.timezones[name]; ok {
+		return pos
+	} else if pos, ok := l.tzids[name]; ok {
+		return pos
+	}
+
+	return l.pos
+}
+
+func (l *lineTokeniser) decodeErrorAt(pos position, err error) *DecodeError {
+	live := l.pos
+	l.pos = pos
+
+	d := l.decodeError(err)
+
+	l.pos = live
+
+	return d
+}
+
+// warn records the error as a Warning at the current position.
+func (l *lineTokeniser) warn(err error) {
+	l.warnings = append(l.warnings, (*Warning)(l.decodeError(err)))
+}
+
+func (l *lineTokeniser) warnLine(line int, err error) {
+	l.warnings = append(l.warnings, &Warning{Line: line, Err: err})
+}
+
+// sortedWarnings returns the recorded warnings ordered by line.
+func (l *lineTokeniser) sortedWarnings() []*Warning {
+	sort.SliceStable(l.warnings, func(i, j int) bool {
+		return l.warnings[i].Line < l.warnings[j].Line
+	})
+
+	return l.warnings
+}
+
+func (l *lineTokeniser) decodeError(err error) *DecodeError {
+	for {
+		e := errors.Unwrap(err)
+		if e == nil {
+			break
+		}
+
+		err = e
+	}
+
+	d := &DecodeError{
+		Line: l.pos.line,
+		Path: append([]string(nil), l.pos.path...),
+		Raw:  l.pos.raw(),
+		Err:  err,
+	}
+
+	if l.pos.phrase.Type == phraseContentLine {
+		switch name := strings.ToUpper(l.pos.phrase.Data[0].Data); name {
+		case "BEGIN", "END":
+		default:
+			d.Property = name
+		}
+	}
+
+	return d
+}
+
+// raw reconstructs the unfolded content line of the phrase.
+func (p position) raw() string {
+	if p.phrase.Type != phraseContentLine {
+		return ""
+	}
+
+	var (
+		sb        strings.Builder
+		lastValue bool
+	)
+
+	for _, tk := range p.phrase.Data {
+		switch tk.Type {
+		case tokenName:
+			sb.WriteString(tk.Data)
+		case tokenParamName:
+			sb.WriteString(";")
+			sb.WriteString(tk.Data)
+			sb.WriteString("=")
+		case tokenParamValue, tokenParamQuotedValue:
+			if lastValue {
+				sb.WriteString(",")
+			}
+
+			if tk.Type == tokenParamQuotedValue {
+				sb.WriteString("\"")
+				sb.WriteString(tk.Data)
+				sb.WriteString("\"")
+			} else {
+				sb.WriteString(tk.Data)
+			}
+		case tokenValue:
+			sb.WriteString(":")
+			sb.WriteString(tk.Data)
+		}
+
+		lastValue = tk.Type == tokenParamValue || tk.Type == tokenParamQuotedValue
+	}
+
+	return sb.String()
+}
+
+type replayTokeniser struct {
+	pt  psuedoTokeniser
+	pos []position
+	l   *lineTokeniser
+}
+
+func (r *replayTokeniser) GetPhrase() (parser.Phrase, error) {
+	if len(r.pos) > 0 {
+		r.l.pos = r.pos[0]
+		r.pos = r.pos[1:]
+	}
+
+	return r.pt.GetPhrase()
+}
@@ -42,7 +42,8 @@ func (p *PropAction) decode(params []parser.Token, value string) error {
 }
 
 func (p *PropAction) encode(w writer) {
-	w.WriteString("ACTION:")
+	w.WriteString("ACTION")
+	w.WriteString(":")
 
 	switch *p {
 	case ActionAudio:
@@ -72,6 +73,8 @@ type PropAttachment struct {
 	FormatType *ParamFormatType
 	URI        *URI
 	Binary     *Binary
+
+	Extensions ExtensionParams
 }
 
 func (p *PropAttachment) decode(params []parser.Token, value string) error {
@@ -120,6 +123,8 @@ func (p *PropAttachment) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingType, cAttachment, ErrInvalidValue)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -158,6 +163,8 @@ func (p *PropAttachment) encode(w writer) {
 		p.FormatType.encode(w)
 	}
 
+	p.Extensions.encode(w)
+
 	if p.URI != nil {
 		p.URI.aencode(w)
 	}
@@ -171,6 +178,10 @@ func (p *PropAttachment) encode(w writer) {
 }
 
 func (p *PropAttachment) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAttachment, err)
+	}
+
 	if p.FormatType != nil {
 		if err := p.FormatType.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cAttachment, cFormatType, err)
@@ -216,6 +227,8 @@ type PropAttendee struct {
 	DirectoryEntry      *ParamDirectoryEntry
 	Language            *ParamLanguage
 	CalendarAddress
+
+	Extensions ExtensionParams
 }
 
 func (p *PropAttendee) decode(params []parser.Token, value string) error {
@@ -340,6 +353,8 @@ func (p *PropAttendee) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingProp, cAttendee, cLanguage, err)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -403,11 +418,17 @@ func (p *PropAttendee) encode(w writer) {
 		p.Language.encode(w)
 	}
 
+	p.Extensions.encode(w)
+
 	p.CalendarAddress.aencode(w)
 	w.WriteString("\r\n")
 }
 
 func (p *PropAttendee) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAttendee, err)
+	}
+
 	if p.CalendarUserType != nil {
 		if err := p.CalendarUserType.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cAttendee, cCalendarUserType, err)
@@ -507,7 +528,8 @@ func (p *PropCalendarScale) decode(params []parser.Token, value string) error {
 }
 
 func (p *PropCalendarScale) encode(w writer) {
-	w.WriteString("CALSCALE:")
+	w.WriteString("CALSCALE")
+	w.WriteString(":")
 
 	switch *p {
 	case CalendarScaleGregorian:
@@ -531,6 +553,8 @@ func (p *PropCalendarScale) valid() error {
 type PropCategories struct {
 	Language *ParamLanguage
 	MText
+
+	Extensions ExtensionParams
 }
 
 func (p *PropCategories) decode(params []parser.Token, value string) error {
@@ -561,6 +585,8 @@ func (p *PropCategories) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingProp, cCategories, cLanguage, err)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -584,11 +610,17 @@ func (p *PropCategories) encode(w writer) {
 		p.Language.encode(w)
 	}
 
+	p.Extensions.encode(w)
+
 	p.MText.aencode(w)
 	w.WriteString("\r\n")
 }
 
 func (p *PropCategories) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cCategories, err)
+	}
+
 	if p.Language != nil {
 		if err := p.Language.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cCategories, cLanguage, err)
@@ -634,7 +666,8 @@ func (p *PropClass) decode(params []parser.Token, value string) error {
 }
 
 func (p *PropClass) encode(w writer) {
-	w.WriteString("CLASS:")
+	w.WriteString("CLASS")
+	w.WriteString(":")
 
 	switch *p {
 	case ClassPublic:
@@ -664,6 +697,8 @@ type PropComment struct {
 	AlternativeRepresentation *ParamAlternativeRepresentation
 	Language                  *ParamLanguage
 	Text
+
+	Extensions ExtensionParams
 }
 
 func (p *PropComment) decode(params []parser.Token, value string) error {
@@ -704,6 +739,8 @@ func (p *PropComment) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingProp, cComment, cLanguage, err)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -731,11 +768,17 @@ func (p *PropComment) encode(w writer) {
 		p.Language.encode(w)
 	}
 
+	p.Extensions.encode(w)
+
 	p.Text.aencode(w)
 	w.WriteString("\r\n")
 }
 
 func (p *PropComment) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cComment, err)
+	}
+
 	if p.AlternativeRepresentation != nil {
 		if err := p.AlternativeRepresentation.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cComment, cAlternativeRepresentation, err)
@@ -817,6 +860,8 @@ type PropContact struct {
 	AlternativeRepresentation *ParamAlternativeRepresentation
 	Language                  *ParamLanguage
 	Text
+
+	Extensions ExtensionParams
 }
 
 func (p *PropContact) decode(params []parser.Token, value string) error {
@@ -857,6 +902,8 @@ func (p *PropContact) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingProp, cContact, cLanguage, err)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -884,11 +931,17 @@ func (p *PropContact) encode(w writer) {
 		p.Language.encode(w)
 	}
 
+	p.Extensions.encode(w)
+
 	p.Text.aencode(w)
 	w.WriteString("\r\n")
 }
 
 func (p *PropContact) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cContact, err)
+	}
+
 	if p.AlternativeRepresentation != nil {
 		if err := p.AlternativeRepresentation.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cContact, cAlternativeRepresentation, err)
@@ -971,6 +1024,8 @@ type PropDescription struct {
 	AlternativeRepresentation *ParamAlternativeRepresentation
 	Language                  *ParamLanguage
 	Text
+
+	Extensions ExtensionParams
 }
 
 func (p *PropDescription) decode(params []parser.Token, value string) error {
@@ -1011,6 +1066,8 @@ func (p *PropDescription) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingProp, cDescription, cLanguage, err)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -1038,11 +1095,17 @@ func (p *PropDescription) encode(w writer) {
 		p.Language.encode(w)
 	}
 
+	p.Extensions.encode(w)
+
 	p.Text.aencode(w)
 	w.WriteString("\r\n")
 }
 
 func (p *PropDescription) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cDescription, err)
+	}
+
 	if p.AlternativeRepresentation != nil {
 		if err := p.AlternativeRepresentation.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cDescription, cAlternativeRepresentation, err)
@@ -1066,6 +1129,8 @@ func (p *PropDescription) valid() error {
 type PropDateTimeEnd struct {
 	DateTime *DateTime
 	Date     *Date
+
+	Extensions ExtensionParams
 }
 
 func (p *PropDateTimeEnd) decode(params []parser.Token, value string) error {
@@ -1104,6 +1169,8 @@ func (p *PropDateTimeEnd) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingType, cDateTimeEnd, ErrInvalidValue)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -1138,6 +1205,8 @@ func (p *PropDateTimeEnd) decode(params []parser.Token, value string) error {
 func (p *PropDateTimeEnd) encode(w writer) {
 	w.WriteString("DTEND")
 
+	p.Extensions.encode(w)
+
 	if p.DateTime != nil {
 		p.DateTime.aencode(w)
 	}
@@ -1151,6 +1220,10 @@ func (p *PropDateTimeEnd) encode(w writer) {
 }
 
 func (p *PropDateTimeEnd) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cDateTimeEnd, err)
+	}
+
 	c := 0
 
 	if p.DateTime != nil {
@@ -1239,6 +1312,8 @@ func (p *PropDateTimeStamp) valid() error {
 type PropDateTimeStart struct {
 	DateTime *DateTime
 	Date     *Date
+
+	Extensions ExtensionParams
 }
 
 func (p *PropDateTimeStart) decode(params []parser.Token, value string) error {
@@ -1277,6 +1352,8 @@ func (p *PropDateTimeStart) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingType, cDateTimeStart, ErrInvalidValue)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -1311,6 +1388,8 @@ func (p *PropDateTimeStart) decode(params []parser.Token, value string) error {
 func (p *PropDateTimeStart) encode(w writer) {
 	w.WriteString("DTSTART")
 
+	p.Extensions.encode(w)
+
 	if p.DateTime != nil {
 		p.DateTime.aencode(w)
 	}
@@ -1324,6 +1403,10 @@ func (p *PropDateTimeStart) encode(w writer) {
 }
 
 func (p *PropDateTimeStart) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cDateTimeStart, err)
+	}
+
 	c := 0
 
 	if p.DateTime != nil {
@@ -1353,6 +1436,8 @@ func (p *PropDateTimeStart) valid() error {
 type PropDue struct {
 	DateTime *DateTime
 	Date     *Date
+
+	Extensions ExtensionParams
 }
 
 func (p *PropDue) decode(params []parser.Token, value string) error {
@@ -1391,6 +1476,8 @@ func (p *PropDue) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingType, cDue, ErrInvalidValue)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -1425,6 +1512,8 @@ func (p *PropDue) decode(params []parser.Token, value string) error {
 func (p *PropDue) encode(w writer) {
 	w.WriteString("DUE")
 
+	p.Extensions.encode(w)
+
 	if p.DateTime != nil {
 		p.DateTime.aencode(w)
 	}
@@ -1438,6 +1527,10 @@ func (p *PropDue) encode(w writer) {
 }
 
 func (p *PropDue) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cDue, err)
+	}
+
 	c := 0
 
 	if p.DateTime != nil {
@@ -1524,6 +1617,8 @@ func (p *PropDuration) valid() error {
 type PropExceptionDateTime struct {
 	DateTime *DateTime
 	Date     *Date
+
+	Extensions ExtensionParams
 }
 
 func (p *PropExceptionDateTime) decode(params []parser.Token, value string) error {
@@ -1562,6 +1657,8 @@ func (p *PropExceptionDateTime) decode(params []parser.Token, value string) erro
 				return fmt.Errorf(errDecodingType, cExceptionDateTime, ErrInvalidValue)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -1596,6 +1693,8 @@ func (p *PropExceptionDateTime) decode(params []parser.Token, value string) erro
 func (p *PropExceptionDateTime) encode(w writer) {
 	w.WriteString("EXDATE")
 
+	p.Extensions.encode(w)
+
 	if p.DateTime != nil {
 		p.DateTime.aencode(w)
 	}
@@ -1609,6 +1708,10 @@ func (p *PropExceptionDateTime) encode(w writer) {
 }
 
 func (p *PropExceptionDateTime) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cExceptionDateTime, err)
+	}
+
 	c := 0
 
 	if p.DateTime != nil {
@@ -1637,7 +1740,9 @@ func (p *PropExceptionDateTime) valid() error {
 // PropFreeBusy defines one or more free or busy time intervals.
 type PropFreeBusy struct {
 	FreeBusyType *ParamFreeBusyType
-	Period
+	MPeriod
+
+	Extensions ExtensionParams
 }
 
 func (p *PropFreeBusy) decode(params []parser.Token, value string) error {
@@ -1668,6 +1773,8 @@ func (p *PropFreeBusy) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingProp, cFreeBusy, cFreeBusyType, err)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -1677,8 +1784,8 @@ func (p *PropFreeBusy) decode(params []parser.Token, value string) error {
 		}
 	}
 
-	if err := p.Period.decode(oParams, value); err != nil {
-		return fmt.Errorf(errDecodingProp, cFreeBusy, cPeriod, err)
+	if err := p.MPeriod.decode(oParams, value); err != nil {
+		return fmt.Errorf(errDecodingProp, cFreeBusy, cMPeriod, err)
 	}
 
 	return nil
@@ -1691,19 +1798,25 @@ func (p *PropFreeBusy) encode(w writer) {
 		p.FreeBusyType.encode(w)
 	}
 
-	p.Period.aencode(w)
+	p.Extensions.encode(w)
+
+	p.MPeriod.aencode(w)
 	w.WriteString("\r\n")
 }
 
 func (p *PropFreeBusy) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cFreeBusy, err)
+	}
+
 	if p.FreeBusyType != nil {
 		if err := p.FreeBusyType.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cFreeBusy, cFreeBusyType, err)
 		}
 	}
 
-	if err := p.Period.valid(); err != nil {
-		return fmt.Errorf(errValidatingProp, cFreeBusy, cPeriod, err)
+	if err := p.MPeriod.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cFreeBusy, cMPeriod, err)
 	}
 
 	return nil
@@ -1829,6 +1942,8 @@ type PropLocation struct {
 	AlternativeRepresentation *ParamAlternativeRepresentation
 	Language                  *ParamLanguage
 	Text
+
+	Extensions ExtensionParams
 }
 
 func (p *PropLocation) decode(params []parser.Token, value string) error {
@@ -1869,6 +1984,8 @@ func (p *PropLocation) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingProp, cLocation, cLanguage, err)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -1896,11 +2013,17 @@ func (p *PropLocation) encode(w writer) {
 		p.Language.encode(w)
 	}
 
+	p.Extensions.encode(w)
+
 	p.Text.aencode(w)
 	w.WriteString("\r\n")
 }
 
 func (p *PropLocation) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cLocation, err)
+	}
+
 	if p.AlternativeRepresentation != nil {
 		if err := p.AlternativeRepresentation.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cLocation, cAlternativeRepresentation, err)
@@ -1984,6 +2107,8 @@ type PropOrganizer struct {
 	SentBy         *ParamSentBy
 	Language       *ParamLanguage
 	CalendarAddress
+
+	Extensions ExtensionParams
 }
 
 func (p *PropOrganizer) decode(params []parser.Token, value string) error {
@@ -2044,6 +2169,8 @@ func (p *PropOrganizer) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingProp, cOrganizer, cLanguage, err)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -2079,11 +2206,17 @@ func (p *PropOrganizer) encode(w writer) {
 		p.Language.encode(w)
 	}
 
+	p.Extensions.encode(w)
+
 	p.CalendarAddress.aencode(w)
 	w.WriteString("\r\n")
 }
 
 func (p *PropOrganizer) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cOrganizer, err)
+	}
+
 	if p.CommonName != nil {
 		if err := p.CommonName.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cOrganizer, cCommonName, err)
@@ -2303,6 +2436,8 @@ type PropRecurrenceDateTimes struct {
 	DateTime *DateTime
 	Date     *Date
 	Period   *Period
+
+	Extensions ExtensionParams
 }
 
 func (p *PropRecurrenceDateTimes) decode(params []parser.Token, value string) error {
@@ -2343,6 +2478,8 @@ func (p *PropRecurrenceDateTimes) decode(params []parser.Token, value string) er
 				return fmt.Errorf(errDecodingType, cRecurrenceDateTimes, ErrInvalidValue)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -2383,6 +2520,8 @@ func (p *PropRecurrenceDateTimes) decode(params []parser.Token, value string) er
 func (p *PropRecurrenceDateTimes) encode(w writer) {
 	w.WriteString("RDATE")
 
+	p.Extensions.encode(w)
+
 	if p.DateTime != nil {
 		p.DateTime.aencode(w)
 	}
@@ -2401,6 +2540,10 @@ func (p *PropRecurrenceDateTimes) encode(w writer) {
 }
 
 func (p *PropRecurrenceDateTimes) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cRecurrenceDateTimes, err)
+	}
+
 	c := 0
 
 	if p.DateTime != nil {
@@ -2440,6 +2583,8 @@ type PropRecurrenceID struct {
 	Range    *ParamRange
 	DateTime *DateTime
 	Date     *Date
+
+	Extensions ExtensionParams
 }
 
 func (p *PropRecurrenceID) decode(params []parser.Token, value string) error {
@@ -2488,6 +2633,8 @@ func (p *PropRecurrenceID) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingType, cRecurrenceID, ErrInvalidValue)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -2526,6 +2673,8 @@ func (p *PropRecurrenceID) encode(w writer) {
 		p.Range.encode(w)
 	}
 
+	p.Extensions.encode(w)
+
 	if p.DateTime != nil {
 		p.DateTime.aencode(w)
 	}
@@ -2539,6 +2688,10 @@ func (p *PropRecurrenceID) encode(w writer) {
 }
 
 func (p *PropRecurrenceID) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cRecurrenceID, err)
+	}
+
 	if p.Range != nil {
 		if err := p.Range.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cRecurrenceID, cRange, err)
@@ -2571,13 +2724,20 @@ func (p *PropRecurrenceID) valid() error {
 }
 
 // PropRelatedTo is used to represent a relationship or reference between one
-// calendar component and another.
+// calendar component and another, with the Text being used unless the URI or
+// UID is set.
 type PropRelatedTo struct {
 	RelationshipType *ParamRelationshipType
+	Gap              *ParamGap
+	URI              *URI
+	UID              *UID
 	Text
+
+	Extensions ExtensionParams
 }
 
 func (p *PropRelatedTo) decode(params []parser.Token, value string) error {
+	vType := -1
 	oParams := make(map[string]string)
 
 	var ts []string
@@ -2604,7 +2764,38 @@ func (p *PropRelatedTo) decode(params []parser.Token, value string) error {
 			if err := p.RelationshipType.decode(pValues); err != nil {
 				return fmt.Errorf(errDecodingProp, cRelatedTo, cRelationshipType, err)
 			}
+		case "GAP":
+			if p.Gap != nil {
+				return fmt.Errorf(errDecodingProp, cRelatedTo, cGap, ErrDuplicateParam)
+			}
+
+			p.Gap = new(ParamGap)
+
+			if err := p.Gap.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cRelatedTo, cGap, err)
+			}
+		case "VALUE":
+			if len(pValues) != 1 {
+				return fmt.Errorf(errDecodingProp, cRelatedTo, cValue, ErrInvalidValue)
+			}
+
+			if vType != -1 {
+				return fmt.Errorf(errDecodingProp, cRelatedTo, cValue, ErrDuplicateParam)
+			}
+
+			switch strings.ToUpper(pValues[0].Data) {
+			case "TEXT":
+				vType = 0
+			case "URI":
+				vType = 1
+			case "UID":
+				vType = 2
+			default:
+				return fmt.Errorf(errDecodingType, cRelatedTo, ErrInvalidValue)
+			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -2614,8 +2805,27 @@ func (p *PropRelatedTo) decode(params []parser.Token, value string) error {
 		}
 	}
 
-	if err := p.Text.decode(oParams, value); err != nil {
-		return fmt.Errorf(errDecodingProp, cRelatedTo, cText, err)
+	if vType == -1 {
+		vType = 0
+	}
+
+	switch vType {
+	case 0:
+		if err := p.Text.decode(oParams, value); err != nil {
+			return fmt.Errorf(errDecodingProp, cRelatedTo, cText, err)
+		}
+	case 1:
+		p.URI = new(URI)
+
+		if err := p.URI.decode(oParams, value); err != nil {
+			return fmt.Errorf(errDecodingProp, cRelatedTo, cURI, err)
+		}
+	case 2:
+		p.UID = new(UID)
+
+		if err := p.UID.decode(oParams, value); err != nil {
+			return fmt.Errorf(errDecodingProp, cRelatedTo, cUID, err)
+		}
 	}
 
 	return nil
@@ -2628,19 +2838,67 @@ func (p *PropRelatedTo) encode(w writer) {
 		p.RelationshipType.encode(w)
 	}
 
-	p.Text.aencode(w)
+	if p.Gap != nil {
+		p.Gap.encode(w)
+	}
+
+	p.Extensions.encode(w)
+
+	switch {
+	case p.URI != nil:
+		w.WriteString(";VALUE=URI")
+		p.URI.aencode(w)
+	case p.UID != nil:
+		w.WriteString(";VALUE=UID")
+		p.UID.aencode(w)
+	default:
+		p.Text.aencode(w)
+	}
+
 	w.WriteString("\r\n")
 }
 
 func (p *PropRelatedTo) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cRelatedTo, err)
+	}
+
 	if p.RelationshipType != nil {
 		if err := p.RelationshipType.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cRelatedTo, cRelationshipType, err)
 		}
 	}
 
-	if err := p.Text.valid(); err != nil {
-		return fmt.Errorf(errValidatingProp, cRelatedTo, cText, err)
+	if p.Gap != nil {
+		if err := p.Gap.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cRelatedTo, cGap, err)
+		}
+	}
+
+	c := 0
+
+	if p.URI != nil {
+		if err := p.URI.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cRelatedTo, cURI, err)
+		}
+
+		c++
+	}
+
+	if p.UID != nil {
+		if err := p.UID.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cRelatedTo, cUID, err)
+		}
+
+		c++
+	}
+
+	if c > 1 {
+		return fmt.Errorf(errValidatingType, cRelatedTo, ErrInvalidValue)
+	} else if c == 0 {
+		if err := p.Text.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cRelatedTo, cText, err)
+		}
 	}
 
 	return nil
@@ -2771,6 +3029,8 @@ type PropResources struct {
 	AlternativeRepresentation *ParamAlternativeRepresentation
 	Language                  *ParamLanguage
 	MText
+
+	Extensions ExtensionParams
 }
 
 func (p *PropResources) decode(params []parser.Token, value string) error {
@@ -2811,6 +3071,8 @@ func (p *PropResources) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingProp, cResources, cLanguage, err)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -2838,11 +3100,17 @@ func (p *PropResources) encode(w writer) {
 		p.Language.encode(w)
 	}
 
+	p.Extensions.encode(w)
+
 	p.MText.aencode(w)
 	w.WriteString("\r\n")
 }
 
 func (p *PropResources) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cResources, err)
+	}
+
 	if p.AlternativeRepresentation != nil {
 		if err := p.AlternativeRepresentation.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cResources, cAlternativeRepresentation, err)
@@ -3030,7 +3298,8 @@ func (p *PropStatus) decode(params []parser.Token, value string) error {
 }
 
 func (p *PropStatus) encode(w writer) {
-	w.WriteString("STATUS:")
+	w.WriteString("STATUS")
+	w.WriteString(":")
 
 	switch *p {
 	case StatusTentative:
@@ -3069,6 +3338,8 @@ type PropSummary struct {
 	AlternativeRepresentation *ParamAlternativeRepresentation
 	Language                  *ParamLanguage
 	Text
+
+	Extensions ExtensionParams
 }
 
 func (p *PropSummary) decode(params []parser.Token, value string) error {
@@ -3109,6 +3380,8 @@ func (p *PropSummary) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingProp, cSummary, cLanguage, err)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -3136,11 +3409,17 @@ func (p *PropSummary) encode(w writer) {
 		p.Language.encode(w)
 	}
 
+	p.Extensions.encode(w)
+
 	p.Text.aencode(w)
 	w.WriteString("\r\n")
 }
 
 func (p *PropSummary) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cSummary, err)
+	}
+
 	if p.AlternativeRepresentation != nil {
 		if err := p.AlternativeRepresentation.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cSummary, cAlternativeRepresentation, err)
@@ -3190,7 +3469,8 @@ func (p *PropTimeTransparency) decode(params []parser.Token, value string) error
 }
 
 func (p *PropTimeTransparency) encode(w writer) {
-	w.WriteString("TRANSP:")
+	w.WriteString("TRANSP")
+	w.WriteString(":")
 
 	switch *p {
 	case TimeTransparencyOpaque:
@@ -3216,6 +3496,8 @@ func (p *PropTimeTransparency) valid() error {
 type PropTrigger struct {
 	Duration *Duration
 	DateTime *DateTime
+
+	Extensions ExtensionParams
 }
 
 func (p *PropTrigger) decode(params []parser.Token, value string) error {
@@ -3254,6 +3536,8 @@ func (p *PropTrigger) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingType, cTrigger, ErrInvalidValue)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -3288,6 +3572,8 @@ func (p *PropTrigger) decode(params []parser.Token, value string) error {
 func (p *PropTrigger) encode(w writer) {
 	w.WriteString("TRIGGER")
 
+	p.Extensions.encode(w)
+
 	if p.Duration != nil {
 		p.Duration.aencode(w)
 	}
@@ -3301,6 +3587,10 @@ func (p *PropTrigger) encode(w writer) {
 }
 
 func (p *PropTrigger) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cTrigger, err)
+	}
+
 	c := 0
 
 	if p.Duration != nil {
@@ -3388,6 +3678,8 @@ func (p *PropTimezoneID) valid() error {
 type PropTimezoneName struct {
 	Language *ParamLanguage
 	Text
+
+	Extensions ExtensionParams
 }
 
 func (p *PropTimezoneName) decode(params []parser.Token, value string) error {
@@ -3418,6 +3710,8 @@ func (p *PropTimezoneName) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingProp, cTimezoneName, cLanguage, err)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -3441,11 +3735,17 @@ func (p *PropTimezoneName) encode(w writer) {
 		p.Language.encode(w)
 	}
 
+	p.Extensions.encode(w)
+
 	p.Text.aencode(w)
 	w.WriteString("\r\n")
 }
 
 func (p *PropTimezoneName) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cTimezoneName, err)
+	}
+
 	if p.Language != nil {
 		if err := p.Language.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cTimezoneName, cLanguage, err)
@@ -3865,6 +4165,8 @@ type PropAlarmAgent struct {
 	ID      *ParamID
 	AgentID *ParamAgentID
 	Text
+
+	Extensions ExtensionParams
 }
 
 func (p *PropAlarmAgent) decode(params []parser.Token, value string) error {
@@ -3915,6 +4217,8 @@ func (p *PropAlarmAgent) decode(params []parser.Token, value string) error {
 				return fmt.Errorf(errDecodingProp, cAlarmAgent, cAgentID, err)
 			}
 		default:
+			p.Extensions.decode(pName, pValues)
+
 			for _, v := range pValues {
 				ts = append(ts, v.Data)
 			}
@@ -3946,11 +4250,17 @@ func (p *PropAlarmAgent) encode(w writer) {
 		p.AgentID.encode(w)
 	}
 
+	p.Extensions.encode(w)
+
 	p.Text.aencode(w)
 	w.WriteString("\r\n")
 }
 
 func (p *PropAlarmAgent) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAlarmAgent, err)
+	}
+
 	if p.URI != nil {
 		if err := p.URI.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cAlarmAgent, cURI, err)
@@ -4008,7 +4318,8 @@ func (p *PropAlarmStatus) decode(params []parser.Token, value string) error {
 }
 
 func (p *PropAlarmStatus) encode(w writer) {
-	w.WriteString("STATUS:")
+	w.WriteString("STATUS")
+	w.WriteString(":")
 
 	switch *p {
 	case AlarmStatusActive:
@@ -4312,6 +4623,1657 @@ func (p *PropDefaultAlarm) valid() error {
 	return nil
 }
 
+// PropName specifies the name of a calendar that can be presented to the user.
+type PropName struct {
+	AlternativeRepresentation *ParamAlternativeRepresentation
+	Language                  *ParamLanguage
+	Text
+
+	Extensions ExtensionParams
+}
+
+func (p *PropName) decode(params []parser.Token, value string) error {
+	oParams := make(map[string]string)
+
+	var ts []string
+
+	for len(params) > 0 {
+		pName := strings.ToUpper(params[0].Data)
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		pValues := params[1:i]
+		params = params[i:]
+
+		switch pName {
+		case "ALTREP":
+			if p.AlternativeRepresentation != nil {
+				return fmt.Errorf(errDecodingProp, cName, cAlternativeRepresentation, ErrDuplicateParam)
+			}
+
+			p.AlternativeRepresentation = new(ParamAlternativeRepresentation)
+
+			if err := p.AlternativeRepresentation.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cName, cAlternativeRepresentation, err)
+			}
+		case "LANGUAGE":
+			if p.Language != nil {
+				return fmt.Errorf(errDecodingProp, cName, cLanguage, ErrDuplicateParam)
+			}
+
+			p.Language = new(ParamLanguage)
+
+			if err := p.Language.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cName, cLanguage, err)
+			}
+		default:
+			p.Extensions.decode(pName, pValues)
+
+			for _, v := range pValues {
+				ts = append(ts, v.Data)
+			}
+
+			oParams[pName] = strings.Join(ts, ",")
+			ts = ts[:0]
+		}
+	}
+
+	if err := p.Text.decode(oParams, value); err != nil {
+		return fmt.Errorf(errDecodingProp, cName, cText, err)
+	}
+
+	return nil
+}
+
+func (p *PropName) encode(w writer) {
+	w.WriteString("NAME")
+
+	if p.AlternativeRepresentation != nil {
+		p.AlternativeRepresentation.encode(w)
+	}
+
+	if p.Language != nil {
+		p.Language.encode(w)
+	}
+
+	p.Extensions.encode(w)
+
+	p.Text.aencode(w)
+	w.WriteString("\r\n")
+}
+
+func (p *PropName) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cName, err)
+	}
+
+	if p.AlternativeRepresentation != nil {
+		if err := p.AlternativeRepresentation.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cName, cAlternativeRepresentation, err)
+		}
+	}
+
+	if p.Language != nil {
+		if err := p.Language.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cName, cLanguage, err)
+		}
+	}
+
+	if err := p.Text.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cName, cText, err)
+	}
+
+	return nil
+}
+
+// PropColor specifies a CSS3 color name used as a hint when presenting a
+// calendar or its components.
+type PropColor Text
+
+func (p *PropColor) decode(params []parser.Token, value string) error {
+	oParams := make(map[string]string)
+
+	var ts []string
+
+	for len(params) > 0 {
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		pValues := params[1:i]
+
+		for _, v := range pValues {
+			ts = append(ts, v.Data)
+		}
+
+		oParams[strings.ToUpper(params[0].Data)] = strings.Join(ts, ",")
+		params = params[i:]
+		ts = ts[:0]
+	}
+
+	var t Text
+
+	if err := t.decode(oParams, value); err != nil {
+		return fmt.Errorf(errDecodingType, cColor, err)
+	}
+
+	*p = PropColor(t)
+
+	return nil
+}
+
+func (p *PropColor) encode(w writer) {
+	w.WriteString("COLOR")
+
+	t := Text(*p)
+
+	t.aencode(w)
+	w.WriteString("\r\n")
+}
+
+func (p *PropColor) valid() error {
+	t := Text(*p)
+
+	if err := t.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cColor, err)
+	}
+
+	return nil
+}
+
+// PropRefreshInterval specifies a suggested minimum interval for polling for
+// changes of the calendar data from the original source.
+type PropRefreshInterval Duration
+
+func (p *PropRefreshInterval) decode(params []parser.Token, value string) error {
+	oParams := make(map[string]string)
+
+	var ts []string
+
+	for len(params) > 0 {
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		pValues := params[1:i]
+
+		for _, v := range pValues {
+			ts = append(ts, v.Data)
+		}
+
+		oParams[strings.ToUpper(params[0].Data)] = strings.Join(ts, ",")
+		params = params[i:]
+		ts = ts[:0]
+	}
+
+	var t Duration
+
+	if err := t.decode(oParams, value); err != nil {
+		return fmt.Errorf(errDecodingType, cRefreshInterval, err)
+	}
+
+	*p = PropRefreshInterval(t)
+
+	return nil
+}
+
+func (p *PropRefreshInterval) encode(w writer) {
+	w.WriteString("REFRESH-INTERVAL")
+	w.WriteString(";VALUE=DURATION")
+
+	t := Duration(*p)
+
+	t.aencode(w)
+	w.WriteString("\r\n")
+}
+
+func (p *PropRefreshInterval) valid() error {
+	t := Duration(*p)
+
+	if err := t.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cRefreshInterval, err)
+	}
+
+	return nil
+}
+
+// PropSource identifies a URI where calendar data can be refreshed from.
+type PropSource URI
+
+func (p *PropSource) decode(params []parser.Token, value string) error {
+	oParams := make(map[string]string)
+
+	var ts []string
+
+	for len(params) > 0 {
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		pValues := params[1:i]
+
+		for _, v := range pValues {
+			ts = append(ts, v.Data)
+		}
+
+		oParams[strings.ToUpper(params[0].Data)] = strings.Join(ts, ",")
+		params = params[i:]
+		ts = ts[:0]
+	}
+
+	var t URI
+
+	if err := t.decode(oParams, value); err != nil {
+		return fmt.Errorf(errDecodingType, cSource, err)
+	}
+
+	*p = PropSource(t)
+
+	return nil
+}
+
+func (p *PropSource) encode(w writer) {
+	w.WriteString("SOURCE")
+
+	t := URI(*p)
+
+	t.aencode(w)
+	w.WriteString("\r\n")
+}
+
+func (p *PropSource) valid() error {
+	t := URI(*p)
+
+	if err := t.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cSource, err)
+	}
+
+	return nil
+}
+
+// PropImage specifies an image associated with a calendar or its components.
+type PropImage struct {
+	FormatType                *ParamFormatType
+	AlternativeRepresentation *ParamAlternativeRepresentation
+	Display                   ParamDisplay
+	URI                       *URI
+	Binary                    *Binary
+
+	Extensions ExtensionParams
+}
+
+func (p *PropImage) decode(params []parser.Token, value string) error {
+	vType := -1
+	oParams := make(map[string]string)
+
+	var ts []string
+
+	for len(params) > 0 {
+		pName := strings.ToUpper(params[0].Data)
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		pValues := params[1:i]
+		params = params[i:]
+
+		switch pName {
+		case "FMTTYPE":
+			if p.FormatType != nil {
+				return fmt.Errorf(errDecodingProp, cImage, cFormatType, ErrDuplicateParam)
+			}
+
+			p.FormatType = new(ParamFormatType)
+
+			if err := p.FormatType.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cImage, cFormatType, err)
+			}
+		case "ALTREP":
+			if p.AlternativeRepresentation != nil {
+				return fmt.Errorf(errDecodingProp, cImage, cAlternativeRepresentation, ErrDuplicateParam)
+			}
+
+			p.AlternativeRepresentation = new(ParamAlternativeRepresentation)
+
+			if err := p.AlternativeRepresentation.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cImage, cAlternativeRepresentation, err)
+			}
+		case "DISPLAY":
+			if p.Display != nil {
+				return fmt.Errorf(errDecodingProp, cImage, cDisplay, ErrDuplicateParam)
+			}
+
+			if err := p.Display.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cImage, cDisplay, err)
+			}
+		case "VALUE":
+			if len(pValues) != 1 {
+				return fmt.Errorf(errDecodingProp, cImage, cValue, ErrInvalidValue)
+			}
+
+			if vType != -1 {
+				return fmt.Errorf(errDecodingProp, cImage, cValue, ErrDuplicateParam)
+			}
+
+			switch strings.ToUpper(pValues[0].Data) {
+			case "URI":
+				vType = 0
+			case "BINARY":
+				vType = 1
+			default:
+				return fmt.Errorf(errDecodingType, cImage, ErrInvalidValue)
+			}
+		default:
+			p.Extensions.decode(pName, pValues)
+
+			for _, v := range pValues {
+				ts = append(ts, v.Data)
+			}
+
+			oParams[pName] = strings.Join(ts, ",")
+			ts = ts[:0]
+		}
+	}
+
+	if vType == -1 {
+		vType = 0
+	}
+
+	switch vType {
+	case 0:
+		p.URI = new(URI)
+
+		if err := p.URI.decode(oParams, value); err != nil {
+			return fmt.Errorf(errDecodingProp, cImage, cURI, err)
+		}
+	case 1:
+		p.Binary = new(Binary)
+
+		if err := p.Binary.decode(oParams, value); err != nil {
+			return fmt.Errorf(errDecodingProp, cImage, cBinary, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *PropImage) encode(w writer) {
+	w.WriteString("IMAGE")
+
+	if p.FormatType != nil {
+		p.FormatType.encode(w)
+	}
+
+	if p.AlternativeRepresentation != nil {
+		p.AlternativeRepresentation.encode(w)
+	}
+
+	if p.Display != nil {
+		p.Display.encode(w)
+	}
+
+	p.Extensions.encode(w)
+
+	if p.URI != nil {
+		w.WriteString(";VALUE=URI")
+		p.URI.aencode(w)
+	}
+
+	if p.Binary != nil {
+		w.WriteString(";VALUE=BINARY")
+		p.Binary.aencode(w)
+	}
+
+	w.WriteString("\r\n")
+}
+
+func (p *PropImage) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cImage, err)
+	}
+
+	if p.FormatType != nil {
+		if err := p.FormatType.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cImage, cFormatType, err)
+		}
+	}
+
+	if p.AlternativeRepresentation != nil {
+		if err := p.AlternativeRepresentation.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cImage, cAlternativeRepresentation, err)
+		}
+	}
+
+	if p.Display != nil {
+		if err := p.Display.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cImage, cDisplay, err)
+		}
+	}
+
+	c := 0
+
+	if p.URI != nil {
+		if err := p.URI.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cImage, cURI, err)
+		}
+
+		c++
+	}
+
+	if p.Binary != nil {
+		if err := p.Binary.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cImage, cBinary, err)
+		}
+
+		c++
+	}
+
+	if c != 1 {
+		return fmt.Errorf(errValidatingType, cImage, ErrInvalidValue)
+	}
+
+	return nil
+}
+
+// PropConference specifies information for accessing a conferencing system.
+type PropConference struct {
+	Feature  ParamFeature
+	Label    *ParamLabel
+	Language *ParamLanguage
+	URI
+
+	Extensions ExtensionParams
+}
+
+func (p *PropConference) decode(params []parser.Token, value string) error {
+	oParams := make(map[string]string)
+
+	var ts []string
+
+	for len(params) > 0 {
+		pName := strings.ToUpper(params[0].Data)
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		pValues := params[1:i]
+		params = params[i:]
+
+		switch pName {
+		case "FEATURE":
+			if p.Feature != nil {
+				return fmt.Errorf(errDecodingProp, cConference, cFeature, ErrDuplicateParam)
+			}
+
+			if err := p.Feature.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cConference, cFeature, err)
+			}
+		case "LABEL":
+			if p.Label != nil {
+				return fmt.Errorf(errDecodingProp, cConference, cLabel, ErrDuplicateParam)
+			}
+
+			p.Label = new(ParamLabel)
+
+			if err := p.Label.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cConference, cLabel, err)
+			}
+		case "LANGUAGE":
+			if p.Language != nil {
+				return fmt.Errorf(errDecodingProp, cConference, cLanguage, ErrDuplicateParam)
+			}
+
+			p.Language = new(ParamLanguage)
+
+			if err := p.Language.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cConference, cLanguage, err)
+			}
+		default:
+			p.Extensions.decode(pName, pValues)
+
+			for _, v := range pValues {
+				ts = append(ts, v.Data)
+			}
+
+			oParams[pName] = strings.Join(ts, ",")
+			ts = ts[:0]
+		}
+	}
+
+	if err := p.URI.decode(oParams, value); err != nil {
+		return fmt.Errorf(errDecodingProp, cConference, cURI, err)
+	}
+
+	return nil
+}
+
+func (p *PropConference) encode(w writer) {
+	w.WriteString("CONFERENCE")
+	w.WriteString(";VALUE=URI")
+
+	if p.Feature != nil {
+		p.Feature.encode(w)
+	}
+
+	if p.Label != nil {
+		p.Label.encode(w)
+	}
+
+	if p.Language != nil {
+		p.Language.encode(w)
+	}
+
+	p.Extensions.encode(w)
+
+	p.URI.aencode(w)
+	w.WriteString("\r\n")
+}
+
+func (p *PropConference) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cConference, err)
+	}
+
+	if p.Feature != nil {
+		if err := p.Feature.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cConference, cFeature, err)
+		}
+	}
+
+	if p.Label != nil {
+		if err := p.Label.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cConference, cLabel, err)
+		}
+	}
+
+	if p.Language != nil {
+		if err := p.Language.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cConference, cLanguage, err)
+		}
+	}
+
+	if err := p.URI.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cConference, cURI, err)
+	}
+
+	return nil
+}
+
+// PropLocationType specifies the types of a location.
+type PropLocationType MText
+
+func (p *PropLocationType) decode(params []parser.Token, value string) error {
+	oParams := make(map[string]string)
+
+	var ts []string
+
+	for len(params) > 0 {
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		pValues := params[1:i]
+
+		for _, v := range pValues {
+			ts = append(ts, v.Data)
+		}
+
+		oParams[strings.ToUpper(params[0].Data)] = strings.Join(ts, ",")
+		params = params[i:]
+		ts = ts[:0]
+	}
+
+	var t MText
+
+	if err := t.decode(oParams, value); err != nil {
+		return fmt.Errorf(errDecodingType, cLocationType, err)
+	}
+
+	*p = PropLocationType(t)
+
+	return nil
+}
+
+func (p *PropLocationType) encode(w writer) {
+	w.WriteString("LOCATION-TYPE")
+
+	t := MText(*p)
+
+	t.aencode(w)
+	w.WriteString("\r\n")
+}
+
+func (p *PropLocationType) valid() error {
+	t := MText(*p)
+
+	if err := t.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cLocationType, err)
+	}
+
+	return nil
+}
+
+// PropParticipantType specifies the type of a participant.
+type PropParticipantType Text
+
+func (p *PropParticipantType) decode(params []parser.Token, value string) error {
+	oParams := make(map[string]string)
+
+	var ts []string
+
+	for len(params) > 0 {
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		pValues := params[1:i]
+
+		for _, v := range pValues {
+			ts = append(ts, v.Data)
+		}
+
+		oParams[strings.ToUpper(params[0].Data)] = strings.Join(ts, ",")
+		params = params[i:]
+		ts = ts[:0]
+	}
+
+	var t Text
+
+	if err := t.decode(oParams, value); err != nil {
+		return fmt.Errorf(errDecodingType, cParticipantType, err)
+	}
+
+	*p = PropParticipantType(t)
+
+	return nil
+}
+
+func (p *PropParticipantType) encode(w writer) {
+	w.WriteString("PARTICIPANT-TYPE")
+
+	t := Text(*p)
+
+	t.aencode(w)
+	w.WriteString("\r\n")
+}
+
+func (p *PropParticipantType) valid() error {
+	t := Text(*p)
+
+	if err := t.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cParticipantType, err)
+	}
+
+	return nil
+}
+
+// PropResourceType specifies the type of a resource.
+type PropResourceType Text
+
+func (p *PropResourceType) decode(params []parser.Token, value string) error {
+	oParams := make(map[string]string)
+
+	var ts []string
+
+	for len(params) > 0 {
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		pValues := params[1:i]
+
+		for _, v := range pValues {
+			ts = append(ts, v.Data)
+		}
+
+		oParams[strings.ToUpper(params[0].Data)] = strings.Join(ts, ",")
+		params = params[i:]
+		ts = ts[:0]
+	}
+
+	var t Text
+
+	if err := t.decode(oParams, value); err != nil {
+		return fmt.Errorf(errDecodingType, cResourceType, err)
+	}
+
+	*p = PropResourceType(t)
+
+	return nil
+}
+
+func (p *PropResourceType) encode(w writer) {
+	w.WriteString("RESOURCE-TYPE")
+
+	t := Text(*p)
+
+	t.aencode(w)
+	w.WriteString("\r\n")
+}
+
+func (p *PropResourceType) valid() error {
+	t := Text(*p)
+
+	if err := t.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cResourceType, err)
+	}
+
+	return nil
+}
+
+// PropCalendarAddress specifies the calendar user address of a participant.
+type PropCalendarAddress CalendarAddress
+
+func (p *PropCalendarAddress) decode(params []parser.Token, value string) error {
+	oParams := make(map[string]string)
+
+	var ts []string
+
+	for len(params) > 0 {
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		pValues := params[1:i]
+
+		for _, v := range pValues {
+			ts = append(ts, v.Data)
+		}
+
+		oParams[strings.ToUpper(params[0].Data)] = strings.Join(ts, ",")
+		params = params[i:]
+		ts = ts[:0]
+	}
+
+	var t CalendarAddress
+
+	if err := t.decode(oParams, value); err != nil {
+		return fmt.Errorf(errDecodingType, cCalendarAddress, err)
+	}
+
+	*p = PropCalendarAddress(t)
+
+	return nil
+}
+
+func (p *PropCalendarAddress) encode(w writer) {
+	w.WriteString("CALENDAR-ADDRESS")
+
+	t := CalendarAddress(*p)
+
+	t.aencode(w)
+	w.WriteString("\r\n")
+}
+
+func (p *PropCalendarAddress) valid() error {
+	t := CalendarAddress(*p)
+
+	if err := t.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cCalendarAddress, err)
+	}
+
+	return nil
+}
+
+// PropStyledDescription provides a rich text description of a calendar
+// component.
+type PropStyledDescription struct {
+	AlternativeRepresentation *ParamAlternativeRepresentation
+	FormatType                *ParamFormatType
+	Language                  *ParamLanguage
+	Derived                   *ParamDerived
+	Text                      *Text
+	URI                       *URI
+
+	Extensions ExtensionParams
+}
+
+func (p *PropStyledDescription) decode(params []parser.Token, value string) error {
+	vType := -1
+	oParams := make(map[string]string)
+
+	var ts []string
+
+	for len(params) > 0 {
+		pName := strings.ToUpper(params[0].Data)
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		pValues := params[1:i]
+		params = params[i:]
+
+		switch pName {
+		case "ALTREP":
+			if p.AlternativeRepresentation != nil {
+				return fmt.Errorf(errDecodingProp, cStyledDescription, cAlternativeRepresentation, ErrDuplicateParam)
+			}
+
+			p.AlternativeRepresentation = new(ParamAlternativeRepresentation)
+
+			if err := p.AlternativeRepresentation.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cStyledDescription, cAlternativeRepresentation, err)
+			}
+		case "FMTTYPE":
+			if p.FormatType != nil {
+				return fmt.Errorf(errDecodingProp, cStyledDescription, cFormatType, ErrDuplicateParam)
+			}
+
+			p.FormatType = new(ParamFormatType)
+
+			if err := p.FormatType.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cStyledDescription, cFormatType, err)
+			}
+		case "LANGUAGE":
+			if p.Language != nil {
+				return fmt.Errorf(errDecodingProp, cStyledDescription, cLanguage, ErrDuplicateParam)
+			}
+
+			p.Language = new(ParamLanguage)
+
+			if err := p.Language.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cStyledDescription, cLanguage, err)
+			}
+		case "DERIVED":
+			if p.Derived != nil {
+				return fmt.Errorf(errDecodingProp, cStyledDescription, cDerived, ErrDuplicateParam)
+			}
+
+			p.Derived = new(ParamDerived)
+
+			if err := p.Derived.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cStyledDescription, cDerived, err)
+			}
+		case "VALUE":
+			if len(pValues) != 1 {
+				return fmt.Errorf(errDecodingProp, cStyledDescription, cValue, ErrInvalidValue)
+			}
+
+			if vType != -1 {
+				return fmt.Errorf(errDecodingProp, cStyledDescription, cValue, ErrDuplicateParam)
+			}
+
+			switch strings.ToUpper(pValues[0].Data) {
+			case "TEXT":
+				vType = 0
+			case "URI":
+				vType = 1
+			default:
+				return fmt.Errorf(errDecodingType, cStyledDescription, ErrInvalidValue)
+			}
+		default:
+			p.Extensions.decode(pName, pValues)
+
+			for _, v := range pValues {
+				ts = append(ts, v.Data)
+			}
+
+			oParams[pName] = strings.Join(ts, ",")
+			ts = ts[:0]
+		}
+	}
+
+	if vType == -1 {
+		vType = 0
+	}
+
+	switch vType {
+	case 0:
+		p.Text = new(Text)
+
+		if err := p.Text.decode(oParams, value); err != nil {
+			return fmt.Errorf(errDecodingProp, cStyledDescription, cText, err)
+		}
+	case 1:
+		p.URI = new(URI)
+
+		if err := p.URI.decode(oParams, value); err != nil {
+			return fmt.Errorf(errDecodingProp, cStyledDescription, cURI, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *PropStyledDescription) encode(w writer) {
+	w.WriteString("STYLED-DESCRIPTION")
+
+	if p.AlternativeRepresentation != nil {
+		p.AlternativeRepresentation.encode(w)
+	}
+
+	if p.FormatType != nil {
+		p.FormatType.encode(w)
+	}
+
+	if p.Language != nil {
+		p.Language.encode(w)
+	}
+
+	if p.Derived != nil {
+		p.Derived.encode(w)
+	}
+
+	p.Extensions.encode(w)
+
+	if p.Text != nil {
+		w.WriteString(";VALUE=TEXT")
+		p.Text.aencode(w)
+	}
+
+	if p.URI != nil {
+		w.WriteString(";VALUE=URI")
+		p.URI.aencode(w)
+	}
+
+	w.WriteString("\r\n")
+}
+
+func (p *PropStyledDescription) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cStyledDescription, err)
+	}
+
+	if p.AlternativeRepresentation != nil {
+		if err := p.AlternativeRepresentation.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cStyledDescription, cAlternativeRepresentation, err)
+		}
+	}
+
+	if p.FormatType != nil {
+		if err := p.FormatType.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cStyledDescription, cFormatType, err)
+		}
+	}
+
+	if p.Language != nil {
+		if err := p.Language.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cStyledDescription, cLanguage, err)
+		}
+	}
+
+	if p.Derived != nil {
+		if err := p.Derived.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cStyledDescription, cDerived, err)
+		}
+	}
+
+	c := 0
+
+	if p.Text != nil {
+		if err := p.Text.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cStyledDescription, cText, err)
+		}
+
+		c++
+	}
+
+	if p.URI != nil {
+		if err := p.URI.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cStyledDescription, cURI, err)
+		}
+
+		c++
+	}
+
+	if c != 1 {
+		return fmt.Errorf(errValidatingType, cStyledDescription, ErrInvalidValue)
+	}
+
+	return nil
+}
+
+// PropStructuredData provides structured data associated with a calendar
+// component.
+type PropStructuredData struct {
+	FormatType *ParamFormatType
+	Schema     *ParamSchema
+	Text       *Text
+	Binary     *Binary
+	URI        *URI
+
+	Extensions ExtensionParams
+}
+
+func (p *PropStructuredData) decode(params []parser.Token, value string) error {
+	vType := -1
+	oParams := make(map[string]string)
+
+	var ts []string
+
+	for len(params) > 0 {
+		pName := strings.ToUpper(params[0].Data)
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		pValues := params[1:i]
+		params = params[i:]
+
+		switch pName {
+		case "FMTTYPE":
+			if p.FormatType != nil {
+				return fmt.Errorf(errDecodingProp, cStructuredData, cFormatType, ErrDuplicateParam)
+			}
+
+			p.FormatType = new(ParamFormatType)
+
+			if err := p.FormatType.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cStructuredData, cFormatType, err)
+			}
+		case "SCHEMA":
+			if p.Schema != nil {
+				return fmt.Errorf(errDecodingProp, cStructuredData, cSchema, ErrDuplicateParam)
+			}
+
+			p.Schema = new(ParamSchema)
+
+			if err := p.Schema.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cStructuredData, cSchema, err)
+			}
+		case "VALUE":
+			if len(pValues) != 1 {
+				return fmt.Errorf(errDecodingProp, cStructuredData, cValue, ErrInvalidValue)
+			}
+
+			if vType != -1 {
+				return fmt.Errorf(errDecodingProp, cStructuredData, cValue, ErrDuplicateParam)
+			}
+
+			switch strings.ToUpper(pValues[0].Data) {
+			case "TEXT":
+				vType = 0
+			case "BINARY":
+				vType = 1
+			case "URI":
+				vType = 2
+			default:
+				return fmt.Errorf(errDecodingType, cStructuredData, ErrInvalidValue)
+			}
+		default:
+			p.Extensions.decode(pName, pValues)
+
+			for _, v := range pValues {
+				ts = append(ts, v.Data)
+			}
+
+			oParams[pName] = strings.Join(ts, ",")
+			ts = ts[:0]
+		}
+	}
+
+	if vType == -1 {
+		vType = 0
+	}
+
+	switch vType {
+	case 0:
+		p.Text = new(Text)
+
+		if err := p.Text.decode(oParams, value); err != nil {
+			return fmt.Errorf(errDecodingProp, cStructuredData, cText, err)
+		}
+	case 1:
+		p.Binary = new(Binary)
+
+		if err := p.Binary.decode(oParams, value); err != nil {
+			return fmt.Errorf(errDecodingProp, cStructuredData, cBinary, err)
+		}
+	case 2:
+		p.URI = new(URI)
+
+		if err := p.URI.decode(oParams, value); err != nil {
+			return fmt.Errorf(errDecodingProp, cStructuredData, cURI, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *PropStructuredData) encode(w writer) {
+	w.WriteString("STRUCTURED-DATA")
+
+	if p.FormatType != nil {
+		p.FormatType.encode(w)
+	}
+
+	if p.Schema != nil {
+		p.Schema.encode(w)
+	}
+
+	p.Extensions.encode(w)
+
+	if p.Text != nil {
+		w.WriteString(";VALUE=TEXT")
+		p.Text.aencode(w)
+	}
+
+	if p.Binary != nil {
+		w.WriteString(";VALUE=BINARY")
+		p.Binary.aencode(w)
+	}
+
+	if p.URI != nil {
+		w.WriteString(";VALUE=URI")
+		p.URI.aencode(w)
+	}
+
+	w.WriteString("\r\n")
+}
+
+func (p *PropStructuredData) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cStructuredData, err)
+	}
+
+	if p.FormatType != nil {
+		if err := p.FormatType.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cStructuredData, cFormatType, err)
+		}
+	}
+
+	if p.Schema != nil {
+		if err := p.Schema.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cStructuredData, cSchema, err)
+		}
+	}
+
+	c := 0
+
+	if p.Text != nil {
+		if err := p.Text.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cStructuredData, cText, err)
+		}
+
+		c++
+	}
+
+	if p.Binary != nil {
+		if err := p.Binary.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cStructuredData, cBinary, err)
+		}
+
+		c++
+	}
+
+	if p.URI != nil {
+		if err := p.URI.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cStructuredData, cURI, err)
+		}
+
+		c++
+	}
+
+	if c != 1 {
+		return fmt.Errorf(errValidatingType, cStructuredData, ErrInvalidValue)
+	}
+
+	return nil
+}
+
+// PropBusyType specifies the default busy time type for time not covered by an
+// available component.
+type PropBusyType uint8
+
+// PropBusyType constant values.
+const (
+	BusyTypeBusyUnavailable PropBusyType = iota
+	BusyTypeBusy
+	BusyTypeBusyTentative
+)
+
+// New returns a pointer to the type (used with constants for ease of use with
+// optional values).
+func (p PropBusyType) New() *PropBusyType {
+	return &p
+}
+
+func (p *PropBusyType) decode(params []parser.Token, value string) error {
+	switch strings.ToUpper(value) {
+	case "BUSY-UNAVAILABLE":
+		*p = BusyTypeBusyUnavailable
+	case "BUSY":
+		*p = BusyTypeBusy
+	case "BUSY-TENTATIVE":
+		*p = BusyTypeBusyTentative
+	default:
+		return fmt.Errorf(errDecodingType, cBusyType, ErrInvalidValue)
+	}
+
+	return nil
+}
+
+func (p *PropBusyType) encode(w writer) {
+	w.WriteString("BUSYTYPE")
+	w.WriteString(":")
+
+	switch *p {
+	case BusyTypeBusyUnavailable:
+		w.WriteString("BUSY-UNAVAILABLE")
+	case BusyTypeBusy:
+		w.WriteString("BUSY")
+	case BusyTypeBusyTentative:
+		w.WriteString("BUSY-TENTATIVE")
+	}
+
+	w.WriteString("\r\n")
+}
+
+func (p *PropBusyType) valid() error {
+	switch *p {
+	case BusyTypeBusyUnavailable, BusyTypeBusy, BusyTypeBusyTentative:
+	default:
+		return fmt.Errorf(errValidatingType, cBusyType, ErrInvalidValue)
+	}
+
+	return nil
+}
+
+// PropLink specifies a reference to external information related to a component.
+type PropLink struct {
+	LinkRelation *ParamLinkRelation
+	Label        *ParamLabel
+	Language     *ParamLanguage
+	FormatType   *ParamFormatType
+	URI          *URI
+	UID          *UID
+	XMLReference *XMLReference
+
+	Extensions ExtensionParams
+}
+
+func (p *PropLink) decode(params []parser.Token, value string) error {
+	vType := -1
+	oParams := make(map[string]string)
+
+	var ts []string
+
+	for len(params) > 0 {
+		pName := strings.ToUpper(params[0].Data)
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		pValues := params[1:i]
+		params = params[i:]
+
+		switch pName {
+		case "LINKREL":
+			if p.LinkRelation != nil {
+				return fmt.Errorf(errDecodingProp, cLink, cLinkRelation, ErrDuplicateParam)
+			}
+
+			p.LinkRelation = new(ParamLinkRelation)
+
+			if err := p.LinkRelation.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cLink, cLinkRelation, err)
+			}
+		case "LABEL":
+			if p.Label != nil {
+				return fmt.Errorf(errDecodingProp, cLink, cLabel, ErrDuplicateParam)
+			}
+
+			p.Label = new(ParamLabel)
+
+			if err := p.Label.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cLink, cLabel, err)
+			}
+		case "LANGUAGE":
+			if p.Language != nil {
+				return fmt.Errorf(errDecodingProp, cLink, cLanguage, ErrDuplicateParam)
+			}
+
+			p.Language = new(ParamLanguage)
+
+			if err := p.Language.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cLink, cLanguage, err)
+			}
+		case "FMTTYPE":
+			if p.FormatType != nil {
+				return fmt.Errorf(errDecodingProp, cLink, cFormatType, ErrDuplicateParam)
+			}
+
+			p.FormatType = new(ParamFormatType)
+
+			if err := p.FormatType.decode(pValues); err != nil {
+				return fmt.Errorf(errDecodingProp, cLink, cFormatType, err)
+			}
+		case "VALUE":
+			if len(pValues) != 1 {
+				return fmt.Errorf(errDecodingProp, cLink, cValue, ErrInvalidValue)
+			}
+
+			if vType != -1 {
+				return fmt.Errorf(errDecodingProp, cLink, cValue, ErrDuplicateParam)
+			}
+
+			switch strings.ToUpper(pValues[0].Data) {
+			case "URI":
+				vType = 0
+			case "UID":
+				vType = 1
+			case "XML-REFERENCE":
+				vType = 2
+			default:
+				return fmt.Errorf(errDecodingType, cLink, ErrInvalidValue)
+			}
+		default:
+			p.Extensions.decode(pName, pValues)
+
+			for _, v := range pValues {
+				ts = append(ts, v.Data)
+			}
+
+			oParams[pName] = strings.Join(ts, ",")
+			ts = ts[:0]
+		}
+	}
+
+	if p.LinkRelation == nil {
+		return fmt.Errorf(errDecodingProp, cLink, cLinkRelation, ErrMissingRequired)
+	}
+
+	if vType == -1 {
+		vType = 0
+	}
+
+	switch vType {
+	case 0:
+		p.URI = new(URI)
+
+		if err := p.URI.decode(oParams, value); err != nil {
+			return fmt.Errorf(errDecodingProp, cLink, cURI, err)
+		}
+	case 1:
+		p.UID = new(UID)
+
+		if err := p.UID.decode(oParams, value); err != nil {
+			return fmt.Errorf(errDecodingProp, cLink, cUID, err)
+		}
+	case 2:
+		p.XMLReference = new(XMLReference)
+
+		if err := p.XMLReference.decode(oParams, value); err != nil {
+			return fmt.Errorf(errDecodingProp, cLink, cXMLReference, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *PropLink) encode(w writer) {
+	w.WriteString("LINK")
+
+	if p.LinkRelation != nil {
+		p.LinkRelation.encode(w)
+	}
+
+	if p.Label != nil {
+		p.Label.encode(w)
+	}
+
+	if p.Language != nil {
+		p.Language.encode(w)
+	}
+
+	if p.FormatType != nil {
+		p.FormatType.encode(w)
+	}
+
+	p.Extensions.encode(w)
+
+	if p.URI != nil {
+		w.WriteString(";VALUE=URI")
+		p.URI.aencode(w)
+	}
+
+	if p.UID != nil {
+		w.WriteString(";VALUE=UID")
+		p.UID.aencode(w)
+	}
+
+	if p.XMLReference != nil {
+		w.WriteString(";VALUE=XML-REFERENCE")
+		p.XMLReference.aencode(w)
+	}
+
+	w.WriteString("\r\n")
+}
+
+func (p *PropLink) valid() error {
+	if err := p.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cLink, err)
+	}
+
+	if p.LinkRelation != nil {
+		if err := p.LinkRelation.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cLink, cLinkRelation, err)
+		}
+	} else {
+		return fmt.Errorf(errValidatingProp, cLink, cLinkRelation, ErrMissingRequired)
+	}
+
+	if p.Label != nil {
+		if err := p.Label.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cLink, cLabel, err)
+		}
+	}
+
+	if p.Language != nil {
+		if err := p.Language.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cLink, cLanguage, err)
+		}
+	}
+
+	if p.FormatType != nil {
+		if err := p.FormatType.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cLink, cFormatType, err)
+		}
+	}
+
+	c := 0
+
+	if p.URI != nil {
+		if err := p.URI.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cLink, cURI, err)
+		}
+
+		c++
+	}
+
+	if p.UID != nil {
+		if err := p.UID.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cLink, cUID, err)
+		}
+
+		c++
+	}
+
+	if p.XMLReference != nil {
+		if err := p.XMLReference.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cLink, cXMLReference, err)
+		}
+
+		c++
+	}
+
+	if c != 1 {
+		return fmt.Errorf(errValidatingType, cLink, ErrInvalidValue)
+	}
+
+	return nil
+}
+
+// PropRefID specifies a free-form key used to group related components.
+type PropRefID Text
+
+func (p *PropRefID) decode(params []parser.Token, value string) error {
+	oParams := make(map[string]string)
+
+	var ts []string
+
+	for len(params) > 0 {
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		pValues := params[1:i]
+
+		for _, v := range pValues {
+			ts = append(ts, v.Data)
+		}
+
+		oParams[strings.ToUpper(params[0].Data)] = strings.Join(ts, ",")
+		params = params[i:]
+		ts = ts[:0]
+	}
+
+	var t Text
+
+	if err := t.decode(oParams, value); err != nil {
+		return fmt.Errorf(errDecodingType, cRefID, err)
+	}
+
+	*p = PropRefID(t)
+
+	return nil
+}
+
+func (p *PropRefID) encode(w writer) {
+	w.WriteString("REFID")
+
+	t := Text(*p)
+
+	t.aencode(w)
+	w.WriteString("\r\n")
+}
+
+func (p *PropRefID) valid() error {
+	t := Text(*p)
+
+	if err := t.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cRefID, err)
+	}
+
+	return nil
+}
+
+// PropConcept specifies a formal categorization of a component.
+type PropConcept URI
+
+func (p *PropConcept) decode(params []parser.Token, value string) error {
+	oParams := make(map[string]string)
+
+	var ts []string
+
+	for len(params) > 0 {
+		i := 1
+
+		for i < len(params) && params[i].Type != tokenParamName {
+			i++
+		}
+
+		pValues := params[1:i]
+
+		for _, v := range pValues {
+			ts = append(ts, v.Data)
+		}
+
+		oParams[strings.ToUpper(params[0].Data)] = strings.Join(ts, ",")
+		params = params[i:]
+		ts = ts[:0]
+	}
+
+	var t URI
+
+	if err := t.decode(oParams, value); err != nil {
+		return fmt.Errorf(errDecodingType, cConcept, err)
+	}
+
+	*p = PropConcept(t)
+
+	return nil
+}
+
+func (p *PropConcept) encode(w writer) {
+	w.WriteString("CONCEPT")
+
+	t := URI(*p)
+
+	t.aencode(w)
+	w.WriteString("\r\n")
+}
+
+func (p *PropConcept) valid() error {
+	t := URI(*p)
+
+	if err := t.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cConcept, err)
+	}
+
+	return nil
+}
+
+// valueType returns the value type of the named property, when it only has
+// one.
+func valueType(property string) string {
+	switch property {
+	case "ACTION", "CALSCALE", "CATEGORIES", "CLASS", "COMMENT", "CONTACT", "DESCRIPTION", "LOCATION", "METHOD", "PRODID", "REQUEST-STATUS", "RESOURCES", "STATUS", "SUMMARY", "TRANSP", "TZID", "TZNAME", "UID", "VERSION", "ALARM-AGENT", "PROXIMITY", "NAME", "COLOR", "LOCATION-TYPE", "PARTICIPANT-TYPE", "RESOURCE-TYPE", "BUSYTYPE", "REFID":
+		return "TEXT"
+	case "ATTENDEE", "ORGANIZER", "CALENDAR-ADDRESS":
+		return "CAL-ADDRESS"
+	case "COMPLETED", "CREATED", "DTSTAMP", "LAST-MODIFIED", "LAST-TRIGGERED", "ACKNOWLEDGED":
+		return "DATE-TIME"
+	case "DURATION", "REFRESH-INTERVAL":
+		return "DURATION"
+	case "FREEBUSY":
+		return "PERIOD"
+	case "GEO":
+		return "FLOAT"
+	case "PERCENT-COMPLETE", "PRIORITY", "REPEAT", "SEQUENCE":
+		return "INTEGER"
+	case "RRULE":
+		return "RECUR"
+	case "TZOFFSETFROM", "TZOFFSETTO":
+		return "UTC-OFFSET"
+	case "TZURL", "URL", "URI", "GEO-LOCATION", "SOURCE", "CONFERENCE", "CONCEPT":
+		return "URI"
+	case "DEFAULT-ALARM":
+		return "BOOLEAN"
+	}
+
+	return ""
+}
+
 // Errors..
 var (
 	ErrDuplicateParam = errors.New("duplicate param")
@@ -4372,4 +6334,19 @@ const (
 	cProximity           = "Proximity"
 	cGeoLocation         = "GeoLocation"
 	cDefaultAlarm        = "DefaultAlarm"
+	cName                = "Name"
+	cColor               = "Color"
+	cRefreshInterval     = "RefreshInterval"
+	cSource              = "Source"
+	cImage               = "Image"
+	cConference          = "Conference"
+	cLocationType        = "LocationType"
+	cParticipantType     = "ParticipantType"
+	cResourceType        = "ResourceType"
+	cStyledDescription   = "StyledDescription"
+	cStructuredData      = "StructuredData"
+	cBusyType            = "BusyType"
+	cLink                = "Link"
+	cRefID               = "RefID"
+	cConcept             = "Concept"
 )
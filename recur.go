@@ -0,0 +1,504 @@
+package ics
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	maxRecurYear = 9999
+
+	// maxEmptyPeriods is the number of consecutive periods without an
+	// occurrence after which a rule is assumed to never match again.
+	maxEmptyPeriods = 100000
+)
+
+// RecurIterator produces the occurrences of a Recur rule, in order.
+//
+// Occurrences are generated lazily, one period (year, month, week, day, hour,
+// minute or second, depending on the Frequency) at a time, so rules without a
+// COUNT or UNTIL can be safely iterated.
+type RecurIterator struct {
+	freq      Frequency
+	interval  int
+	weekStart time.Weekday
+	count     uint64
+	until     time.Time
+	untilDate bool
+	start     time.Time
+	loc       *time.Location
+
+	bySecond, byMinute, byHour []int
+	byDay                      []DayRecur
+	byMonthDay                 []int
+	byYearDay                  []int
+	byWeekNum                  []int
+	byMonth                    []int
+	bySetPos                   []int
+	times                      []int
+
+	base    time.Time
+	step    time.Duration
+	period  int64
+	buf     []time.Time
+	emitted uint64
+	done    bool
+}
+
+// Iterate returns a RecurIterator that yields the occurrences of the rule on or
+// after dtstart, as described in RFC 5545 Section 3.3.10.
+//
+// Any BYxxx rule parts not given are derived from dtstart, and all generated
+// times are in the location of dtstart. Only occurrences generated by the rule
+// are returned, so dtstart itself is only returned if it matches the rule.
+func (r *Recur) Iterate(dtstart time.Time) *RecurIterator {
+	ri := &RecurIterator{
+		freq:       r.Frequency,
+		interval:   int(r.Interval),
+		weekStart:  time.Monday,
+		count:      r.Count,
+		until:      r.Until,
+		untilDate:  !r.UntilTime,
+		start:      dtstart,
+		loc:        dtstart.Location(),
+		bySecond:   uint8s(r.BySecond),
+		byMinute:   uint8s(r.ByMinute),
+		byHour:     uint8s(r.ByHour),
+		byDay:      r.ByDay,
+		byMonthDay: make([]int, len(r.ByMonthDay)),
+		byYearDay:  make([]int, len(r.ByYearDay)),
+		byWeekNum:  make([]int, len(r.ByWeekNum)),
+		byMonth:    make([]int, len(r.ByMonth)),
+		bySetPos:   make([]int, len(r.BySetPos)),
+	}
+
+	if ri.interval == 0 {
+		ri.interval = 1
+	}
+
+	if r.WeekStart != UnknownDay {
+		ri.weekStart = weekday(r.WeekStart)
+	}
+
+	for n, d := range r.ByMonthDay {
+		ri.byMonthDay[n] = int(d)
+	}
+
+	for n, d := range r.ByYearDay {
+		ri.byYearDay[n] = int(d)
+	}
+
+	for n, w := range r.ByWeekNum {
+		ri.byWeekNum[n] = int(w)
+	}
+
+	for n, m := range r.ByMonth {
+		ri.byMonth[n] = int(m)
+	}
+
+	for n, p := range r.BySetPos {
+		ri.bySetPos[n] = int(p)
+	}
+
+	if len(ri.byWeekNum) == 0 && len(ri.byYearDay) == 0 && len(ri.byMonthDay) == 0 && len(ri.byDay) == 0 {
+		switch ri.freq {
+		case Yearly:
+			if len(ri.byMonth) == 0 {
+				ri.byMonth = []int{int(dtstart.Month())}
+			}
+
+			ri.byMonthDay = []int{dtstart.Day()}
+		case Monthly:
+			ri.byMonthDay = []int{dtstart.Day()}
+		case Weekly:
+			ri.byDay = []DayRecur{{Day: WeekDay(dtstart.Weekday() + 1)}}
+		}
+	}
+
+	if ri.freq > Hourly && len(ri.byHour) == 0 {
+		ri.byHour = []int{dtstart.Hour()}
+	}
+
+	if ri.freq > Minutely && len(ri.byMinute) == 0 {
+		ri.byMinute = []int{dtstart.Minute()}
+	}
+
+	if ri.freq > Secondly && len(ri.bySecond) == 0 {
+		ri.bySecond = []int{dtstart.Second()}
+	}
+
+	// A leap second cannot be represented, so a rule with only leap seconds
+	// can never match.
+	seconds := ri.bySecond[:0:0]
+
+	for _, s := range ri.bySecond {
+		if s < 60 {
+			seconds = append(seconds, s)
+		}
+	}
+
+	ri.bySecond = seconds
+	ri.done = len(r.BySecond) > 0 && len(seconds) == 0
+
+	sort.Ints(ri.byHour)
+	sort.Ints(ri.byMinute)
+	sort.Ints(ri.bySecond)
+
+	switch ri.freq {
+	case Hourly:
+		ri.base = time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day(), dtstart.Hour(), 0, 0, 0, ri.loc)
+		ri.step = time.Duration(ri.interval) * time.Hour
+	case Minutely:
+		ri.base = time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day(), dtstart.Hour(), dtstart.Minute(), 0, 0, ri.loc)
+		ri.step = time.Duration(ri.interval) * time.Minute
+	case Secondly:
+		ri.base = time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day(), dtstart.Hour(), dtstart.Minute(), dtstart.Second(), 0, ri.loc)
+		ri.step = time.Duration(ri.interval) * time.Second
+	default:
+		for _, h := range ri.byHour {
+			for _, m := range ri.byMinute {
+				for _, s := range ri.bySecond {
+					ri.times = append(ri.times, h*3600+m*60+s)
+				}
+			}
+		}
+	}
+
+	return ri
+}
+
+// Iterate returns a RecurIterator for the recurrence rule, as Recur.Iterate.
+func (p *PropRecurrenceRule) Iterate(dtstart time.Time) *RecurIterator {
+	return (*Recur)(p).Iterate(dtstart)
+}
+
+// Next returns the next occurrence of the rule. The bool will be false when
+// there are no more occurrences.
+func (r *RecurIterator) Next() (time.Time, bool) {
+	for {
+		for empty := 0; len(r.buf) == 0; empty++ {
+			if r.done || empty >= maxEmptyPeriods || !r.nextPeriod() {
+				r.done = true
+
+				return time.Time{}, false
+			}
+		}
+
+		t := r.buf[0]
+		r.buf = r.buf[1:]
+
+		if t.Before(r.start) {
+			continue
+		}
+
+		if r.pastUntil(t) || r.count > 0 && r.emitted >= r.count {
+			r.done = true
+			r.buf = nil
+
+			return time.Time{}, false
+		}
+
+		r.emitted++
+
+		return t, true
+	}
+}
+
+func (r *RecurIterator) pastUntil(t time.Time) bool {
+	if r.until.IsZero() {
+		return false
+	} else if r.untilDate {
+		return !t.Before(time.Date(r.until.Year(), r.until.Month(), r.until.Day()+1, 0, 0, 0, 0, r.loc))
+	}
+
+	return t.After(r.until)
+}
+
+func (r *RecurIterator) nextPeriod() bool {
+	k := r.period
+	r.period++
+
+	var (
+		days  []time.Time
+		start = time.Date(r.start.Year(), r.start.Month(), r.start.Day(), 0, 0, 0, 0, time.UTC)
+	)
+
+	switch r.freq {
+	case Yearly:
+		y := r.start.Year() + int(k)*r.interval
+		if y > maxRecurYear {
+			return false
+		}
+
+		days = dayRange(time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC), daysInYear(y))
+	case Monthly:
+		m := int(r.start.Month()) - 1 + int(k)*r.interval
+		y := r.start.Year() + m/12
+		if y > maxRecurYear {
+			return false
+		}
+
+		days = dayRange(time.Date(y, time.Month(m%12+1), 1, 0, 0, 0, 0, time.UTC), daysInMonth(y, time.Month(m%12+1)))
+	case Weekly:
+		offset := int(7+start.Weekday()-r.weekStart) % 7
+		ws := start.AddDate(0, 0, 7*int(k)*r.interval-offset)
+		if ws.Year() > maxRecurYear {
+			return false
+		}
+
+		days = dayRange(ws, 7)
+	case Daily:
+		d := start.AddDate(0, 0, int(k)*r.interval)
+		if d.Year() > maxRecurYear {
+			return false
+		}
+
+		days = []time.Time{d}
+	default:
+		return r.subDaily(k)
+	}
+
+	var set []time.Time
+
+	for _, d := range days {
+		if !r.matchDay(d) {
+			continue
+		}
+
+		for _, t := range r.times {
+			set = append(set, time.Date(d.Year(), d.Month(), d.Day(), t/3600, t/60%60, t%60, 0, r.loc))
+		}
+	}
+
+	r.setBuf(set)
+
+	return true
+}
+
+func (r *RecurIterator) subDaily(k int64) bool {
+	p := r.base.Add(time.Duration(k) * r.step)
+	if p.Year() > maxRecurYear {
+		return false
+	}
+
+	y, m, d := p.Date()
+
+	if !r.matchDay(time.Date(y, m, d, 0, 0, 0, 0, time.UTC)) {
+		r.skipTo(time.Date(y, m, d+1, 0, 0, 0, 0, r.loc))
+
+		return true
+	} else if !matchInt(r.byHour, p.Hour()) {
+		r.skipTo(time.Date(y, m, d, p.Hour()+1, 0, 0, 0, r.loc))
+
+		return true
+	}
+
+	var set []time.Time
+
+	switch r.freq {
+	case Hourly:
+		for _, mi := range r.byMinute {
+			for _, s := range r.bySecond {
+				set = append(set, p.Add(time.Duration(mi)*time.Minute+time.Duration(s)*time.Second))
+			}
+		}
+	case Minutely:
+		if !matchInt(r.byMinute, p.Minute()) {
+			r.skipTo(time.Date(y, m, d, p.Hour(), p.Minute()+1, 0, 0, r.loc))
+
+			return true
+		}
+
+		for _, s := range r.bySecond {
+			set = append(set, p.Add(time.Duration(s)*time.Second))
+		}
+	default:
+		if !matchInt(r.byMinute, p.Minute()) {
+			r.skipTo(time.Date(y, m, d, p.Hour(), p.Minute()+1, 0, 0, r.loc))
+
+			return true
+		} else if matchInt(r.bySecond, p.Second()) {
+			set = append(set, p)
+		}
+	}
+
+	r.setBuf(set)
+
+	return true
+}
+
+func (r *RecurIterator) skipTo(t time.Time) {
+	if k := int64((t.Sub(r.base) + r.step - 1) / r.step); k > r.period {
+		r.period = k
+	}
+}
+
+func (r *RecurIterator) setBuf(set []time.Time) {
+	sort.Slice(set, func(i, j int) bool {
+		return set[i].Before(set[j])
+	})
+
+	if len(r.bySetPos) > 0 && len(set) > 0 {
+		var selected []time.Time
+
+		for _, pos := range r.bySetPos {
+			if pos > 0 {
+				pos--
+			} else {
+				pos += len(set)
+			}
+
+			if pos >= 0 && pos < len(set) {
+				selected = append(selected, set[pos])
+			}
+		}
+
+		sort.Slice(selected, func(i, j int) bool {
+			return selected[i].Before(selected[j])
+		})
+
+		set = set[:0]
+
+		for n, t := range selected {
+			if n == 0 || !t.Equal(selected[n-1]) {
+				set = append(set, t)
+			}
+		}
+	}
+
+	r.buf = set
+}
+
+func (r *RecurIterator) matchDay(d time.Time) bool {
+	if len(r.byMonth) > 0 && !matchInt(r.byMonth, int(d.Month())) {
+		return false
+	}
+
+	if len(r.byWeekNum) > 0 && !r.matchWeekNum(d) {
+		return false
+	}
+
+	if len(r.byYearDay) > 0 && !matchSigned(r.byYearDay, d.YearDay(), daysInYear(d.Year())) {
+		return false
+	}
+
+	if len(r.byMonthDay) > 0 && !matchSigned(r.byMonthDay, d.Day(), daysInMonth(d.Year(), d.Month())) {
+		return false
+	}
+
+	if len(r.byDay) == 0 {
+		return true
+	}
+
+	var pos, length int
+
+	switch {
+	case r.freq == Monthly, r.freq == Yearly && len(r.byMonth) > 0 && len(r.byWeekNum) == 0:
+		pos, length = d.Day(), daysInMonth(d.Year(), d.Month())
+	case r.freq == Yearly && len(r.byWeekNum) == 0:
+		pos, length = d.YearDay(), daysInYear(d.Year())
+	}
+
+	for _, day := range r.byDay {
+		if weekday(day.Day) != d.Weekday() {
+			continue
+		}
+
+		if day.Occurrence == 0 || length == 0 {
+			return true
+		}
+
+		if o := int(day.Occurrence); o > 0 && (pos-1)/7+1 == o || o < 0 && -((length-pos)/7+1) == o {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *RecurIterator) matchWeekNum(d time.Time) bool {
+	y := d.Year()
+	w1 := r.firstWeek(y)
+
+	if d.Before(w1) {
+		y--
+		w1 = r.firstWeek(y)
+	} else if next := r.firstWeek(y + 1); !d.Before(next) {
+		y++
+		w1 = next
+	}
+
+	weeks := int(r.firstWeek(y+1).Sub(w1) / (7 * 24 * time.Hour))
+	week := int(d.Sub(w1)/(7*24*time.Hour)) + 1
+
+	return matchSigned(r.byWeekNum, week, weeks)
+}
+
+// firstWeek returns the first day of week number 1 of the given year, that
+// being the first week with at least four days in the year.
+func (r *RecurIterator) firstWeek(y int) time.Time {
+	jan1 := time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC)
+	offset := int(7+r.weekStart-jan1.Weekday()) % 7
+
+	if offset >= 4 {
+		offset -= 7
+	}
+
+	return jan1.AddDate(0, 0, offset)
+}
+
+func weekday(d WeekDay) time.Weekday {
+	return time.Weekday(d - 1)
+}
+
+func uint8s(u []uint8) []int {
+	is := make([]int, len(u))
+
+	for n, v := range u {
+		is[n] = int(v)
+	}
+
+	return is
+}
+
+func matchInt(list []int, v int) bool {
+	if len(list) == 0 {
+		return true
+	}
+
+	for _, l := range list {
+		if l == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchSigned(list []int, v, length int) bool {
+	for _, l := range list {
+		if l == v || l == v-length-1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func dayRange(first time.Time, n int) []time.Time {
+	days := make([]time.Time, n)
+
+	for i := range days {
+		days[i] = first.AddDate(0, 0, i)
+	}
+
+	return days
+}
+
+func daysInYear(y int) int {
+	return time.Date(y, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
+
+func daysInMonth(y int, m time.Month) int {
+	return time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,238 @@
+package ics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecurIterate(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("unexpected error loading timezone: %s", err)
+	}
+
+	tests := []struct {
+		Start  time.Time
+		Rule   string
+		Limit  int
+		Output []string
+	}{
+		{ // 1
+			Start:  time.Date(1997, 9, 2, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=DAILY;COUNT=10",
+			Output: []string{"19970902T090000", "19970903T090000", "19970904T090000", "19970905T090000", "19970906T090000", "19970907T090000", "19970908T090000", "19970909T090000", "19970910T090000", "19970911T090000"},
+		},
+		{ // 2
+			Start:  time.Date(1997, 9, 2, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=DAILY;UNTIL=19970907T000000Z",
+			Output: []string{"19970902T090000", "19970903T090000", "19970904T090000", "19970905T090000", "19970906T090000"},
+		},
+		{ // 3
+			Start:  time.Date(1997, 9, 2, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=DAILY;INTERVAL=10;COUNT=5",
+			Output: []string{"19970902T090000", "19970912T090000", "19970922T090000", "19971002T090000", "19971012T090000"},
+		},
+		{ // 4
+			Start:  time.Date(1998, 1, 1, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=YEARLY;UNTIL=20000131T140000Z;BYMONTH=1;BYDAY=SU,MO,TU,WE,TH,FR,SA",
+			Limit:  4,
+			Output: []string{"19980101T090000", "19980102T090000", "19980103T090000", "19980104T090000"},
+		},
+		{ // 5
+			Start:  time.Date(1997, 9, 2, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=WEEKLY;UNTIL=19971007T000000Z;WKST=SU;BYDAY=TU,TH",
+			Output: []string{"19970902T090000", "19970904T090000", "19970909T090000", "19970911T090000", "19970916T090000", "19970918T090000", "19970923T090000", "19970925T090000", "19970930T090000", "19971002T090000"},
+		},
+		{ // 6
+			Start:  time.Date(1997, 9, 1, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;WKST=SU;BYDAY=MO,WE,FR",
+			Limit:  6,
+			Output: []string{"19970901T090000", "19970903T090000", "19970905T090000", "19970915T090000", "19970917T090000", "19970919T090000"},
+		},
+		{ // 7
+			Start:  time.Date(1997, 9, 5, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=MONTHLY;COUNT=10;BYDAY=1FR",
+			Output: []string{"19970905T090000", "19971003T090000", "19971107T090000", "19971205T090000", "19980102T090000", "19980206T090000", "19980306T090000", "19980403T090000", "19980501T090000", "19980605T090000"},
+		},
+		{ // 8
+			Start:  time.Date(1997, 9, 7, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=MONTHLY;INTERVAL=2;COUNT=10;BYDAY=1SU,-1SU",
+			Output: []string{"19970907T090000", "19970928T090000", "19971102T090000", "19971130T090000", "19980104T090000", "19980125T090000", "19980301T090000", "19980329T090000", "19980503T090000", "19980531T090000"},
+		},
+		{ // 9
+			Start:  time.Date(1997, 9, 22, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=MONTHLY;COUNT=6;BYDAY=-2MO",
+			Output: []string{"19970922T090000", "19971020T090000", "19971117T090000", "19971222T090000", "19980119T090000", "19980216T090000"},
+		},
+		{ // 10
+			Start:  time.Date(1997, 9, 28, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=MONTHLY;BYMONTHDAY=-3",
+			Limit:  6,
+			Output: []string{"19970928T090000", "19971029T090000", "19971128T090000", "19971229T090000", "19980129T090000", "19980226T090000"},
+		},
+		{ // 11
+			Start:  time.Date(1997, 9, 10, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=MONTHLY;INTERVAL=18;COUNT=10;BYMONTHDAY=10,11,12,13,14,15",
+			Output: []string{"19970910T090000", "19970911T090000", "19970912T090000", "19970913T090000", "19970914T090000", "19970915T090000", "19990310T090000", "19990311T090000", "19990312T090000", "19990313T090000"},
+		},
+		{ // 12
+			Start:  time.Date(1997, 1, 1, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=YEARLY;INTERVAL=3;COUNT=10;BYYEARDAY=1,100,200",
+			Output: []string{"19970101T090000", "19970410T090000", "19970719T090000", "20000101T090000", "20000409T090000", "20000718T090000", "20030101T090000", "20030410T090000", "20030719T090000", "20060101T090000"},
+		},
+		{ // 13
+			Start:  time.Date(1997, 5, 19, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=YEARLY;BYDAY=20MO",
+			Limit:  3,
+			Output: []string{"19970519T090000", "19980518T090000", "19990517T090000"},
+		},
+		{ // 14
+			Start:  time.Date(1997, 5, 12, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO",
+			Limit:  3,
+			Output: []string{"19970512T090000", "19980511T090000", "19990517T090000"},
+		},
+		{ // 15
+			Start:  time.Date(1997, 3, 13, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=YEARLY;BYMONTH=3;BYDAY=TH",
+			Limit:  6,
+			Output: []string{"19970313T090000", "19970320T090000", "19970327T090000", "19980305T090000", "19980312T090000", "19980319T090000"},
+		},
+		{ // 16
+			Start:  time.Date(1998, 2, 13, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+			Limit:  4,
+			Output: []string{"19980213T090000", "19980313T090000", "19981113T090000", "19990813T090000"},
+		},
+		{ // 17
+			Start:  time.Date(1996, 11, 5, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=YEARLY;INTERVAL=4;BYMONTH=11;BYDAY=TU;BYMONTHDAY=2,3,4,5,6,7,8",
+			Limit:  3,
+			Output: []string{"19961105T090000", "20001107T090000", "20041102T090000"},
+		},
+		{ // 18
+			Start:  time.Date(1997, 9, 4, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=MONTHLY;COUNT=3;BYDAY=TU,WE,TH;BYSETPOS=3",
+			Output: []string{"19970904T090000", "19971007T090000", "19971106T090000"},
+		},
+		{ // 19
+			Start:  time.Date(1997, 9, 29, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2",
+			Limit:  4,
+			Output: []string{"19970929T090000", "19971030T090000", "19971127T090000", "19971230T090000"},
+		},
+		{ // 20
+			Start:  time.Date(1997, 9, 2, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=HOURLY;INTERVAL=3;UNTIL=19970902T170000Z",
+			Output: []string{"19970902T090000", "19970902T120000"},
+		},
+		{ // 21
+			Start:  time.Date(1997, 9, 2, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=MINUTELY;INTERVAL=15;COUNT=6",
+			Output: []string{"19970902T090000", "19970902T091500", "19970902T093000", "19970902T094500", "19970902T100000", "19970902T101500"},
+		},
+		{ // 22
+			Start:  time.Date(1997, 9, 2, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=MINUTELY;INTERVAL=20;BYHOUR=9,10,11,12,13,14,15,16",
+			Limit:  4,
+			Output: []string{"19970902T090000", "19970902T092000", "19970902T094000", "19970902T100000"},
+		},
+		{ // 23
+			Start:  time.Date(1997, 8, 5, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO",
+			Output: []string{"19970805T090000", "19970810T090000", "19970819T090000", "19970824T090000"},
+		},
+		{ // 24
+			Start:  time.Date(1997, 8, 5, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
+			Output: []string{"19970805T090000", "19970817T090000", "19970819T090000", "19970831T090000"},
+		},
+		{ // 25
+			Start:  time.Date(2007, 1, 15, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=MONTHLY;BYMONTHDAY=15,30;COUNT=5",
+			Output: []string{"20070115T090000", "20070130T090000", "20070215T090000", "20070315T090000", "20070330T090000"},
+		},
+		{ // 26
+			Start:  time.Date(1997, 6, 10, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=YEARLY;COUNT=10;BYMONTH=6,7",
+			Limit:  4,
+			Output: []string{"19970610T090000", "19970710T090000", "19980610T090000", "19980710T090000"},
+		},
+		{ // 27
+			Start:  time.Date(2000, 2, 29, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=YEARLY",
+			Limit:  3,
+			Output: []string{"20000229T090000", "20040229T090000", "20080229T090000"},
+		},
+		{ // 28
+			Start:  time.Date(1997, 9, 2, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=MONTHLY;BYMONTH=2;BYMONTHDAY=30",
+			Output: []string{},
+		},
+		{ // 29
+			Start:  time.Date(1997, 9, 2, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=DAILY;UNTIL=19970904",
+			Output: []string{"19970902T090000", "19970903T090000", "19970904T090000"},
+		},
+		{ // 30
+			Start:  time.Date(1997, 9, 2, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=SECONDLY;BYSECOND=60",
+			Output: []string{},
+		},
+		{ // 31
+			Start:  time.Date(1997, 9, 2, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=MINUTELY;BYSECOND=60",
+			Output: []string{},
+		},
+		{ // 32
+			Start:  time.Date(1997, 9, 2, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=SECONDLY;BYMONTH=2;BYMONTHDAY=30",
+			Output: []string{},
+		},
+		{ // 33
+			Start:  time.Date(1997, 9, 2, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=MINUTELY;BYSECOND=30,60;COUNT=2",
+			Output: []string{"19970902T090030", "19970902T090130"},
+		},
+		{ // 34
+			Start:  time.Date(2097, 3, 1, 9, 0, 0, 0, ny),
+			Rule:   "FREQ=DAILY;BYMONTH=2;BYMONTHDAY=29;COUNT=1",
+			Output: []string{"21040229T090000"},
+		},
+	}
+
+	for n, test := range tests {
+		var r Recur
+
+		if err := r.decode(nil, test.Rule); err != nil {
+			t.Errorf("test %d: unexpected error decoding rule: %s", n+1, err)
+
+			continue
+		}
+
+		it := r.Iterate(test.Start)
+
+		var got []string
+
+		for test.Limit == 0 || len(got) < test.Limit {
+			o, ok := it.Next()
+			if !ok {
+				break
+			}
+
+			got = append(got, o.In(ny).Format(dateTimeFormat[:15]))
+		}
+
+		if len(got) != len(test.Output) {
+			t.Errorf("test %d: expecting %d occurrences, got %d: %v", n+1, len(test.Output), len(got), got)
+
+			continue
+		}
+
+		for m, o := range test.Output {
+			if got[m] != o {
+				t.Errorf("test %d.%d: expecting occurrence %s, got %s", n+1, m+1, o, got[m])
+			}
+		}
+	}
+}
@@ -13,13 +13,30 @@ import (
 
 // Calendar represents a iCalendar object.
 type Calendar struct {
-	Version   PropVersion
-	ProductID PropProductID
-	Event     []Event
-	Todo      []Todo
-	Journal   []Journal
-	FreeBusy  []FreeBusy
-	Timezone  []Timezone
+	Version         PropVersion
+	ProductID       PropProductID
+	CalendarScale   *PropCalendarScale
+	Method          *PropMethod
+	UID             *PropUID
+	LastModified    *PropLastModified
+	URL             *PropURL
+	RefreshInterval *PropRefreshInterval
+	Source          *PropSource
+	Color           *PropColor
+	Name            []PropName
+	Description     []PropDescription
+	Categories      []PropCategories
+	Image           []PropImage
+	Event           []Event
+	Todo            []Todo
+	Journal         []Journal
+	FreeBusy        []FreeBusy
+	Timezone        []Timezone
+	Availability    []Availability
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
 }
 
 func (s *Calendar) decode(t tokeniser) error {
@@ -37,6 +54,8 @@ Loop:
 		params := p.Data[1 : len(p.Data)-1]
 		value := p.Data[len(p.Data)-1].Data
 
+		checkProperty(t, p.Data[0].Data, params, value)
+
 		switch strings.ToUpper(p.Data[0].Data) {
 		case "BEGIN":
 			switch n := strings.ToUpper(value); n {
@@ -80,14 +99,30 @@ Loop:
 				}
 
 				s.Timezone = append(s.Timezone, e)
+			case "VAVAILABILITY":
+				var e Availability
+
+				if err := e.decode(t); err != nil {
+					return fmt.Errorf(errDecodingProp, cCalendar, cAvailability, err)
+				}
+
+				s.Availability = append(s.Availability, e)
 			default:
-				if err := decodeDummy(t, n); err != nil {
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
 					return fmt.Errorf(errDecodingType, cCalendar, err)
 				}
+
+				s.Components = append(s.Components, c)
 			}
 		case "VERSION":
 			if requiredVersion {
-				return fmt.Errorf(errMultiple, cCalendar, cVersion)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cCalendar, ErrDuplicateProperty, cVersion)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredVersion = true
@@ -95,9 +130,15 @@ Loop:
 			if err := s.Version.decode(params, value); err != nil {
 				return fmt.Errorf(errDecodingProp, cCalendar, cVersion, err)
 			}
+
+			s.PropertyParams.decode("VERSION", params)
 		case "PRODID":
 			if requiredProductID {
-				return fmt.Errorf(errMultiple, cCalendar, cProductID)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cCalendar, ErrDuplicateProperty, cProductID)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredProductID = true
@@ -105,12 +146,240 @@ Loop:
 			if err := s.ProductID.decode(params, value); err != nil {
 				return fmt.Errorf(errDecodingProp, cCalendar, cProductID, err)
 			}
+
+			s.PropertyParams.decode("PRODID", params)
+		case "CALSCALE":
+			if s.CalendarScale != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cCalendar, ErrDuplicateProperty, cCalendarScale)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.CalendarScale = new(PropCalendarScale)
+
+			if err := s.CalendarScale.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cCalendar, cCalendarScale, err)); err != nil {
+					return err
+				}
+
+				s.CalendarScale = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("CALSCALE", params)
+		case "METHOD":
+			if s.Method != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cCalendar, ErrDuplicateProperty, cMethod)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Method = new(PropMethod)
+
+			if err := s.Method.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cCalendar, cMethod, err)); err != nil {
+					return err
+				}
+
+				s.Method = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("METHOD", params)
+		case "UID":
+			if s.UID != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cCalendar, ErrDuplicateProperty, cUID)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.UID = new(PropUID)
+
+			if err := s.UID.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cCalendar, cUID, err)); err != nil {
+					return err
+				}
+
+				s.UID = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("UID", params)
+		case "LAST-MODIFIED":
+			if s.LastModified != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cCalendar, ErrDuplicateProperty, cLastModified)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.LastModified = new(PropLastModified)
+
+			if err := s.LastModified.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cCalendar, cLastModified, err)); err != nil {
+					return err
+				}
+
+				s.LastModified = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("LAST-MODIFIED", params)
+		case "URL":
+			if s.URL != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cCalendar, ErrDuplicateProperty, cURL)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.URL = new(PropURL)
+
+			if err := s.URL.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cCalendar, cURL, err)); err != nil {
+					return err
+				}
+
+				s.URL = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("URL", params)
+		case "REFRESH-INTERVAL":
+			if s.RefreshInterval != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cCalendar, ErrDuplicateProperty, cRefreshInterval)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.RefreshInterval = new(PropRefreshInterval)
+
+			if err := s.RefreshInterval.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cCalendar, cRefreshInterval, err)); err != nil {
+					return err
+				}
+
+				s.RefreshInterval = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("REFRESH-INTERVAL", params)
+		case "SOURCE":
+			if s.Source != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cCalendar, ErrDuplicateProperty, cSource)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Source = new(PropSource)
+
+			if err := s.Source.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cCalendar, cSource, err)); err != nil {
+					return err
+				}
+
+				s.Source = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("SOURCE", params)
+		case "COLOR":
+			if s.Color != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cCalendar, ErrDuplicateProperty, cColor)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Color = new(PropColor)
+
+			if err := s.Color.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cCalendar, cColor, err)); err != nil {
+					return err
+				}
+
+				s.Color = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("COLOR", params)
+		case "NAME":
+			var e PropName
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cCalendar, cName, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Name = append(s.Name, e)
+		case "DESCRIPTION":
+			var e PropDescription
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cCalendar, cDescription, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Description = append(s.Description, e)
+		case "CATEGORIES":
+			var e PropCategories
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cCalendar, cCategories, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Categories = append(s.Categories, e)
+		case "IMAGE":
+			var e PropImage
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cCalendar, cImage, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Image = append(s.Image, e)
 		case "END":
-			if value != "VCALENDAR" {
-				return fmt.Errorf(errDecodingType, cCalendar, ErrInvalidEnd)
+			if err := endValue(t, value, "VCALENDAR"); err != nil {
+				return fmt.Errorf(errDecodingType, cCalendar, err)
 			}
 
 			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
 		}
 	}
 
@@ -123,8 +392,58 @@ Loop:
 
 func (s *Calendar) encode(w writer) {
 	w.WriteString("BEGIN:VCALENDAR\r\n")
-	s.Version.encode(w)
-	s.ProductID.encode(w)
+	s.Version.encode(s.PropertyParams.writer(w, "VERSION"))
+	s.ProductID.encode(s.PropertyParams.writer(w, "PRODID"))
+
+	if s.CalendarScale != nil {
+		s.CalendarScale.encode(s.PropertyParams.writer(w, "CALSCALE"))
+	}
+
+	if s.Method != nil {
+		s.Method.encode(s.PropertyParams.writer(w, "METHOD"))
+	}
+
+	if s.UID != nil {
+		s.UID.encode(s.PropertyParams.writer(w, "UID"))
+	}
+
+	if s.LastModified != nil {
+		s.LastModified.encode(s.PropertyParams.writer(w, "LAST-MODIFIED"))
+	}
+
+	if s.URL != nil {
+		s.URL.encode(s.PropertyParams.writer(w, "URL"))
+	}
+
+	if s.RefreshInterval != nil {
+		s.RefreshInterval.encode(s.PropertyParams.writer(w, "REFRESH-INTERVAL"))
+	}
+
+	if s.Source != nil {
+		s.Source.encode(s.PropertyParams.writer(w, "SOURCE"))
+	}
+
+	if s.Color != nil {
+		s.Color.encode(s.PropertyParams.writer(w, "COLOR"))
+	}
+
+	for n := range s.Name {
+		s.Name[n].encode(w)
+	}
+
+	for n := range s.Description {
+		s.Description[n].encode(w)
+	}
+
+	for n := range s.Categories {
+		s.Categories[n].encode(w)
+	}
+
+	for n := range s.Image {
+		s.Image[n].encode(w)
+	}
+
+	s.Extensions.encode(w)
 
 	for n := range s.Event {
 		s.Event[n].encode(w)
@@ -146,6 +465,14 @@ func (s *Calendar) encode(w writer) {
 		s.Timezone[n].encode(w)
 	}
 
+	for n := range s.Availability {
+		s.Availability[n].encode(w)
+	}
+
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
+
 	w.WriteString("END:VCALENDAR\r\n")
 }
 
@@ -158,6 +485,78 @@ func (s *Calendar) valid() error {
 		return fmt.Errorf(errValidatingProp, cCalendar, cProductID, err)
 	}
 
+	if s.CalendarScale != nil {
+		if err := s.CalendarScale.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cCalendar, cCalendarScale, err)
+		}
+	}
+
+	if s.Method != nil {
+		if err := s.Method.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cCalendar, cMethod, err)
+		}
+	}
+
+	if s.UID != nil {
+		if err := s.UID.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cCalendar, cUID, err)
+		}
+	}
+
+	if s.LastModified != nil {
+		if err := s.LastModified.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cCalendar, cLastModified, err)
+		}
+	}
+
+	if s.URL != nil {
+		if err := s.URL.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cCalendar, cURL, err)
+		}
+	}
+
+	if s.RefreshInterval != nil {
+		if err := s.RefreshInterval.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cCalendar, cRefreshInterval, err)
+		}
+	}
+
+	if s.Source != nil {
+		if err := s.Source.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cCalendar, cSource, err)
+		}
+	}
+
+	if s.Color != nil {
+		if err := s.Color.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cCalendar, cColor, err)
+		}
+	}
+
+	for n := range s.Name {
+		if err := s.Name[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cCalendar, cName, err)
+		}
+	}
+
+	for n := range s.Description {
+		if err := s.Description[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cCalendar, cDescription, err)
+		}
+	}
+
+	for n := range s.Categories {
+		if err := s.Categories[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cCalendar, cCategories, err)
+		}
+	}
+
+	for n := range s.Image {
+		if err := s.Image[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cCalendar, cImage, err)
+		}
+	}
+
 	for n := range s.Event {
 		if err := s.Event[n].valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cCalendar, cEvent, err)
@@ -188,6 +587,26 @@ func (s *Calendar) valid() error {
 		}
 	}
 
+	for n := range s.Availability {
+		if err := s.Availability[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cCalendar, cAvailability, err)
+		}
+	}
+
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cCalendar, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cCalendar, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cCalendar, err)
+		}
+	}
+
 	return nil
 }
 
@@ -209,6 +628,7 @@ type Event struct {
 	Summary             *PropSummary
 	TimeTransparency    *PropTimeTransparency
 	URL                 *PropURL
+	Color               *PropColor
 	RecurrenceID        *PropRecurrenceID
 	RecurrenceRule      *PropRecurrenceRule
 	DateTimeEnd         *PropDateTimeEnd
@@ -223,7 +643,21 @@ type Event struct {
 	RelatedTo           []PropRelatedTo
 	Resources           []PropResources
 	RecurrenceDateTimes []PropRecurrenceDateTimes
+	Image               []PropImage
+	Conference          []PropConference
+	Link                []PropLink
+	RefID               []PropRefID
+	Concept             []PropConcept
+	StyledDescription   []PropStyledDescription
+	StructuredData      []PropStructuredData
+	Participant         []Participant
+	StructuredLocation  []Location
+	StructuredResource  []Resource
 	Alarm               []Alarm
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
 }
 
 func (s *Event) decode(t tokeniser) error {
@@ -241,9 +675,35 @@ Loop:
 		params := p.Data[1 : len(p.Data)-1]
 		value := p.Data[len(p.Data)-1].Data
 
+		checkProperty(t, p.Data[0].Data, params, value)
+
 		switch strings.ToUpper(p.Data[0].Data) {
 		case "BEGIN":
 			switch n := strings.ToUpper(value); n {
+			case "PARTICIPANT":
+				var e Participant
+
+				if err := e.decode(t); err != nil {
+					return fmt.Errorf(errDecodingProp, cEvent, cParticipant, err)
+				}
+
+				s.Participant = append(s.Participant, e)
+			case "VLOCATION":
+				var e Location
+
+				if err := e.decode(t); err != nil {
+					return fmt.Errorf(errDecodingProp, cEvent, cLocation, err)
+				}
+
+				s.StructuredLocation = append(s.StructuredLocation, e)
+			case "VRESOURCE":
+				var e Resource
+
+				if err := e.decode(t); err != nil {
+					return fmt.Errorf(errDecodingProp, cEvent, cResource, err)
+				}
+
+				s.StructuredResource = append(s.StructuredResource, e)
 			case "VALARM":
 				var e Alarm
 
@@ -253,13 +713,21 @@ Loop:
 
 				s.Alarm = append(s.Alarm, e)
 			default:
-				if err := decodeDummy(t, n); err != nil {
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
 					return fmt.Errorf(errDecodingType, cEvent, err)
 				}
+
+				s.Components = append(s.Components, c)
 			}
 		case "DTSTAMP":
 			if requiredDateTimeStamp {
-				return fmt.Errorf(errMultiple, cEvent, cDateTimeStamp)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cDateTimeStamp)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredDateTimeStamp = true
@@ -267,9 +735,15 @@ Loop:
 			if err := s.DateTimeStamp.decode(params, value); err != nil {
 				return fmt.Errorf(errDecodingProp, cEvent, cDateTimeStamp, err)
 			}
+
+			s.PropertyParams.decode("DTSTAMP", params)
 		case "UID":
 			if requiredUID {
-				return fmt.Errorf(errMultiple, cEvent, cUID)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cUID)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredUID = true
@@ -277,191 +751,407 @@ Loop:
 			if err := s.UID.decode(params, value); err != nil {
 				return fmt.Errorf(errDecodingProp, cEvent, cUID, err)
 			}
+
+			s.PropertyParams.decode("UID", params)
 		case "DTSTART":
 			if s.DateTimeStart != nil {
-				return fmt.Errorf(errMultiple, cEvent, cDateTimeStart)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cDateTimeStart)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.DateTimeStart = new(PropDateTimeStart)
 
 			if err := s.DateTimeStart.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cDateTimeStart, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cDateTimeStart, err)); err != nil {
+					return err
+				}
+
+				s.DateTimeStart = nil
 			}
 		case "CLASS":
 			if s.Class != nil {
-				return fmt.Errorf(errMultiple, cEvent, cClass)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cClass)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Class = new(PropClass)
 
 			if err := s.Class.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cClass, err)
-			}
-		case "CREATED":
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cClass, err)); err != nil {
+					return err
+				}
+
+				s.Class = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("CLASS", params)
+		case "CREATED":
 			if s.Created != nil {
-				return fmt.Errorf(errMultiple, cEvent, cCreated)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cCreated)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Created = new(PropCreated)
 
 			if err := s.Created.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cCreated, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cCreated, err)); err != nil {
+					return err
+				}
+
+				s.Created = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("CREATED", params)
 		case "DESCRIPTION":
 			if s.Description != nil {
-				return fmt.Errorf(errMultiple, cEvent, cDescription)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cDescription)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Description = new(PropDescription)
 
 			if err := s.Description.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cDescription, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cDescription, err)); err != nil {
+					return err
+				}
+
+				s.Description = nil
 			}
 		case "GEO":
 			if s.Geo != nil {
-				return fmt.Errorf(errMultiple, cEvent, cGeo)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cGeo)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Geo = new(PropGeo)
 
 			if err := s.Geo.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cGeo, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cGeo, err)); err != nil {
+					return err
+				}
+
+				s.Geo = nil
+
+				continue
 			}
-		case "LAST-MOD":
+
+			s.PropertyParams.decode("GEO", params)
+		case "LAST-MODIFIED":
 			if s.LastModified != nil {
-				return fmt.Errorf(errMultiple, cEvent, cLastModified)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cLastModified)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.LastModified = new(PropLastModified)
 
 			if err := s.LastModified.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cLastModified, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cLastModified, err)); err != nil {
+					return err
+				}
+
+				s.LastModified = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("LAST-MODIFIED", params)
 		case "LOCATION":
 			if s.Location != nil {
-				return fmt.Errorf(errMultiple, cEvent, cLocation)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cLocation)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Location = new(PropLocation)
 
 			if err := s.Location.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cLocation, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cLocation, err)); err != nil {
+					return err
+				}
+
+				s.Location = nil
 			}
 		case "ORGANIZER":
 			if s.Organizer != nil {
-				return fmt.Errorf(errMultiple, cEvent, cOrganizer)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cOrganizer)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Organizer = new(PropOrganizer)
 
 			if err := s.Organizer.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cOrganizer, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cOrganizer, err)); err != nil {
+					return err
+				}
+
+				s.Organizer = nil
 			}
 		case "PRIORITY":
 			if s.Priority != nil {
-				return fmt.Errorf(errMultiple, cEvent, cPriority)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cPriority)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Priority = new(PropPriority)
 
 			if err := s.Priority.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cPriority, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cPriority, err)); err != nil {
+					return err
+				}
+
+				s.Priority = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("PRIORITY", params)
 		case "SEQUENCE":
 			if s.Sequence != nil {
-				return fmt.Errorf(errMultiple, cEvent, cSequence)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cSequence)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Sequence = new(PropSequence)
 
 			if err := s.Sequence.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cSequence, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cSequence, err)); err != nil {
+					return err
+				}
+
+				s.Sequence = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("SEQUENCE", params)
 		case "STATUS":
 			if s.Status != nil {
-				return fmt.Errorf(errMultiple, cEvent, cStatus)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cStatus)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Status = new(PropStatus)
 
 			if err := s.Status.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cStatus, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cStatus, err)); err != nil {
+					return err
+				}
+
+				s.Status = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("STATUS", params)
 		case "SUMMARY":
 			if s.Summary != nil {
-				return fmt.Errorf(errMultiple, cEvent, cSummary)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cSummary)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Summary = new(PropSummary)
 
 			if err := s.Summary.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cSummary, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cSummary, err)); err != nil {
+					return err
+				}
+
+				s.Summary = nil
 			}
 		case "TRANSP":
 			if s.TimeTransparency != nil {
-				return fmt.Errorf(errMultiple, cEvent, cTimeTransparency)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cTimeTransparency)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.TimeTransparency = new(PropTimeTransparency)
 
 			if err := s.TimeTransparency.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cTimeTransparency, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cTimeTransparency, err)); err != nil {
+					return err
+				}
+
+				s.TimeTransparency = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("TRANSP", params)
 		case "URL":
 			if s.URL != nil {
-				return fmt.Errorf(errMultiple, cEvent, cURL)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cURL)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.URL = new(PropURL)
 
 			if err := s.URL.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cURL, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cURL, err)); err != nil {
+					return err
+				}
+
+				s.URL = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("URL", params)
+		case "COLOR":
+			if s.Color != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cColor)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Color = new(PropColor)
+
+			if err := s.Color.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cColor, err)); err != nil {
+					return err
+				}
+
+				s.Color = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("COLOR", params)
 		case "RECURRENCE-ID":
 			if s.RecurrenceID != nil {
-				return fmt.Errorf(errMultiple, cEvent, cRecurrenceID)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cRecurrenceID)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RecurrenceID = new(PropRecurrenceID)
 
 			if err := s.RecurrenceID.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cRecurrenceID, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cRecurrenceID, err)); err != nil {
+					return err
+				}
+
+				s.RecurrenceID = nil
 			}
 		case "RRULE":
 			if s.RecurrenceRule != nil {
-				return fmt.Errorf(errMultiple, cEvent, cRecurrenceRule)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cRecurrenceRule)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RecurrenceRule = new(PropRecurrenceRule)
 
 			if err := s.RecurrenceRule.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cRecurrenceRule, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cRecurrenceRule, err)); err != nil {
+					return err
+				}
+
+				s.RecurrenceRule = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("RRULE", params)
 		case "DTEND":
 			if s.DateTimeEnd != nil {
-				return fmt.Errorf(errMultiple, cEvent, cDateTimeEnd)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cDateTimeEnd)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.DateTimeEnd = new(PropDateTimeEnd)
 
 			if err := s.DateTimeEnd.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cDateTimeEnd, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cDateTimeEnd, err)); err != nil {
+					return err
+				}
+
+				s.DateTimeEnd = nil
 			}
 		case "DURATION":
 			if s.Duration != nil {
-				return fmt.Errorf(errMultiple, cEvent, cDuration)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cEvent, ErrDuplicateProperty, cDuration)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Duration = new(PropDuration)
 
 			if err := s.Duration.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cDuration, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cDuration, err)); err != nil {
+					return err
+				}
+
+				s.Duration = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("DURATION", params)
 		case "ATTACH":
 			var e PropAttachment
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cAttachment, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cAttachment, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Attachment = append(s.Attachment, e)
@@ -469,7 +1159,11 @@ Loop:
 			var e PropAttendee
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cAttendee, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cAttendee, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Attendee = append(s.Attendee, e)
@@ -477,7 +1171,11 @@ Loop:
 			var e PropCategories
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cCategories, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cCategories, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Categories = append(s.Categories, e)
@@ -485,7 +1183,11 @@ Loop:
 			var e PropComment
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cComment, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cComment, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Comment = append(s.Comment, e)
@@ -493,7 +1195,11 @@ Loop:
 			var e PropContact
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cContact, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cContact, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Contact = append(s.Contact, e)
@@ -501,7 +1207,11 @@ Loop:
 			var e PropExceptionDateTime
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cExceptionDateTime, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cExceptionDateTime, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.ExceptionDateTime = append(s.ExceptionDateTime, e)
@@ -509,15 +1219,25 @@ Loop:
 			var e PropRequestStatus
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cRequestStatus, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cRequestStatus, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RequestStatus = append(s.RequestStatus, e)
+
+			s.PropertyParams.decode("REQUEST-STATUS", params)
 		case "RELATED-TO":
 			var e PropRelatedTo
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cRelatedTo, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cRelatedTo, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RelatedTo = append(s.RelatedTo, e)
@@ -525,7 +1245,11 @@ Loop:
 			var e PropResources
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cResources, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cResources, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Resources = append(s.Resources, e)
@@ -533,16 +1257,110 @@ Loop:
 			var e PropRecurrenceDateTimes
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cEvent, cRecurrenceDateTimes, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cRecurrenceDateTimes, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RecurrenceDateTimes = append(s.RecurrenceDateTimes, e)
+		case "IMAGE":
+			var e PropImage
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cImage, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Image = append(s.Image, e)
+		case "CONFERENCE":
+			var e PropConference
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cConference, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Conference = append(s.Conference, e)
+		case "LINK":
+			var e PropLink
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cLink, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Link = append(s.Link, e)
+		case "REFID":
+			var e PropRefID
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cRefID, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.RefID = append(s.RefID, e)
+
+			s.PropertyParams.decode("REFID", params)
+		case "CONCEPT":
+			var e PropConcept
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cConcept, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Concept = append(s.Concept, e)
+
+			s.PropertyParams.decode("CONCEPT", params)
+		case "STYLED-DESCRIPTION":
+			var e PropStyledDescription
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cStyledDescription, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.StyledDescription = append(s.StyledDescription, e)
+		case "STRUCTURED-DATA":
+			var e PropStructuredData
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cEvent, cStructuredData, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.StructuredData = append(s.StructuredData, e)
 		case "END":
-			if value != "VEVENT" {
-				return fmt.Errorf(errDecodingType, cEvent, ErrInvalidEnd)
+			if err := endValue(t, value, "VEVENT"); err != nil {
+				return fmt.Errorf(errDecodingType, cEvent, err)
 			}
 
 			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
 		}
 	}
 
@@ -559,19 +1377,19 @@ Loop:
 
 func (s *Event) encode(w writer) {
 	w.WriteString("BEGIN:VEVENT\r\n")
-	s.DateTimeStamp.encode(w)
-	s.UID.encode(w)
+	s.DateTimeStamp.encode(s.PropertyParams.writer(w, "DTSTAMP"))
+	s.UID.encode(s.PropertyParams.writer(w, "UID"))
 
 	if s.DateTimeStart != nil {
 		s.DateTimeStart.encode(w)
 	}
 
 	if s.Class != nil {
-		s.Class.encode(w)
+		s.Class.encode(s.PropertyParams.writer(w, "CLASS"))
 	}
 
 	if s.Created != nil {
-		s.Created.encode(w)
+		s.Created.encode(s.PropertyParams.writer(w, "CREATED"))
 	}
 
 	if s.Description != nil {
@@ -579,11 +1397,11 @@ func (s *Event) encode(w writer) {
 	}
 
 	if s.Geo != nil {
-		s.Geo.encode(w)
+		s.Geo.encode(s.PropertyParams.writer(w, "GEO"))
 	}
 
 	if s.LastModified != nil {
-		s.LastModified.encode(w)
+		s.LastModified.encode(s.PropertyParams.writer(w, "LAST-MODIFIED"))
 	}
 
 	if s.Location != nil {
@@ -595,15 +1413,15 @@ func (s *Event) encode(w writer) {
 	}
 
 	if s.Priority != nil {
-		s.Priority.encode(w)
+		s.Priority.encode(s.PropertyParams.writer(w, "PRIORITY"))
 	}
 
 	if s.Sequence != nil {
-		s.Sequence.encode(w)
+		s.Sequence.encode(s.PropertyParams.writer(w, "SEQUENCE"))
 	}
 
 	if s.Status != nil {
-		s.Status.encode(w)
+		s.Status.encode(s.PropertyParams.writer(w, "STATUS"))
 	}
 
 	if s.Summary != nil {
@@ -611,11 +1429,15 @@ func (s *Event) encode(w writer) {
 	}
 
 	if s.TimeTransparency != nil {
-		s.TimeTransparency.encode(w)
+		s.TimeTransparency.encode(s.PropertyParams.writer(w, "TRANSP"))
 	}
 
 	if s.URL != nil {
-		s.URL.encode(w)
+		s.URL.encode(s.PropertyParams.writer(w, "URL"))
+	}
+
+	if s.Color != nil {
+		s.Color.encode(s.PropertyParams.writer(w, "COLOR"))
 	}
 
 	if s.RecurrenceID != nil {
@@ -623,7 +1445,7 @@ func (s *Event) encode(w writer) {
 	}
 
 	if s.RecurrenceRule != nil {
-		s.RecurrenceRule.encode(w)
+		s.RecurrenceRule.encode(s.PropertyParams.writer(w, "RRULE"))
 	}
 
 	if s.DateTimeEnd != nil {
@@ -631,7 +1453,7 @@ func (s *Event) encode(w writer) {
 	}
 
 	if s.Duration != nil {
-		s.Duration.encode(w)
+		s.Duration.encode(s.PropertyParams.writer(w, "DURATION"))
 	}
 
 	for n := range s.Attachment {
@@ -659,7 +1481,7 @@ func (s *Event) encode(w writer) {
 	}
 
 	for n := range s.RequestStatus {
-		s.RequestStatus[n].encode(w)
+		s.RequestStatus[n].encode(s.PropertyParams.writer(w, "REQUEST-STATUS"))
 	}
 
 	for n := range s.RelatedTo {
@@ -674,10 +1496,56 @@ func (s *Event) encode(w writer) {
 		s.RecurrenceDateTimes[n].encode(w)
 	}
 
+	for n := range s.Image {
+		s.Image[n].encode(w)
+	}
+
+	for n := range s.Conference {
+		s.Conference[n].encode(w)
+	}
+
+	for n := range s.Link {
+		s.Link[n].encode(w)
+	}
+
+	for n := range s.RefID {
+		s.RefID[n].encode(s.PropertyParams.writer(w, "REFID"))
+	}
+
+	for n := range s.Concept {
+		s.Concept[n].encode(s.PropertyParams.writer(w, "CONCEPT"))
+	}
+
+	for n := range s.StyledDescription {
+		s.StyledDescription[n].encode(w)
+	}
+
+	for n := range s.StructuredData {
+		s.StructuredData[n].encode(w)
+	}
+
+	s.Extensions.encode(w)
+
+	for n := range s.Participant {
+		s.Participant[n].encode(w)
+	}
+
+	for n := range s.StructuredLocation {
+		s.StructuredLocation[n].encode(w)
+	}
+
+	for n := range s.StructuredResource {
+		s.StructuredResource[n].encode(w)
+	}
+
 	for n := range s.Alarm {
 		s.Alarm[n].encode(w)
 	}
 
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
+
 	w.WriteString("END:VEVENT\r\n")
 }
 
@@ -774,6 +1642,12 @@ func (s *Event) valid() error {
 		}
 	}
 
+	if s.Color != nil {
+		if err := s.Color.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cEvent, cColor, err)
+		}
+	}
+
 	if s.RecurrenceID != nil {
 		if err := s.RecurrenceID.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cEvent, cRecurrenceID, err)
@@ -858,12 +1732,86 @@ func (s *Event) valid() error {
 		}
 	}
 
+	for n := range s.Image {
+		if err := s.Image[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cEvent, cImage, err)
+		}
+	}
+
+	for n := range s.Conference {
+		if err := s.Conference[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cEvent, cConference, err)
+		}
+	}
+
+	for n := range s.Link {
+		if err := s.Link[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cEvent, cLink, err)
+		}
+	}
+
+	for n := range s.RefID {
+		if err := s.RefID[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cEvent, cRefID, err)
+		}
+	}
+
+	for n := range s.Concept {
+		if err := s.Concept[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cEvent, cConcept, err)
+		}
+	}
+
+	for n := range s.StyledDescription {
+		if err := s.StyledDescription[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cEvent, cStyledDescription, err)
+		}
+	}
+
+	for n := range s.StructuredData {
+		if err := s.StructuredData[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cEvent, cStructuredData, err)
+		}
+	}
+
+	for n := range s.Participant {
+		if err := s.Participant[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cEvent, cParticipant, err)
+		}
+	}
+
+	for n := range s.StructuredLocation {
+		if err := s.StructuredLocation[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cEvent, cLocation, err)
+		}
+	}
+
+	for n := range s.StructuredResource {
+		if err := s.StructuredResource[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cEvent, cResource, err)
+		}
+	}
+
 	for n := range s.Alarm {
 		if err := s.Alarm[n].valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cEvent, cAlarm, err)
 		}
 	}
 
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cEvent, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cEvent, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cEvent, err)
+		}
+	}
+
 	return nil
 }
 
@@ -887,6 +1835,8 @@ type Todo struct {
 	Status              *PropStatus
 	Summary             *PropSummary
 	URL                 *PropURL
+	Color               *PropColor
+	RecurrenceRule      *PropRecurrenceRule
 	Due                 *PropDue
 	Duration            *PropDuration
 	Attachment          []PropAttachment
@@ -899,7 +1849,21 @@ type Todo struct {
 	RelatedTo           []PropRelatedTo
 	Resources           []PropResources
 	RecurrenceDateTimes []PropRecurrenceDateTimes
+	Image               []PropImage
+	Conference          []PropConference
+	Link                []PropLink
+	RefID               []PropRefID
+	Concept             []PropConcept
+	StyledDescription   []PropStyledDescription
+	StructuredData      []PropStructuredData
+	Participant         []Participant
+	StructuredLocation  []Location
+	StructuredResource  []Resource
 	Alarm               []Alarm
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
 }
 
 func (s *Todo) decode(t tokeniser) error {
@@ -917,9 +1881,35 @@ Loop:
 		params := p.Data[1 : len(p.Data)-1]
 		value := p.Data[len(p.Data)-1].Data
 
+		checkProperty(t, p.Data[0].Data, params, value)
+
 		switch strings.ToUpper(p.Data[0].Data) {
 		case "BEGIN":
 			switch n := strings.ToUpper(value); n {
+			case "PARTICIPANT":
+				var e Participant
+
+				if err := e.decode(t); err != nil {
+					return fmt.Errorf(errDecodingProp, cTodo, cParticipant, err)
+				}
+
+				s.Participant = append(s.Participant, e)
+			case "VLOCATION":
+				var e Location
+
+				if err := e.decode(t); err != nil {
+					return fmt.Errorf(errDecodingProp, cTodo, cLocation, err)
+				}
+
+				s.StructuredLocation = append(s.StructuredLocation, e)
+			case "VRESOURCE":
+				var e Resource
+
+				if err := e.decode(t); err != nil {
+					return fmt.Errorf(errDecodingProp, cTodo, cResource, err)
+				}
+
+				s.StructuredResource = append(s.StructuredResource, e)
 			case "VALARM":
 				var e Alarm
 
@@ -929,13 +1919,21 @@ Loop:
 
 				s.Alarm = append(s.Alarm, e)
 			default:
-				if err := decodeDummy(t, n); err != nil {
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
 					return fmt.Errorf(errDecodingType, cTodo, err)
 				}
+
+				s.Components = append(s.Components, c)
 			}
 		case "DTSTAMP":
 			if requiredDateTimeStamp {
-				return fmt.Errorf(errMultiple, cTodo, cDateTimeStamp)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cDateTimeStamp)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredDateTimeStamp = true
@@ -943,9 +1941,15 @@ Loop:
 			if err := s.DateTimeStamp.decode(params, value); err != nil {
 				return fmt.Errorf(errDecodingProp, cTodo, cDateTimeStamp, err)
 			}
+
+			s.PropertyParams.decode("DTSTAMP", params)
 		case "UID":
 			if requiredUID {
-				return fmt.Errorf(errMultiple, cTodo, cUID)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cUID)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredUID = true
@@ -953,191 +1957,429 @@ Loop:
 			if err := s.UID.decode(params, value); err != nil {
 				return fmt.Errorf(errDecodingProp, cTodo, cUID, err)
 			}
+
+			s.PropertyParams.decode("UID", params)
 		case "CLASS":
 			if s.Class != nil {
-				return fmt.Errorf(errMultiple, cTodo, cClass)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cClass)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Class = new(PropClass)
 
 			if err := s.Class.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cClass, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cClass, err)); err != nil {
+					return err
+				}
+
+				s.Class = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("CLASS", params)
 		case "COMPLETED":
 			if s.Completed != nil {
-				return fmt.Errorf(errMultiple, cTodo, cCompleted)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cCompleted)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Completed = new(PropCompleted)
 
 			if err := s.Completed.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cCompleted, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cCompleted, err)); err != nil {
+					return err
+				}
+
+				s.Completed = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("COMPLETED", params)
 		case "CREATED":
 			if s.Created != nil {
-				return fmt.Errorf(errMultiple, cTodo, cCreated)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cCreated)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Created = new(PropCreated)
 
 			if err := s.Created.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cCreated, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cCreated, err)); err != nil {
+					return err
+				}
+
+				s.Created = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("CREATED", params)
 		case "DESCRIPTION":
 			if s.Description != nil {
-				return fmt.Errorf(errMultiple, cTodo, cDescription)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cDescription)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Description = new(PropDescription)
 
 			if err := s.Description.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cDescription, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cDescription, err)); err != nil {
+					return err
+				}
+
+				s.Description = nil
 			}
 		case "DTSTART":
 			if s.DateTimeStart != nil {
-				return fmt.Errorf(errMultiple, cTodo, cDateTimeStart)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cDateTimeStart)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.DateTimeStart = new(PropDateTimeStart)
 
 			if err := s.DateTimeStart.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cDateTimeStart, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cDateTimeStart, err)); err != nil {
+					return err
+				}
+
+				s.DateTimeStart = nil
 			}
 		case "GEO":
 			if s.Geo != nil {
-				return fmt.Errorf(errMultiple, cTodo, cGeo)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cGeo)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Geo = new(PropGeo)
 
 			if err := s.Geo.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cGeo, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cGeo, err)); err != nil {
+					return err
+				}
+
+				s.Geo = nil
+
+				continue
 			}
-		case "LAST-MOD":
+
+			s.PropertyParams.decode("GEO", params)
+		case "LAST-MODIFIED":
 			if s.LastModified != nil {
-				return fmt.Errorf(errMultiple, cTodo, cLastModified)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cLastModified)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.LastModified = new(PropLastModified)
 
 			if err := s.LastModified.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cLastModified, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cLastModified, err)); err != nil {
+					return err
+				}
+
+				s.LastModified = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("LAST-MODIFIED", params)
 		case "LOCATION":
 			if s.Location != nil {
-				return fmt.Errorf(errMultiple, cTodo, cLocation)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cLocation)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Location = new(PropLocation)
 
 			if err := s.Location.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cLocation, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cLocation, err)); err != nil {
+					return err
+				}
+
+				s.Location = nil
 			}
 		case "ORGANIZER":
 			if s.Organizer != nil {
-				return fmt.Errorf(errMultiple, cTodo, cOrganizer)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cOrganizer)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Organizer = new(PropOrganizer)
 
 			if err := s.Organizer.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cOrganizer, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cOrganizer, err)); err != nil {
+					return err
+				}
+
+				s.Organizer = nil
 			}
 		case "PERCENT-COMPLETE":
 			if s.PercentComplete != nil {
-				return fmt.Errorf(errMultiple, cTodo, cPercentComplete)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cPercentComplete)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.PercentComplete = new(PropPercentComplete)
 
 			if err := s.PercentComplete.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cPercentComplete, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cPercentComplete, err)); err != nil {
+					return err
+				}
+
+				s.PercentComplete = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("PERCENT-COMPLETE", params)
 		case "PRIORITY":
 			if s.Priority != nil {
-				return fmt.Errorf(errMultiple, cTodo, cPriority)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cPriority)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Priority = new(PropPriority)
 
 			if err := s.Priority.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cPriority, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cPriority, err)); err != nil {
+					return err
+				}
+
+				s.Priority = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("PRIORITY", params)
 		case "RECURRENCE-ID":
 			if s.RecurrenceID != nil {
-				return fmt.Errorf(errMultiple, cTodo, cRecurrenceID)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cRecurrenceID)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RecurrenceID = new(PropRecurrenceID)
 
 			if err := s.RecurrenceID.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cRecurrenceID, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cRecurrenceID, err)); err != nil {
+					return err
+				}
+
+				s.RecurrenceID = nil
 			}
 		case "SEQUENCE":
 			if s.Sequence != nil {
-				return fmt.Errorf(errMultiple, cTodo, cSequence)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cSequence)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Sequence = new(PropSequence)
 
 			if err := s.Sequence.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cSequence, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cSequence, err)); err != nil {
+					return err
+				}
+
+				s.Sequence = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("SEQUENCE", params)
 		case "STATUS":
 			if s.Status != nil {
-				return fmt.Errorf(errMultiple, cTodo, cStatus)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cStatus)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Status = new(PropStatus)
 
 			if err := s.Status.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cStatus, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cStatus, err)); err != nil {
+					return err
+				}
+
+				s.Status = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("STATUS", params)
 		case "SUMMARY":
 			if s.Summary != nil {
-				return fmt.Errorf(errMultiple, cTodo, cSummary)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cSummary)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Summary = new(PropSummary)
 
 			if err := s.Summary.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cSummary, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cSummary, err)); err != nil {
+					return err
+				}
+
+				s.Summary = nil
 			}
 		case "URL":
 			if s.URL != nil {
-				return fmt.Errorf(errMultiple, cTodo, cURL)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cURL)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.URL = new(PropURL)
 
 			if err := s.URL.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cURL, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cURL, err)); err != nil {
+					return err
+				}
+
+				s.URL = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("URL", params)
+		case "COLOR":
+			if s.Color != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cColor)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Color = new(PropColor)
+
+			if err := s.Color.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cColor, err)); err != nil {
+					return err
+				}
+
+				s.Color = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("COLOR", params)
+		case "RRULE":
+			if s.RecurrenceRule != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cRecurrenceRule)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.RecurrenceRule = new(PropRecurrenceRule)
+
+			if err := s.RecurrenceRule.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cRecurrenceRule, err)); err != nil {
+					return err
+				}
+
+				s.RecurrenceRule = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("RRULE", params)
 		case "DUE":
 			if s.Due != nil {
-				return fmt.Errorf(errMultiple, cTodo, cDue)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cDue)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Due = new(PropDue)
 
 			if err := s.Due.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cDue, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cDue, err)); err != nil {
+					return err
+				}
+
+				s.Due = nil
 			}
 		case "DURATION":
 			if s.Duration != nil {
-				return fmt.Errorf(errMultiple, cTodo, cDuration)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTodo, ErrDuplicateProperty, cDuration)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Duration = new(PropDuration)
 
 			if err := s.Duration.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cDuration, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cDuration, err)); err != nil {
+					return err
+				}
+
+				s.Duration = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("DURATION", params)
 		case "ATTACH":
 			var e PropAttachment
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cAttachment, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cAttachment, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Attachment = append(s.Attachment, e)
@@ -1145,7 +2387,11 @@ Loop:
 			var e PropAttendee
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cAttendee, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cAttendee, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Attendee = append(s.Attendee, e)
@@ -1153,7 +2399,11 @@ Loop:
 			var e PropCategories
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cCategories, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cCategories, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Categories = append(s.Categories, e)
@@ -1161,7 +2411,11 @@ Loop:
 			var e PropComment
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cComment, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cComment, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Comment = append(s.Comment, e)
@@ -1169,7 +2423,11 @@ Loop:
 			var e PropContact
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cContact, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cContact, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Contact = append(s.Contact, e)
@@ -1177,7 +2435,11 @@ Loop:
 			var e PropExceptionDateTime
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cExceptionDateTime, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cExceptionDateTime, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.ExceptionDateTime = append(s.ExceptionDateTime, e)
@@ -1185,15 +2447,25 @@ Loop:
 			var e PropRequestStatus
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cRequestStatus, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cRequestStatus, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RequestStatus = append(s.RequestStatus, e)
+
+			s.PropertyParams.decode("REQUEST-STATUS", params)
 		case "RELATED-TO":
 			var e PropRelatedTo
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cRelatedTo, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cRelatedTo, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RelatedTo = append(s.RelatedTo, e)
@@ -1201,7 +2473,11 @@ Loop:
 			var e PropResources
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cResources, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cResources, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Resources = append(s.Resources, e)
@@ -1209,16 +2485,110 @@ Loop:
 			var e PropRecurrenceDateTimes
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTodo, cRecurrenceDateTimes, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cRecurrenceDateTimes, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RecurrenceDateTimes = append(s.RecurrenceDateTimes, e)
+		case "IMAGE":
+			var e PropImage
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cImage, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Image = append(s.Image, e)
+		case "CONFERENCE":
+			var e PropConference
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cConference, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Conference = append(s.Conference, e)
+		case "LINK":
+			var e PropLink
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cLink, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Link = append(s.Link, e)
+		case "REFID":
+			var e PropRefID
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cRefID, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.RefID = append(s.RefID, e)
+
+			s.PropertyParams.decode("REFID", params)
+		case "CONCEPT":
+			var e PropConcept
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cConcept, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Concept = append(s.Concept, e)
+
+			s.PropertyParams.decode("CONCEPT", params)
+		case "STYLED-DESCRIPTION":
+			var e PropStyledDescription
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cStyledDescription, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.StyledDescription = append(s.StyledDescription, e)
+		case "STRUCTURED-DATA":
+			var e PropStructuredData
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTodo, cStructuredData, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.StructuredData = append(s.StructuredData, e)
 		case "END":
-			if value != "VTODO" {
-				return fmt.Errorf(errDecodingType, cTodo, ErrInvalidEnd)
+			if err := endValue(t, value, "VTODO"); err != nil {
+				return fmt.Errorf(errDecodingType, cTodo, err)
 			}
 
 			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
 		}
 	}
 
@@ -1239,19 +2609,19 @@ Loop:
 
 func (s *Todo) encode(w writer) {
 	w.WriteString("BEGIN:VTODO\r\n")
-	s.DateTimeStamp.encode(w)
-	s.UID.encode(w)
+	s.DateTimeStamp.encode(s.PropertyParams.writer(w, "DTSTAMP"))
+	s.UID.encode(s.PropertyParams.writer(w, "UID"))
 
 	if s.Class != nil {
-		s.Class.encode(w)
+		s.Class.encode(s.PropertyParams.writer(w, "CLASS"))
 	}
 
 	if s.Completed != nil {
-		s.Completed.encode(w)
+		s.Completed.encode(s.PropertyParams.writer(w, "COMPLETED"))
 	}
 
 	if s.Created != nil {
-		s.Created.encode(w)
+		s.Created.encode(s.PropertyParams.writer(w, "CREATED"))
 	}
 
 	if s.Description != nil {
@@ -1263,11 +2633,11 @@ func (s *Todo) encode(w writer) {
 	}
 
 	if s.Geo != nil {
-		s.Geo.encode(w)
+		s.Geo.encode(s.PropertyParams.writer(w, "GEO"))
 	}
 
 	if s.LastModified != nil {
-		s.LastModified.encode(w)
+		s.LastModified.encode(s.PropertyParams.writer(w, "LAST-MODIFIED"))
 	}
 
 	if s.Location != nil {
@@ -1279,11 +2649,11 @@ func (s *Todo) encode(w writer) {
 	}
 
 	if s.PercentComplete != nil {
-		s.PercentComplete.encode(w)
+		s.PercentComplete.encode(s.PropertyParams.writer(w, "PERCENT-COMPLETE"))
 	}
 
 	if s.Priority != nil {
-		s.Priority.encode(w)
+		s.Priority.encode(s.PropertyParams.writer(w, "PRIORITY"))
 	}
 
 	if s.RecurrenceID != nil {
@@ -1291,11 +2661,11 @@ func (s *Todo) encode(w writer) {
 	}
 
 	if s.Sequence != nil {
-		s.Sequence.encode(w)
+		s.Sequence.encode(s.PropertyParams.writer(w, "SEQUENCE"))
 	}
 
 	if s.Status != nil {
-		s.Status.encode(w)
+		s.Status.encode(s.PropertyParams.writer(w, "STATUS"))
 	}
 
 	if s.Summary != nil {
@@ -1303,7 +2673,15 @@ func (s *Todo) encode(w writer) {
 	}
 
 	if s.URL != nil {
-		s.URL.encode(w)
+		s.URL.encode(s.PropertyParams.writer(w, "URL"))
+	}
+
+	if s.Color != nil {
+		s.Color.encode(s.PropertyParams.writer(w, "COLOR"))
+	}
+
+	if s.RecurrenceRule != nil {
+		s.RecurrenceRule.encode(s.PropertyParams.writer(w, "RRULE"))
 	}
 
 	if s.Due != nil {
@@ -1311,7 +2689,7 @@ func (s *Todo) encode(w writer) {
 	}
 
 	if s.Duration != nil {
-		s.Duration.encode(w)
+		s.Duration.encode(s.PropertyParams.writer(w, "DURATION"))
 	}
 
 	for n := range s.Attachment {
@@ -1339,7 +2717,7 @@ func (s *Todo) encode(w writer) {
 	}
 
 	for n := range s.RequestStatus {
-		s.RequestStatus[n].encode(w)
+		s.RequestStatus[n].encode(s.PropertyParams.writer(w, "REQUEST-STATUS"))
 	}
 
 	for n := range s.RelatedTo {
@@ -1354,10 +2732,56 @@ func (s *Todo) encode(w writer) {
 		s.RecurrenceDateTimes[n].encode(w)
 	}
 
+	for n := range s.Image {
+		s.Image[n].encode(w)
+	}
+
+	for n := range s.Conference {
+		s.Conference[n].encode(w)
+	}
+
+	for n := range s.Link {
+		s.Link[n].encode(w)
+	}
+
+	for n := range s.RefID {
+		s.RefID[n].encode(s.PropertyParams.writer(w, "REFID"))
+	}
+
+	for n := range s.Concept {
+		s.Concept[n].encode(s.PropertyParams.writer(w, "CONCEPT"))
+	}
+
+	for n := range s.StyledDescription {
+		s.StyledDescription[n].encode(w)
+	}
+
+	for n := range s.StructuredData {
+		s.StructuredData[n].encode(w)
+	}
+
+	s.Extensions.encode(w)
+
+	for n := range s.Participant {
+		s.Participant[n].encode(w)
+	}
+
+	for n := range s.StructuredLocation {
+		s.StructuredLocation[n].encode(w)
+	}
+
+	for n := range s.StructuredResource {
+		s.StructuredResource[n].encode(w)
+	}
+
 	for n := range s.Alarm {
 		s.Alarm[n].encode(w)
 	}
 
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
+
 	w.WriteString("END:VTODO\r\n")
 }
 
@@ -1466,6 +2890,18 @@ func (s *Todo) valid() error {
 		}
 	}
 
+	if s.Color != nil {
+		if err := s.Color.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cTodo, cColor, err)
+		}
+	}
+
+	if s.RecurrenceRule != nil {
+		if err := s.RecurrenceRule.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cTodo, cRecurrenceRule, err)
+		}
+	}
+
 	if s.Due != nil {
 		if err := s.Due.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cTodo, cDue, err)
@@ -1538,12 +2974,86 @@ func (s *Todo) valid() error {
 		}
 	}
 
+	for n := range s.Image {
+		if err := s.Image[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cTodo, cImage, err)
+		}
+	}
+
+	for n := range s.Conference {
+		if err := s.Conference[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cTodo, cConference, err)
+		}
+	}
+
+	for n := range s.Link {
+		if err := s.Link[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cTodo, cLink, err)
+		}
+	}
+
+	for n := range s.RefID {
+		if err := s.RefID[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cTodo, cRefID, err)
+		}
+	}
+
+	for n := range s.Concept {
+		if err := s.Concept[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cTodo, cConcept, err)
+		}
+	}
+
+	for n := range s.StyledDescription {
+		if err := s.StyledDescription[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cTodo, cStyledDescription, err)
+		}
+	}
+
+	for n := range s.StructuredData {
+		if err := s.StructuredData[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cTodo, cStructuredData, err)
+		}
+	}
+
+	for n := range s.Participant {
+		if err := s.Participant[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cTodo, cParticipant, err)
+		}
+	}
+
+	for n := range s.StructuredLocation {
+		if err := s.StructuredLocation[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cTodo, cLocation, err)
+		}
+	}
+
+	for n := range s.StructuredResource {
+		if err := s.StructuredResource[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cTodo, cResource, err)
+		}
+	}
+
 	for n := range s.Alarm {
 		if err := s.Alarm[n].valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cTodo, cAlarm, err)
 		}
 	}
 
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cTodo, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cTodo, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cTodo, err)
+		}
+	}
+
 	return nil
 }
 
@@ -1561,6 +3071,7 @@ type Journal struct {
 	Status              *PropStatus
 	Summary             *PropSummary
 	URL                 *PropURL
+	Color               *PropColor
 	RecurrenceRule      *PropRecurrenceRule
 	Attachment          []PropAttachment
 	Attendee            []PropAttendee
@@ -1573,6 +3084,14 @@ type Journal struct {
 	RelatedTo           []PropRelatedTo
 	Resources           []PropResources
 	RecurrenceDateTimes []PropRecurrenceDateTimes
+	Image               []PropImage
+	Link                []PropLink
+	RefID               []PropRefID
+	Concept             []PropConcept
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
 }
 
 func (s *Journal) decode(t tokeniser) error {
@@ -1590,17 +3109,27 @@ Loop:
 		params := p.Data[1 : len(p.Data)-1]
 		value := p.Data[len(p.Data)-1].Data
 
+		checkProperty(t, p.Data[0].Data, params, value)
+
 		switch strings.ToUpper(p.Data[0].Data) {
 		case "BEGIN":
 			switch n := strings.ToUpper(value); n {
 			default:
-				if err := decodeDummy(t, n); err != nil {
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
 					return fmt.Errorf(errDecodingType, cJournal, err)
 				}
+
+				s.Components = append(s.Components, c)
 			}
 		case "DTSTAMP":
 			if requiredDateTimeStamp {
-				return fmt.Errorf(errMultiple, cJournal, cDateTimeStamp)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cJournal, ErrDuplicateProperty, cDateTimeStamp)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredDateTimeStamp = true
@@ -1608,9 +3137,15 @@ Loop:
 			if err := s.DateTimeStamp.decode(params, value); err != nil {
 				return fmt.Errorf(errDecodingProp, cJournal, cDateTimeStamp, err)
 			}
+
+			s.PropertyParams.decode("DTSTAMP", params)
 		case "UID":
 			if requiredUID {
-				return fmt.Errorf(errMultiple, cJournal, cUID)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cJournal, ErrDuplicateProperty, cUID)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredUID = true
@@ -1618,121 +3153,265 @@ Loop:
 			if err := s.UID.decode(params, value); err != nil {
 				return fmt.Errorf(errDecodingProp, cJournal, cUID, err)
 			}
+
+			s.PropertyParams.decode("UID", params)
 		case "CLASS":
 			if s.Class != nil {
-				return fmt.Errorf(errMultiple, cJournal, cClass)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cJournal, ErrDuplicateProperty, cClass)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Class = new(PropClass)
 
 			if err := s.Class.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cClass, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cClass, err)); err != nil {
+					return err
+				}
+
+				s.Class = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("CLASS", params)
 		case "CREATED":
 			if s.Created != nil {
-				return fmt.Errorf(errMultiple, cJournal, cCreated)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cJournal, ErrDuplicateProperty, cCreated)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Created = new(PropCreated)
 
 			if err := s.Created.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cCreated, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cCreated, err)); err != nil {
+					return err
+				}
+
+				s.Created = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("CREATED", params)
 		case "DTSTART":
 			if s.DateTimeStart != nil {
-				return fmt.Errorf(errMultiple, cJournal, cDateTimeStart)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cJournal, ErrDuplicateProperty, cDateTimeStart)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.DateTimeStart = new(PropDateTimeStart)
 
 			if err := s.DateTimeStart.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cDateTimeStart, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cDateTimeStart, err)); err != nil {
+					return err
+				}
+
+				s.DateTimeStart = nil
 			}
-		case "LAST-MOD":
+		case "LAST-MODIFIED":
 			if s.LastModified != nil {
-				return fmt.Errorf(errMultiple, cJournal, cLastModified)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cJournal, ErrDuplicateProperty, cLastModified)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.LastModified = new(PropLastModified)
 
 			if err := s.LastModified.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cLastModified, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cLastModified, err)); err != nil {
+					return err
+				}
+
+				s.LastModified = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("LAST-MODIFIED", params)
 		case "ORGANIZER":
 			if s.Organizer != nil {
-				return fmt.Errorf(errMultiple, cJournal, cOrganizer)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cJournal, ErrDuplicateProperty, cOrganizer)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Organizer = new(PropOrganizer)
 
 			if err := s.Organizer.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cOrganizer, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cOrganizer, err)); err != nil {
+					return err
+				}
+
+				s.Organizer = nil
 			}
 		case "RECURRENCE-ID":
 			if s.RecurrenceID != nil {
-				return fmt.Errorf(errMultiple, cJournal, cRecurrenceID)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cJournal, ErrDuplicateProperty, cRecurrenceID)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RecurrenceID = new(PropRecurrenceID)
 
 			if err := s.RecurrenceID.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cRecurrenceID, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cRecurrenceID, err)); err != nil {
+					return err
+				}
+
+				s.RecurrenceID = nil
 			}
 		case "SEQUENCE":
 			if s.Sequence != nil {
-				return fmt.Errorf(errMultiple, cJournal, cSequence)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cJournal, ErrDuplicateProperty, cSequence)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Sequence = new(PropSequence)
 
 			if err := s.Sequence.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cSequence, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cSequence, err)); err != nil {
+					return err
+				}
+
+				s.Sequence = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("SEQUENCE", params)
 		case "STATUS":
 			if s.Status != nil {
-				return fmt.Errorf(errMultiple, cJournal, cStatus)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cJournal, ErrDuplicateProperty, cStatus)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Status = new(PropStatus)
 
 			if err := s.Status.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cStatus, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cStatus, err)); err != nil {
+					return err
+				}
+
+				s.Status = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("STATUS", params)
 		case "SUMMARY":
 			if s.Summary != nil {
-				return fmt.Errorf(errMultiple, cJournal, cSummary)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cJournal, ErrDuplicateProperty, cSummary)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Summary = new(PropSummary)
 
 			if err := s.Summary.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cSummary, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cSummary, err)); err != nil {
+					return err
+				}
+
+				s.Summary = nil
 			}
 		case "URL":
 			if s.URL != nil {
-				return fmt.Errorf(errMultiple, cJournal, cURL)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cJournal, ErrDuplicateProperty, cURL)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.URL = new(PropURL)
 
 			if err := s.URL.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cURL, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cURL, err)); err != nil {
+					return err
+				}
+
+				s.URL = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("URL", params)
+		case "COLOR":
+			if s.Color != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cJournal, ErrDuplicateProperty, cColor)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Color = new(PropColor)
+
+			if err := s.Color.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cColor, err)); err != nil {
+					return err
+				}
+
+				s.Color = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("COLOR", params)
 		case "RRULE":
 			if s.RecurrenceRule != nil {
-				return fmt.Errorf(errMultiple, cJournal, cRecurrenceRule)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cJournal, ErrDuplicateProperty, cRecurrenceRule)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RecurrenceRule = new(PropRecurrenceRule)
 
 			if err := s.RecurrenceRule.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cRecurrenceRule, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cRecurrenceRule, err)); err != nil {
+					return err
+				}
+
+				s.RecurrenceRule = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("RRULE", params)
 		case "ATTACH":
 			var e PropAttachment
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cAttachment, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cAttachment, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Attachment = append(s.Attachment, e)
@@ -1740,7 +3419,11 @@ Loop:
 			var e PropAttendee
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cAttendee, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cAttendee, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Attendee = append(s.Attendee, e)
@@ -1748,7 +3431,11 @@ Loop:
 			var e PropCategories
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cCategories, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cCategories, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Categories = append(s.Categories, e)
@@ -1756,7 +3443,11 @@ Loop:
 			var e PropComment
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cComment, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cComment, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Comment = append(s.Comment, e)
@@ -1764,7 +3455,11 @@ Loop:
 			var e PropContact
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cContact, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cContact, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Contact = append(s.Contact, e)
@@ -1772,7 +3467,11 @@ Loop:
 			var e PropDescription
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cDescription, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cDescription, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Description = append(s.Description, e)
@@ -1780,7 +3479,11 @@ Loop:
 			var e PropExceptionDateTime
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cExceptionDateTime, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cExceptionDateTime, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.ExceptionDateTime = append(s.ExceptionDateTime, e)
@@ -1788,15 +3491,25 @@ Loop:
 			var e PropRequestStatus
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cRequestStatus, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cRequestStatus, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RequestStatus = append(s.RequestStatus, e)
+
+			s.PropertyParams.decode("REQUEST-STATUS", params)
 		case "RELATED-TO":
 			var e PropRelatedTo
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cRelatedTo, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cRelatedTo, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RelatedTo = append(s.RelatedTo, e)
@@ -1804,7 +3517,11 @@ Loop:
 			var e PropResources
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cResources, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cResources, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Resources = append(s.Resources, e)
@@ -1812,16 +3529,74 @@ Loop:
 			var e PropRecurrenceDateTimes
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cJournal, cRecurrenceDateTimes, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cRecurrenceDateTimes, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RecurrenceDateTimes = append(s.RecurrenceDateTimes, e)
+		case "IMAGE":
+			var e PropImage
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cImage, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Image = append(s.Image, e)
+		case "LINK":
+			var e PropLink
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cLink, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Link = append(s.Link, e)
+		case "REFID":
+			var e PropRefID
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cRefID, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.RefID = append(s.RefID, e)
+
+			s.PropertyParams.decode("REFID", params)
+		case "CONCEPT":
+			var e PropConcept
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cJournal, cConcept, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Concept = append(s.Concept, e)
+
+			s.PropertyParams.decode("CONCEPT", params)
 		case "END":
-			if value != "VJOURNAL" {
-				return fmt.Errorf(errDecodingType, cJournal, ErrInvalidEnd)
+			if err := endValue(t, value, "VJOURNAL"); err != nil {
+				return fmt.Errorf(errDecodingType, cJournal, err)
 			}
 
 			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
 		}
 	}
 
@@ -1834,15 +3609,15 @@ Loop:
 
 func (s *Journal) encode(w writer) {
 	w.WriteString("BEGIN:VJOURNAL\r\n")
-	s.DateTimeStamp.encode(w)
-	s.UID.encode(w)
+	s.DateTimeStamp.encode(s.PropertyParams.writer(w, "DTSTAMP"))
+	s.UID.encode(s.PropertyParams.writer(w, "UID"))
 
 	if s.Class != nil {
-		s.Class.encode(w)
+		s.Class.encode(s.PropertyParams.writer(w, "CLASS"))
 	}
 
 	if s.Created != nil {
-		s.Created.encode(w)
+		s.Created.encode(s.PropertyParams.writer(w, "CREATED"))
 	}
 
 	if s.DateTimeStart != nil {
@@ -1850,7 +3625,7 @@ func (s *Journal) encode(w writer) {
 	}
 
 	if s.LastModified != nil {
-		s.LastModified.encode(w)
+		s.LastModified.encode(s.PropertyParams.writer(w, "LAST-MODIFIED"))
 	}
 
 	if s.Organizer != nil {
@@ -1862,11 +3637,11 @@ func (s *Journal) encode(w writer) {
 	}
 
 	if s.Sequence != nil {
-		s.Sequence.encode(w)
+		s.Sequence.encode(s.PropertyParams.writer(w, "SEQUENCE"))
 	}
 
 	if s.Status != nil {
-		s.Status.encode(w)
+		s.Status.encode(s.PropertyParams.writer(w, "STATUS"))
 	}
 
 	if s.Summary != nil {
@@ -1874,11 +3649,15 @@ func (s *Journal) encode(w writer) {
 	}
 
 	if s.URL != nil {
-		s.URL.encode(w)
+		s.URL.encode(s.PropertyParams.writer(w, "URL"))
+	}
+
+	if s.Color != nil {
+		s.Color.encode(s.PropertyParams.writer(w, "COLOR"))
 	}
 
 	if s.RecurrenceRule != nil {
-		s.RecurrenceRule.encode(w)
+		s.RecurrenceRule.encode(s.PropertyParams.writer(w, "RRULE"))
 	}
 
 	for n := range s.Attachment {
@@ -1910,7 +3689,7 @@ func (s *Journal) encode(w writer) {
 	}
 
 	for n := range s.RequestStatus {
-		s.RequestStatus[n].encode(w)
+		s.RequestStatus[n].encode(s.PropertyParams.writer(w, "REQUEST-STATUS"))
 	}
 
 	for n := range s.RelatedTo {
@@ -1925,6 +3704,28 @@ func (s *Journal) encode(w writer) {
 		s.RecurrenceDateTimes[n].encode(w)
 	}
 
+	for n := range s.Image {
+		s.Image[n].encode(w)
+	}
+
+	for n := range s.Link {
+		s.Link[n].encode(w)
+	}
+
+	for n := range s.RefID {
+		s.RefID[n].encode(s.PropertyParams.writer(w, "REFID"))
+	}
+
+	for n := range s.Concept {
+		s.Concept[n].encode(s.PropertyParams.writer(w, "CONCEPT"))
+	}
+
+	s.Extensions.encode(w)
+
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
+
 	w.WriteString("END:VJOURNAL\r\n")
 }
 
@@ -1997,6 +3798,12 @@ func (s *Journal) valid() error {
 		}
 	}
 
+	if s.Color != nil {
+		if err := s.Color.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cJournal, cColor, err)
+		}
+	}
+
 	if s.RecurrenceRule != nil {
 		if err := s.RecurrenceRule.valid(); err != nil {
 			return fmt.Errorf(errValidatingProp, cJournal, cRecurrenceRule, err)
@@ -2069,6 +3876,44 @@ func (s *Journal) valid() error {
 		}
 	}
 
+	for n := range s.Image {
+		if err := s.Image[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cJournal, cImage, err)
+		}
+	}
+
+	for n := range s.Link {
+		if err := s.Link[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cJournal, cLink, err)
+		}
+	}
+
+	for n := range s.RefID {
+		if err := s.RefID[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cJournal, cRefID, err)
+		}
+	}
+
+	for n := range s.Concept {
+		if err := s.Concept[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cJournal, cConcept, err)
+		}
+	}
+
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cJournal, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cJournal, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cJournal, err)
+		}
+	}
+
 	return nil
 }
 
@@ -2087,6 +3932,10 @@ type FreeBusy struct {
 	Comment       []PropComment
 	FreeBusy      []PropFreeBusy
 	RequestStatus []PropRequestStatus
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
 }
 
 func (s *FreeBusy) decode(t tokeniser) error {
@@ -2104,17 +3953,27 @@ Loop:
 		params := p.Data[1 : len(p.Data)-1]
 		value := p.Data[len(p.Data)-1].Data
 
+		checkProperty(t, p.Data[0].Data, params, value)
+
 		switch strings.ToUpper(p.Data[0].Data) {
 		case "BEGIN":
 			switch n := strings.ToUpper(value); n {
 			default:
-				if err := decodeDummy(t, n); err != nil {
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
 					return fmt.Errorf(errDecodingType, cFreeBusy, err)
 				}
+
+				s.Components = append(s.Components, c)
 			}
 		case "DTSTAMP":
 			if requiredDateTimeStamp {
-				return fmt.Errorf(errMultiple, cFreeBusy, cDateTimeStamp)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cFreeBusy, ErrDuplicateProperty, cDateTimeStamp)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredDateTimeStamp = true
@@ -2122,9 +3981,15 @@ Loop:
 			if err := s.DateTimeStamp.decode(params, value); err != nil {
 				return fmt.Errorf(errDecodingProp, cFreeBusy, cDateTimeStamp, err)
 			}
+
+			s.PropertyParams.decode("DTSTAMP", params)
 		case "UID":
 			if requiredUID {
-				return fmt.Errorf(errMultiple, cFreeBusy, cUID)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cFreeBusy, ErrDuplicateProperty, cUID)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredUID = true
@@ -2132,61 +3997,111 @@ Loop:
 			if err := s.UID.decode(params, value); err != nil {
 				return fmt.Errorf(errDecodingProp, cFreeBusy, cUID, err)
 			}
+
+			s.PropertyParams.decode("UID", params)
 		case "CONTACT":
 			if s.Contact != nil {
-				return fmt.Errorf(errMultiple, cFreeBusy, cContact)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cFreeBusy, ErrDuplicateProperty, cContact)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Contact = new(PropContact)
 
 			if err := s.Contact.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cFreeBusy, cContact, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cFreeBusy, cContact, err)); err != nil {
+					return err
+				}
+
+				s.Contact = nil
 			}
 		case "DTSTART":
 			if s.DateTimeStart != nil {
-				return fmt.Errorf(errMultiple, cFreeBusy, cDateTimeStart)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cFreeBusy, ErrDuplicateProperty, cDateTimeStart)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.DateTimeStart = new(PropDateTimeStart)
 
 			if err := s.DateTimeStart.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cFreeBusy, cDateTimeStart, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cFreeBusy, cDateTimeStart, err)); err != nil {
+					return err
+				}
+
+				s.DateTimeStart = nil
 			}
 		case "DTEND":
 			if s.DateTimeEnd != nil {
-				return fmt.Errorf(errMultiple, cFreeBusy, cDateTimeEnd)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cFreeBusy, ErrDuplicateProperty, cDateTimeEnd)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.DateTimeEnd = new(PropDateTimeEnd)
 
 			if err := s.DateTimeEnd.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cFreeBusy, cDateTimeEnd, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cFreeBusy, cDateTimeEnd, err)); err != nil {
+					return err
+				}
+
+				s.DateTimeEnd = nil
 			}
 		case "ORGANIZER":
 			if s.Organizer != nil {
-				return fmt.Errorf(errMultiple, cFreeBusy, cOrganizer)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cFreeBusy, ErrDuplicateProperty, cOrganizer)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Organizer = new(PropOrganizer)
 
 			if err := s.Organizer.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cFreeBusy, cOrganizer, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cFreeBusy, cOrganizer, err)); err != nil {
+					return err
+				}
+
+				s.Organizer = nil
 			}
 		case "URL":
 			if s.URL != nil {
-				return fmt.Errorf(errMultiple, cFreeBusy, cURL)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cFreeBusy, ErrDuplicateProperty, cURL)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.URL = new(PropURL)
 
 			if err := s.URL.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cFreeBusy, cURL, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cFreeBusy, cURL, err)); err != nil {
+					return err
+				}
+
+				s.URL = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("URL", params)
 		case "ATTENDEE":
 			var e PropAttendee
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cFreeBusy, cAttendee, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cFreeBusy, cAttendee, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Attendee = append(s.Attendee, e)
@@ -2194,7 +4109,11 @@ Loop:
 			var e PropComment
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cFreeBusy, cComment, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cFreeBusy, cComment, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Comment = append(s.Comment, e)
@@ -2202,7 +4121,11 @@ Loop:
 			var e PropFreeBusy
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cFreeBusy, cFreeBusy, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cFreeBusy, cFreeBusy, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.FreeBusy = append(s.FreeBusy, e)
@@ -2210,16 +4133,24 @@ Loop:
 			var e PropRequestStatus
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cFreeBusy, cRequestStatus, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cFreeBusy, cRequestStatus, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RequestStatus = append(s.RequestStatus, e)
+
+			s.PropertyParams.decode("REQUEST-STATUS", params)
 		case "END":
-			if value != "VFREEBUSY" {
-				return fmt.Errorf(errDecodingType, cFreeBusy, ErrInvalidEnd)
+			if err := endValue(t, value, "VFREEBUSY"); err != nil {
+				return fmt.Errorf(errDecodingType, cFreeBusy, err)
 			}
 
 			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
 		}
 	}
 
@@ -2232,8 +4163,8 @@ Loop:
 
 func (s *FreeBusy) encode(w writer) {
 	w.WriteString("BEGIN:VFREEBUSY\r\n")
-	s.DateTimeStamp.encode(w)
-	s.UID.encode(w)
+	s.DateTimeStamp.encode(s.PropertyParams.writer(w, "DTSTAMP"))
+	s.UID.encode(s.PropertyParams.writer(w, "UID"))
 
 	if s.Contact != nil {
 		s.Contact.encode(w)
@@ -2252,7 +4183,7 @@ func (s *FreeBusy) encode(w writer) {
 	}
 
 	if s.URL != nil {
-		s.URL.encode(w)
+		s.URL.encode(s.PropertyParams.writer(w, "URL"))
 	}
 
 	for n := range s.Attendee {
@@ -2268,7 +4199,13 @@ func (s *FreeBusy) encode(w writer) {
 	}
 
 	for n := range s.RequestStatus {
-		s.RequestStatus[n].encode(w)
+		s.RequestStatus[n].encode(s.PropertyParams.writer(w, "REQUEST-STATUS"))
+	}
+
+	s.Extensions.encode(w)
+
+	for n := range s.Components {
+		s.Components[n].encode(w)
 	}
 
 	w.WriteString("END:VFREEBUSY\r\n")
@@ -2337,6 +4274,20 @@ func (s *FreeBusy) valid() error {
 		}
 	}
 
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cFreeBusy, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cFreeBusy, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cFreeBusy, err)
+		}
+	}
+
 	return nil
 }
 
@@ -2347,6 +4298,10 @@ type Timezone struct {
 	TimezoneURL  *PropTimezoneURL
 	Standard     []Standard
 	Daylight     []Daylight
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
 }
 
 func (s *Timezone) decode(t tokeniser) error {
@@ -2364,6 +4319,8 @@ Loop:
 		params := p.Data[1 : len(p.Data)-1]
 		value := p.Data[len(p.Data)-1].Data
 
+		checkProperty(t, p.Data[0].Data, params, value)
+
 		switch strings.ToUpper(p.Data[0].Data) {
 		case "BEGIN":
 			switch n := strings.ToUpper(value); n {
@@ -2384,13 +4341,21 @@ Loop:
 
 				s.Daylight = append(s.Daylight, e)
 			default:
-				if err := decodeDummy(t, n); err != nil {
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
 					return fmt.Errorf(errDecodingType, cTimezone, err)
 				}
+
+				s.Components = append(s.Components, c)
 			}
 		case "TZID":
 			if requiredTimezoneID {
-				return fmt.Errorf(errMultiple, cTimezone, cTimezoneID)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTimezone, ErrDuplicateProperty, cTimezoneID)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredTimezoneID = true
@@ -2398,32 +4363,60 @@ Loop:
 			if err := s.TimezoneID.decode(params, value); err != nil {
 				return fmt.Errorf(errDecodingProp, cTimezone, cTimezoneID, err)
 			}
-		case "LAST-MOD":
+
+			s.PropertyParams.decode("TZID", params)
+		case "LAST-MODIFIED":
 			if s.LastModified != nil {
-				return fmt.Errorf(errMultiple, cTimezone, cLastModified)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTimezone, ErrDuplicateProperty, cLastModified)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.LastModified = new(PropLastModified)
 
 			if err := s.LastModified.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTimezone, cLastModified, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTimezone, cLastModified, err)); err != nil {
+					return err
+				}
+
+				s.LastModified = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("LAST-MODIFIED", params)
 		case "TZURL":
 			if s.TimezoneURL != nil {
-				return fmt.Errorf(errMultiple, cTimezone, cTimezoneURL)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cTimezone, ErrDuplicateProperty, cTimezoneURL)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.TimezoneURL = new(PropTimezoneURL)
 
 			if err := s.TimezoneURL.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cTimezone, cTimezoneURL, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cTimezone, cTimezoneURL, err)); err != nil {
+					return err
+				}
+
+				s.TimezoneURL = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("TZURL", params)
 		case "END":
-			if value != "VTIMEZONE" {
-				return fmt.Errorf(errDecodingType, cTimezone, ErrInvalidEnd)
+			if err := endValue(t, value, "VTIMEZONE"); err != nil {
+				return fmt.Errorf(errDecodingType, cTimezone, err)
 			}
 
 			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
 		}
 	}
 
@@ -2440,16 +4433,18 @@ Loop:
 
 func (s *Timezone) encode(w writer) {
 	w.WriteString("BEGIN:VTIMEZONE\r\n")
-	s.TimezoneID.encode(w)
+	s.TimezoneID.encode(s.PropertyParams.writer(w, "TZID"))
 
 	if s.LastModified != nil {
-		s.LastModified.encode(w)
+		s.LastModified.encode(s.PropertyParams.writer(w, "LAST-MODIFIED"))
 	}
 
 	if s.TimezoneURL != nil {
-		s.TimezoneURL.encode(w)
+		s.TimezoneURL.encode(s.PropertyParams.writer(w, "TZURL"))
 	}
 
+	s.Extensions.encode(w)
+
 	for n := range s.Standard {
 		s.Standard[n].encode(w)
 	}
@@ -2458,6 +4453,10 @@ func (s *Timezone) encode(w writer) {
 		s.Daylight[n].encode(w)
 	}
 
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
+
 	w.WriteString("END:VTIMEZONE\r\n")
 }
 
@@ -2490,6 +4489,20 @@ func (s *Timezone) valid() error {
 		}
 	}
 
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cTimezone, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cTimezone, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cTimezone, err)
+		}
+	}
+
 	return nil
 }
 
@@ -2502,6 +4515,10 @@ type Standard struct {
 	Comment             []PropComment
 	RecurrenceDateTimes []PropRecurrenceDateTimes
 	TimezoneName        []PropTimezoneName
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
 }
 
 func (s *Standard) decode(t tokeniser) error {
@@ -2519,17 +4536,27 @@ Loop:
 		params := p.Data[1 : len(p.Data)-1]
 		value := p.Data[len(p.Data)-1].Data
 
+		checkProperty(t, p.Data[0].Data, params, value)
+
 		switch strings.ToUpper(p.Data[0].Data) {
 		case "BEGIN":
 			switch n := strings.ToUpper(value); n {
 			default:
-				if err := decodeDummy(t, n); err != nil {
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
 					return fmt.Errorf(errDecodingType, cStandard, err)
 				}
+
+				s.Components = append(s.Components, c)
 			}
 		case "DTSTART":
 			if requiredDateTimeStart {
-				return fmt.Errorf(errMultiple, cStandard, cDateTimeStart)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cStandard, ErrDuplicateProperty, cDateTimeStart)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredDateTimeStart = true
@@ -2539,7 +4566,11 @@ Loop:
 			}
 		case "TZOFFSETTO":
 			if requiredTimezoneOffsetTo {
-				return fmt.Errorf(errMultiple, cStandard, cTimezoneOffsetTo)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cStandard, ErrDuplicateProperty, cTimezoneOffsetTo)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredTimezoneOffsetTo = true
@@ -2547,9 +4578,15 @@ Loop:
 			if err := s.TimezoneOffsetTo.decode(params, value); err != nil {
 				return fmt.Errorf(errDecodingProp, cStandard, cTimezoneOffsetTo, err)
 			}
+
+			s.PropertyParams.decode("TZOFFSETTO", params)
 		case "TZOFFSETFROM":
 			if requiredTimezoneOffsetFrom {
-				return fmt.Errorf(errMultiple, cStandard, cTimezoneOffsetFrom)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cStandard, ErrDuplicateProperty, cTimezoneOffsetFrom)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredTimezoneOffsetFrom = true
@@ -2557,294 +4594,2923 @@ Loop:
 			if err := s.TimezoneOffsetFrom.decode(params, value); err != nil {
 				return fmt.Errorf(errDecodingProp, cStandard, cTimezoneOffsetFrom, err)
 			}
+
+			s.PropertyParams.decode("TZOFFSETFROM", params)
 		case "RRULE":
 			if s.RecurrenceRule != nil {
-				return fmt.Errorf(errMultiple, cStandard, cRecurrenceRule)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cStandard, ErrDuplicateProperty, cRecurrenceRule)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RecurrenceRule = new(PropRecurrenceRule)
 
 			if err := s.RecurrenceRule.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cStandard, cRecurrenceRule, err)
-			}
-		case "COMMENT":
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cStandard, cRecurrenceRule, err)); err != nil {
+					return err
+				}
+
+				s.RecurrenceRule = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("RRULE", params)
+		case "COMMENT":
+			var e PropComment
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cStandard, cComment, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Comment = append(s.Comment, e)
+		case "RDATE":
+			var e PropRecurrenceDateTimes
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cStandard, cRecurrenceDateTimes, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.RecurrenceDateTimes = append(s.RecurrenceDateTimes, e)
+		case "TZNAME":
+			var e PropTimezoneName
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cStandard, cTimezoneName, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.TimezoneName = append(s.TimezoneName, e)
+		case "END":
+			if err := endValue(t, value, "STANDARD"); err != nil {
+				return fmt.Errorf(errDecodingType, cStandard, err)
+			}
+
+			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
+		}
+	}
+
+	if !requiredDateTimeStart || !requiredTimezoneOffsetTo || !requiredTimezoneOffsetFrom {
+		return fmt.Errorf(errDecodingType, cStandard, ErrMissingRequired)
+	}
+
+	return nil
+}
+
+func (s *Standard) encode(w writer) {
+	w.WriteString("BEGIN:STANDARD\r\n")
+	s.DateTimeStart.encode(w)
+	s.TimezoneOffsetTo.encode(s.PropertyParams.writer(w, "TZOFFSETTO"))
+	s.TimezoneOffsetFrom.encode(s.PropertyParams.writer(w, "TZOFFSETFROM"))
+
+	if s.RecurrenceRule != nil {
+		s.RecurrenceRule.encode(s.PropertyParams.writer(w, "RRULE"))
+	}
+
+	for n := range s.Comment {
+		s.Comment[n].encode(w)
+	}
+
+	for n := range s.RecurrenceDateTimes {
+		s.RecurrenceDateTimes[n].encode(w)
+	}
+
+	for n := range s.TimezoneName {
+		s.TimezoneName[n].encode(w)
+	}
+
+	s.Extensions.encode(w)
+
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
+
+	w.WriteString("END:STANDARD\r\n")
+}
+
+func (s *Standard) valid() error {
+	if err := s.DateTimeStart.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cStandard, cDateTimeStart, err)
+	}
+
+	if err := s.TimezoneOffsetTo.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cStandard, cTimezoneOffsetTo, err)
+	}
+
+	if err := s.TimezoneOffsetFrom.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cStandard, cTimezoneOffsetFrom, err)
+	}
+
+	if s.RecurrenceRule != nil {
+		if err := s.RecurrenceRule.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cStandard, cRecurrenceRule, err)
+		}
+	}
+
+	for n := range s.Comment {
+		if err := s.Comment[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cStandard, cComment, err)
+		}
+	}
+
+	for n := range s.RecurrenceDateTimes {
+		if err := s.RecurrenceDateTimes[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cStandard, cRecurrenceDateTimes, err)
+		}
+	}
+
+	for n := range s.TimezoneName {
+		if err := s.TimezoneName[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cStandard, cTimezoneName, err)
+		}
+	}
+
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cStandard, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cStandard, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cStandard, err)
+		}
+	}
+
+	return nil
+}
+
+// Daylight represents daylight savings timezone rules.
+type Daylight struct {
+	DateTimeStart       PropDateTimeStart
+	TimezoneOffsetTo    PropTimezoneOffsetTo
+	TimezoneOffsetFrom  PropTimezoneOffsetFrom
+	RecurrenceRule      *PropRecurrenceRule
+	Comment             []PropComment
+	RecurrenceDateTimes []PropRecurrenceDateTimes
+	TimezoneName        []PropTimezoneName
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
+}
+
+func (s *Daylight) decode(t tokeniser) error {
+	var requiredDateTimeStart, requiredTimezoneOffsetTo, requiredTimezoneOffsetFrom bool
+
+Loop:
+	for {
+		p, err := t.GetPhrase()
+		if err != nil {
+			return fmt.Errorf(errDecodingType, cDaylight, err)
+		} else if p.Type == parser.PhraseDone {
+			return fmt.Errorf(errDecodingType, cDaylight, io.ErrUnexpectedEOF)
+		}
+
+		params := p.Data[1 : len(p.Data)-1]
+		value := p.Data[len(p.Data)-1].Data
+
+		checkProperty(t, p.Data[0].Data, params, value)
+
+		switch strings.ToUpper(p.Data[0].Data) {
+		case "BEGIN":
+			switch n := strings.ToUpper(value); n {
+			default:
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
+					return fmt.Errorf(errDecodingType, cDaylight, err)
+				}
+
+				s.Components = append(s.Components, c)
+			}
+		case "DTSTART":
+			if requiredDateTimeStart {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cDaylight, ErrDuplicateProperty, cDateTimeStart)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			requiredDateTimeStart = true
+
+			if err := s.DateTimeStart.decode(params, value); err != nil {
+				return fmt.Errorf(errDecodingProp, cDaylight, cDateTimeStart, err)
+			}
+		case "TZOFFSETTO":
+			if requiredTimezoneOffsetTo {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cDaylight, ErrDuplicateProperty, cTimezoneOffsetTo)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			requiredTimezoneOffsetTo = true
+
+			if err := s.TimezoneOffsetTo.decode(params, value); err != nil {
+				return fmt.Errorf(errDecodingProp, cDaylight, cTimezoneOffsetTo, err)
+			}
+
+			s.PropertyParams.decode("TZOFFSETTO", params)
+		case "TZOFFSETFROM":
+			if requiredTimezoneOffsetFrom {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cDaylight, ErrDuplicateProperty, cTimezoneOffsetFrom)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			requiredTimezoneOffsetFrom = true
+
+			if err := s.TimezoneOffsetFrom.decode(params, value); err != nil {
+				return fmt.Errorf(errDecodingProp, cDaylight, cTimezoneOffsetFrom, err)
+			}
+
+			s.PropertyParams.decode("TZOFFSETFROM", params)
+		case "RRULE":
+			if s.RecurrenceRule != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cDaylight, ErrDuplicateProperty, cRecurrenceRule)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.RecurrenceRule = new(PropRecurrenceRule)
+
+			if err := s.RecurrenceRule.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cDaylight, cRecurrenceRule, err)); err != nil {
+					return err
+				}
+
+				s.RecurrenceRule = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("RRULE", params)
+		case "COMMENT":
 			var e PropComment
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cStandard, cComment, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cDaylight, cComment, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Comment = append(s.Comment, e)
 		case "RDATE":
 			var e PropRecurrenceDateTimes
 
-			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cStandard, cRecurrenceDateTimes, err)
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cDaylight, cRecurrenceDateTimes, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.RecurrenceDateTimes = append(s.RecurrenceDateTimes, e)
+		case "TZNAME":
+			var e PropTimezoneName
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cDaylight, cTimezoneName, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.TimezoneName = append(s.TimezoneName, e)
+		case "END":
+			if err := endValue(t, value, "DAYLIGHT"); err != nil {
+				return fmt.Errorf(errDecodingType, cDaylight, err)
+			}
+
+			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
+		}
+	}
+
+	if !requiredDateTimeStart || !requiredTimezoneOffsetTo || !requiredTimezoneOffsetFrom {
+		return fmt.Errorf(errDecodingType, cDaylight, ErrMissingRequired)
+	}
+
+	return nil
+}
+
+func (s *Daylight) encode(w writer) {
+	w.WriteString("BEGIN:DAYLIGHT\r\n")
+	s.DateTimeStart.encode(w)
+	s.TimezoneOffsetTo.encode(s.PropertyParams.writer(w, "TZOFFSETTO"))
+	s.TimezoneOffsetFrom.encode(s.PropertyParams.writer(w, "TZOFFSETFROM"))
+
+	if s.RecurrenceRule != nil {
+		s.RecurrenceRule.encode(s.PropertyParams.writer(w, "RRULE"))
+	}
+
+	for n := range s.Comment {
+		s.Comment[n].encode(w)
+	}
+
+	for n := range s.RecurrenceDateTimes {
+		s.RecurrenceDateTimes[n].encode(w)
+	}
+
+	for n := range s.TimezoneName {
+		s.TimezoneName[n].encode(w)
+	}
+
+	s.Extensions.encode(w)
+
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
+
+	w.WriteString("END:DAYLIGHT\r\n")
+}
+
+func (s *Daylight) valid() error {
+	if err := s.DateTimeStart.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cDaylight, cDateTimeStart, err)
+	}
+
+	if err := s.TimezoneOffsetTo.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cDaylight, cTimezoneOffsetTo, err)
+	}
+
+	if err := s.TimezoneOffsetFrom.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cDaylight, cTimezoneOffsetFrom, err)
+	}
+
+	if s.RecurrenceRule != nil {
+		if err := s.RecurrenceRule.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cDaylight, cRecurrenceRule, err)
+		}
+	}
+
+	for n := range s.Comment {
+		if err := s.Comment[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cDaylight, cComment, err)
+		}
+	}
+
+	for n := range s.RecurrenceDateTimes {
+		if err := s.RecurrenceDateTimes[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cDaylight, cRecurrenceDateTimes, err)
+		}
+	}
+
+	for n := range s.TimezoneName {
+		if err := s.TimezoneName[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cDaylight, cTimezoneName, err)
+		}
+	}
+
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cDaylight, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cDaylight, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cDaylight, err)
+		}
+	}
+
+	return nil
+}
+
+// Availability provides a group of components that describe the availability of
+// a calendar user.
+type Availability struct {
+	DateTimeStamp PropDateTimeStamp
+	UID           PropUID
+	BusyType      *PropBusyType
+	Class         *PropClass
+	Created       *PropCreated
+	Description   *PropDescription
+	DateTimeStart *PropDateTimeStart
+	LastModified  *PropLastModified
+	Location      *PropLocation
+	Organizer     *PropOrganizer
+	Priority      *PropPriority
+	Sequence      *PropSequence
+	Summary       *PropSummary
+	URL           *PropURL
+	DateTimeEnd   *PropDateTimeEnd
+	Duration      *PropDuration
+	Categories    []PropCategories
+	Comment       []PropComment
+	Contact       []PropContact
+	Available     []Available
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
+}
+
+func (s *Availability) decode(t tokeniser) error {
+	var requiredDateTimeStamp, requiredUID bool
+
+Loop:
+	for {
+		p, err := t.GetPhrase()
+		if err != nil {
+			return fmt.Errorf(errDecodingType, cAvailability, err)
+		} else if p.Type == parser.PhraseDone {
+			return fmt.Errorf(errDecodingType, cAvailability, io.ErrUnexpectedEOF)
+		}
+
+		params := p.Data[1 : len(p.Data)-1]
+		value := p.Data[len(p.Data)-1].Data
+
+		checkProperty(t, p.Data[0].Data, params, value)
+
+		switch strings.ToUpper(p.Data[0].Data) {
+		case "BEGIN":
+			switch n := strings.ToUpper(value); n {
+			case "AVAILABLE":
+				var e Available
+
+				if err := e.decode(t); err != nil {
+					return fmt.Errorf(errDecodingProp, cAvailability, cAvailable, err)
+				}
+
+				s.Available = append(s.Available, e)
+			default:
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
+					return fmt.Errorf(errDecodingType, cAvailability, err)
+				}
+
+				s.Components = append(s.Components, c)
+			}
+		case "DTSTAMP":
+			if requiredDateTimeStamp {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cDateTimeStamp)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			requiredDateTimeStamp = true
+
+			if err := s.DateTimeStamp.decode(params, value); err != nil {
+				return fmt.Errorf(errDecodingProp, cAvailability, cDateTimeStamp, err)
+			}
+
+			s.PropertyParams.decode("DTSTAMP", params)
+		case "UID":
+			if requiredUID {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cUID)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			requiredUID = true
+
+			if err := s.UID.decode(params, value); err != nil {
+				return fmt.Errorf(errDecodingProp, cAvailability, cUID, err)
+			}
+
+			s.PropertyParams.decode("UID", params)
+		case "BUSYTYPE":
+			if s.BusyType != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cBusyType)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.BusyType = new(PropBusyType)
+
+			if err := s.BusyType.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cBusyType, err)); err != nil {
+					return err
+				}
+
+				s.BusyType = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("BUSYTYPE", params)
+		case "CLASS":
+			if s.Class != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cClass)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Class = new(PropClass)
+
+			if err := s.Class.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cClass, err)); err != nil {
+					return err
+				}
+
+				s.Class = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("CLASS", params)
+		case "CREATED":
+			if s.Created != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cCreated)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Created = new(PropCreated)
+
+			if err := s.Created.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cCreated, err)); err != nil {
+					return err
+				}
+
+				s.Created = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("CREATED", params)
+		case "DESCRIPTION":
+			if s.Description != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cDescription)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Description = new(PropDescription)
+
+			if err := s.Description.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cDescription, err)); err != nil {
+					return err
+				}
+
+				s.Description = nil
+			}
+		case "DTSTART":
+			if s.DateTimeStart != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cDateTimeStart)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.DateTimeStart = new(PropDateTimeStart)
+
+			if err := s.DateTimeStart.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cDateTimeStart, err)); err != nil {
+					return err
+				}
+
+				s.DateTimeStart = nil
+			}
+		case "LAST-MODIFIED":
+			if s.LastModified != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cLastModified)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.LastModified = new(PropLastModified)
+
+			if err := s.LastModified.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cLastModified, err)); err != nil {
+					return err
+				}
+
+				s.LastModified = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("LAST-MODIFIED", params)
+		case "LOCATION":
+			if s.Location != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cLocation)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Location = new(PropLocation)
+
+			if err := s.Location.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cLocation, err)); err != nil {
+					return err
+				}
+
+				s.Location = nil
+			}
+		case "ORGANIZER":
+			if s.Organizer != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cOrganizer)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Organizer = new(PropOrganizer)
+
+			if err := s.Organizer.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cOrganizer, err)); err != nil {
+					return err
+				}
+
+				s.Organizer = nil
+			}
+		case "PRIORITY":
+			if s.Priority != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cPriority)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Priority = new(PropPriority)
+
+			if err := s.Priority.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cPriority, err)); err != nil {
+					return err
+				}
+
+				s.Priority = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("PRIORITY", params)
+		case "SEQUENCE":
+			if s.Sequence != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cSequence)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Sequence = new(PropSequence)
+
+			if err := s.Sequence.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cSequence, err)); err != nil {
+					return err
+				}
+
+				s.Sequence = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("SEQUENCE", params)
+		case "SUMMARY":
+			if s.Summary != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cSummary)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Summary = new(PropSummary)
+
+			if err := s.Summary.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cSummary, err)); err != nil {
+					return err
+				}
+
+				s.Summary = nil
+			}
+		case "URL":
+			if s.URL != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cURL)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.URL = new(PropURL)
+
+			if err := s.URL.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cURL, err)); err != nil {
+					return err
+				}
+
+				s.URL = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("URL", params)
+		case "DTEND":
+			if s.DateTimeEnd != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cDateTimeEnd)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.DateTimeEnd = new(PropDateTimeEnd)
+
+			if err := s.DateTimeEnd.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cDateTimeEnd, err)); err != nil {
+					return err
+				}
+
+				s.DateTimeEnd = nil
+			}
+		case "DURATION":
+			if s.Duration != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailability, ErrDuplicateProperty, cDuration)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Duration = new(PropDuration)
+
+			if err := s.Duration.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cDuration, err)); err != nil {
+					return err
+				}
+
+				s.Duration = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("DURATION", params)
+		case "CATEGORIES":
+			var e PropCategories
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cCategories, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Categories = append(s.Categories, e)
+		case "COMMENT":
+			var e PropComment
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cComment, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Comment = append(s.Comment, e)
+		case "CONTACT":
+			var e PropContact
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailability, cContact, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Contact = append(s.Contact, e)
+		case "END":
+			if err := endValue(t, value, "VAVAILABILITY"); err != nil {
+				return fmt.Errorf(errDecodingType, cAvailability, err)
+			}
+
+			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
+		}
+	}
+
+	if !requiredDateTimeStamp || !requiredUID {
+		return fmt.Errorf(errDecodingType, cAvailability, ErrMissingRequired)
+	}
+
+	if s.DateTimeEnd != nil && s.Duration != nil {
+		return fmt.Errorf(errDecodingType, cAvailability, ErrRequirementNotMet)
+	}
+
+	if s.DateTimeEnd != nil && (s.DateTimeStart == nil) {
+		return fmt.Errorf(errDecodingType, cAvailability, ErrRequirementNotMet)
+	}
+
+	if s.Duration != nil && (s.DateTimeStart == nil) {
+		return fmt.Errorf(errDecodingType, cAvailability, ErrRequirementNotMet)
+	}
+
+	return nil
+}
+
+func (s *Availability) encode(w writer) {
+	w.WriteString("BEGIN:VAVAILABILITY\r\n")
+	s.DateTimeStamp.encode(s.PropertyParams.writer(w, "DTSTAMP"))
+	s.UID.encode(s.PropertyParams.writer(w, "UID"))
+
+	if s.BusyType != nil {
+		s.BusyType.encode(s.PropertyParams.writer(w, "BUSYTYPE"))
+	}
+
+	if s.Class != nil {
+		s.Class.encode(s.PropertyParams.writer(w, "CLASS"))
+	}
+
+	if s.Created != nil {
+		s.Created.encode(s.PropertyParams.writer(w, "CREATED"))
+	}
+
+	if s.Description != nil {
+		s.Description.encode(w)
+	}
+
+	if s.DateTimeStart != nil {
+		s.DateTimeStart.encode(w)
+	}
+
+	if s.LastModified != nil {
+		s.LastModified.encode(s.PropertyParams.writer(w, "LAST-MODIFIED"))
+	}
+
+	if s.Location != nil {
+		s.Location.encode(w)
+	}
+
+	if s.Organizer != nil {
+		s.Organizer.encode(w)
+	}
+
+	if s.Priority != nil {
+		s.Priority.encode(s.PropertyParams.writer(w, "PRIORITY"))
+	}
+
+	if s.Sequence != nil {
+		s.Sequence.encode(s.PropertyParams.writer(w, "SEQUENCE"))
+	}
+
+	if s.Summary != nil {
+		s.Summary.encode(w)
+	}
+
+	if s.URL != nil {
+		s.URL.encode(s.PropertyParams.writer(w, "URL"))
+	}
+
+	if s.DateTimeEnd != nil {
+		s.DateTimeEnd.encode(w)
+	}
+
+	if s.Duration != nil {
+		s.Duration.encode(s.PropertyParams.writer(w, "DURATION"))
+	}
+
+	for n := range s.Categories {
+		s.Categories[n].encode(w)
+	}
+
+	for n := range s.Comment {
+		s.Comment[n].encode(w)
+	}
+
+	for n := range s.Contact {
+		s.Contact[n].encode(w)
+	}
+
+	s.Extensions.encode(w)
+
+	for n := range s.Available {
+		s.Available[n].encode(w)
+	}
+
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
+
+	w.WriteString("END:VAVAILABILITY\r\n")
+}
+
+func (s *Availability) valid() error {
+	if err := s.DateTimeStamp.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cAvailability, cDateTimeStamp, err)
+	}
+
+	if err := s.UID.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cAvailability, cUID, err)
+	}
+
+	if s.BusyType != nil {
+		if err := s.BusyType.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cBusyType, err)
+		}
+	}
+
+	if s.Class != nil {
+		if err := s.Class.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cClass, err)
+		}
+	}
+
+	if s.Created != nil {
+		if err := s.Created.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cCreated, err)
+		}
+	}
+
+	if s.Description != nil {
+		if err := s.Description.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cDescription, err)
+		}
+	}
+
+	if s.DateTimeStart != nil {
+		if err := s.DateTimeStart.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cDateTimeStart, err)
+		}
+	}
+
+	if s.LastModified != nil {
+		if err := s.LastModified.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cLastModified, err)
+		}
+	}
+
+	if s.Location != nil {
+		if err := s.Location.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cLocation, err)
+		}
+	}
+
+	if s.Organizer != nil {
+		if err := s.Organizer.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cOrganizer, err)
+		}
+	}
+
+	if s.Priority != nil {
+		if err := s.Priority.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cPriority, err)
+		}
+	}
+
+	if s.Sequence != nil {
+		if err := s.Sequence.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cSequence, err)
+		}
+	}
+
+	if s.Summary != nil {
+		if err := s.Summary.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cSummary, err)
+		}
+	}
+
+	if s.URL != nil {
+		if err := s.URL.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cURL, err)
+		}
+	}
+
+	if s.DateTimeEnd != nil {
+		if err := s.DateTimeEnd.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cDateTimeEnd, err)
+		}
+	}
+
+	if s.Duration != nil {
+		if err := s.Duration.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cDuration, err)
+		}
+	}
+
+	for n := range s.Categories {
+		if err := s.Categories[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cCategories, err)
+		}
+	}
+
+	for n := range s.Comment {
+		if err := s.Comment[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cComment, err)
+		}
+	}
+
+	for n := range s.Contact {
+		if err := s.Contact[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cContact, err)
+		}
+	}
+
+	for n := range s.Available {
+		if err := s.Available[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailability, cAvailable, err)
+		}
+	}
+
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAvailability, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAvailability, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cAvailability, err)
+		}
+	}
+
+	return nil
+}
+
+// Available provides a group of components that define a period of availability.
+type Available struct {
+	DateTimeStamp       PropDateTimeStamp
+	DateTimeStart       PropDateTimeStart
+	UID                 PropUID
+	Created             *PropCreated
+	Description         *PropDescription
+	LastModified        *PropLastModified
+	Location            *PropLocation
+	RecurrenceID        *PropRecurrenceID
+	RecurrenceRule      *PropRecurrenceRule
+	Summary             *PropSummary
+	DateTimeEnd         *PropDateTimeEnd
+	Duration            *PropDuration
+	Categories          []PropCategories
+	Comment             []PropComment
+	Contact             []PropContact
+	ExceptionDateTime   []PropExceptionDateTime
+	RecurrenceDateTimes []PropRecurrenceDateTimes
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
+}
+
+func (s *Available) decode(t tokeniser) error {
+	var requiredDateTimeStamp, requiredDateTimeStart, requiredUID bool
+
+Loop:
+	for {
+		p, err := t.GetPhrase()
+		if err != nil {
+			return fmt.Errorf(errDecodingType, cAvailable, err)
+		} else if p.Type == parser.PhraseDone {
+			return fmt.Errorf(errDecodingType, cAvailable, io.ErrUnexpectedEOF)
+		}
+
+		params := p.Data[1 : len(p.Data)-1]
+		value := p.Data[len(p.Data)-1].Data
+
+		checkProperty(t, p.Data[0].Data, params, value)
+
+		switch strings.ToUpper(p.Data[0].Data) {
+		case "BEGIN":
+			switch n := strings.ToUpper(value); n {
+			default:
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
+					return fmt.Errorf(errDecodingType, cAvailable, err)
+				}
+
+				s.Components = append(s.Components, c)
+			}
+		case "DTSTAMP":
+			if requiredDateTimeStamp {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailable, ErrDuplicateProperty, cDateTimeStamp)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			requiredDateTimeStamp = true
+
+			if err := s.DateTimeStamp.decode(params, value); err != nil {
+				return fmt.Errorf(errDecodingProp, cAvailable, cDateTimeStamp, err)
+			}
+
+			s.PropertyParams.decode("DTSTAMP", params)
+		case "DTSTART":
+			if requiredDateTimeStart {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailable, ErrDuplicateProperty, cDateTimeStart)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			requiredDateTimeStart = true
+
+			if err := s.DateTimeStart.decode(params, value); err != nil {
+				return fmt.Errorf(errDecodingProp, cAvailable, cDateTimeStart, err)
+			}
+		case "UID":
+			if requiredUID {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailable, ErrDuplicateProperty, cUID)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			requiredUID = true
+
+			if err := s.UID.decode(params, value); err != nil {
+				return fmt.Errorf(errDecodingProp, cAvailable, cUID, err)
+			}
+
+			s.PropertyParams.decode("UID", params)
+		case "CREATED":
+			if s.Created != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailable, ErrDuplicateProperty, cCreated)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Created = new(PropCreated)
+
+			if err := s.Created.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailable, cCreated, err)); err != nil {
+					return err
+				}
+
+				s.Created = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("CREATED", params)
+		case "DESCRIPTION":
+			if s.Description != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailable, ErrDuplicateProperty, cDescription)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Description = new(PropDescription)
+
+			if err := s.Description.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailable, cDescription, err)); err != nil {
+					return err
+				}
+
+				s.Description = nil
+			}
+		case "LAST-MODIFIED":
+			if s.LastModified != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailable, ErrDuplicateProperty, cLastModified)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.LastModified = new(PropLastModified)
+
+			if err := s.LastModified.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailable, cLastModified, err)); err != nil {
+					return err
+				}
+
+				s.LastModified = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("LAST-MODIFIED", params)
+		case "LOCATION":
+			if s.Location != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailable, ErrDuplicateProperty, cLocation)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Location = new(PropLocation)
+
+			if err := s.Location.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailable, cLocation, err)); err != nil {
+					return err
+				}
+
+				s.Location = nil
+			}
+		case "RECURRENCE-ID":
+			if s.RecurrenceID != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailable, ErrDuplicateProperty, cRecurrenceID)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.RecurrenceID = new(PropRecurrenceID)
+
+			if err := s.RecurrenceID.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailable, cRecurrenceID, err)); err != nil {
+					return err
+				}
+
+				s.RecurrenceID = nil
+			}
+		case "RRULE":
+			if s.RecurrenceRule != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailable, ErrDuplicateProperty, cRecurrenceRule)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.RecurrenceRule = new(PropRecurrenceRule)
+
+			if err := s.RecurrenceRule.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailable, cRecurrenceRule, err)); err != nil {
+					return err
+				}
+
+				s.RecurrenceRule = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("RRULE", params)
+		case "SUMMARY":
+			if s.Summary != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailable, ErrDuplicateProperty, cSummary)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Summary = new(PropSummary)
+
+			if err := s.Summary.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailable, cSummary, err)); err != nil {
+					return err
+				}
+
+				s.Summary = nil
+			}
+		case "DTEND":
+			if s.DateTimeEnd != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailable, ErrDuplicateProperty, cDateTimeEnd)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.DateTimeEnd = new(PropDateTimeEnd)
+
+			if err := s.DateTimeEnd.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailable, cDateTimeEnd, err)); err != nil {
+					return err
+				}
+
+				s.DateTimeEnd = nil
+			}
+		case "DURATION":
+			if s.Duration != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAvailable, ErrDuplicateProperty, cDuration)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Duration = new(PropDuration)
+
+			if err := s.Duration.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailable, cDuration, err)); err != nil {
+					return err
+				}
+
+				s.Duration = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("DURATION", params)
+		case "CATEGORIES":
+			var e PropCategories
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailable, cCategories, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Categories = append(s.Categories, e)
+		case "COMMENT":
+			var e PropComment
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailable, cComment, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Comment = append(s.Comment, e)
+		case "CONTACT":
+			var e PropContact
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailable, cContact, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Contact = append(s.Contact, e)
+		case "EXDATE":
+			var e PropExceptionDateTime
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailable, cExceptionDateTime, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.ExceptionDateTime = append(s.ExceptionDateTime, e)
+		case "RDATE":
+			var e PropRecurrenceDateTimes
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAvailable, cRecurrenceDateTimes, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.RecurrenceDateTimes = append(s.RecurrenceDateTimes, e)
+		case "END":
+			if err := endValue(t, value, "AVAILABLE"); err != nil {
+				return fmt.Errorf(errDecodingType, cAvailable, err)
+			}
+
+			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
+		}
+	}
+
+	if !requiredDateTimeStamp || !requiredDateTimeStart || !requiredUID {
+		return fmt.Errorf(errDecodingType, cAvailable, ErrMissingRequired)
+	}
+
+	if s.DateTimeEnd != nil && s.Duration != nil {
+		return fmt.Errorf(errDecodingType, cAvailable, ErrRequirementNotMet)
+	}
+
+	return nil
+}
+
+func (s *Available) encode(w writer) {
+	w.WriteString("BEGIN:AVAILABLE\r\n")
+	s.DateTimeStamp.encode(s.PropertyParams.writer(w, "DTSTAMP"))
+	s.DateTimeStart.encode(w)
+	s.UID.encode(s.PropertyParams.writer(w, "UID"))
+
+	if s.Created != nil {
+		s.Created.encode(s.PropertyParams.writer(w, "CREATED"))
+	}
+
+	if s.Description != nil {
+		s.Description.encode(w)
+	}
+
+	if s.LastModified != nil {
+		s.LastModified.encode(s.PropertyParams.writer(w, "LAST-MODIFIED"))
+	}
+
+	if s.Location != nil {
+		s.Location.encode(w)
+	}
+
+	if s.RecurrenceID != nil {
+		s.RecurrenceID.encode(w)
+	}
+
+	if s.RecurrenceRule != nil {
+		s.RecurrenceRule.encode(s.PropertyParams.writer(w, "RRULE"))
+	}
+
+	if s.Summary != nil {
+		s.Summary.encode(w)
+	}
+
+	if s.DateTimeEnd != nil {
+		s.DateTimeEnd.encode(w)
+	}
+
+	if s.Duration != nil {
+		s.Duration.encode(s.PropertyParams.writer(w, "DURATION"))
+	}
+
+	for n := range s.Categories {
+		s.Categories[n].encode(w)
+	}
+
+	for n := range s.Comment {
+		s.Comment[n].encode(w)
+	}
+
+	for n := range s.Contact {
+		s.Contact[n].encode(w)
+	}
+
+	for n := range s.ExceptionDateTime {
+		s.ExceptionDateTime[n].encode(w)
+	}
+
+	for n := range s.RecurrenceDateTimes {
+		s.RecurrenceDateTimes[n].encode(w)
+	}
+
+	s.Extensions.encode(w)
+
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
+
+	w.WriteString("END:AVAILABLE\r\n")
+}
+
+func (s *Available) valid() error {
+	if err := s.DateTimeStamp.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cAvailable, cDateTimeStamp, err)
+	}
+
+	if err := s.DateTimeStart.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cAvailable, cDateTimeStart, err)
+	}
+
+	if err := s.UID.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cAvailable, cUID, err)
+	}
+
+	if s.Created != nil {
+		if err := s.Created.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailable, cCreated, err)
+		}
+	}
+
+	if s.Description != nil {
+		if err := s.Description.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailable, cDescription, err)
+		}
+	}
+
+	if s.LastModified != nil {
+		if err := s.LastModified.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailable, cLastModified, err)
+		}
+	}
+
+	if s.Location != nil {
+		if err := s.Location.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailable, cLocation, err)
+		}
+	}
+
+	if s.RecurrenceID != nil {
+		if err := s.RecurrenceID.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailable, cRecurrenceID, err)
+		}
+	}
+
+	if s.RecurrenceRule != nil {
+		if err := s.RecurrenceRule.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailable, cRecurrenceRule, err)
+		}
+	}
+
+	if s.Summary != nil {
+		if err := s.Summary.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailable, cSummary, err)
+		}
+	}
+
+	if s.DateTimeEnd != nil {
+		if err := s.DateTimeEnd.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailable, cDateTimeEnd, err)
+		}
+	}
+
+	if s.Duration != nil {
+		if err := s.Duration.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailable, cDuration, err)
+		}
+	}
+
+	for n := range s.Categories {
+		if err := s.Categories[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailable, cCategories, err)
+		}
+	}
+
+	for n := range s.Comment {
+		if err := s.Comment[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailable, cComment, err)
+		}
+	}
+
+	for n := range s.Contact {
+		if err := s.Contact[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailable, cContact, err)
+		}
+	}
+
+	for n := range s.ExceptionDateTime {
+		if err := s.ExceptionDateTime[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailable, cExceptionDateTime, err)
+		}
+	}
+
+	for n := range s.RecurrenceDateTimes {
+		if err := s.RecurrenceDateTimes[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cAvailable, cRecurrenceDateTimes, err)
+		}
+	}
+
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAvailable, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAvailable, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cAvailable, err)
+		}
+	}
+
+	return nil
+}
+
+// Participant provides a group of components that describe a participant in an
+// event or to-do.
+type Participant struct {
+	UID                PropUID
+	ParticipantType    PropParticipantType
+	CalendarAddress    *PropCalendarAddress
+	Created            *PropCreated
+	Description        *PropDescription
+	DateTimeStamp      *PropDateTimeStamp
+	Geo                *PropGeo
+	LastModified       *PropLastModified
+	Priority           *PropPriority
+	Sequence           *PropSequence
+	Status             *PropStatus
+	Summary            *PropSummary
+	URL                *PropURL
+	Attachment         []PropAttachment
+	Categories         []PropCategories
+	Comment            []PropComment
+	Contact            []PropContact
+	Location           []PropLocation
+	RequestStatus      []PropRequestStatus
+	RelatedTo          []PropRelatedTo
+	Resources          []PropResources
+	StyledDescription  []PropStyledDescription
+	StructuredData     []PropStructuredData
+	StructuredLocation []Location
+	StructuredResource []Resource
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
+}
+
+func (s *Participant) decode(t tokeniser) error {
+	var requiredUID, requiredParticipantType bool
+
+Loop:
+	for {
+		p, err := t.GetPhrase()
+		if err != nil {
+			return fmt.Errorf(errDecodingType, cParticipant, err)
+		} else if p.Type == parser.PhraseDone {
+			return fmt.Errorf(errDecodingType, cParticipant, io.ErrUnexpectedEOF)
+		}
+
+		params := p.Data[1 : len(p.Data)-1]
+		value := p.Data[len(p.Data)-1].Data
+
+		checkProperty(t, p.Data[0].Data, params, value)
+
+		switch strings.ToUpper(p.Data[0].Data) {
+		case "BEGIN":
+			switch n := strings.ToUpper(value); n {
+			case "VLOCATION":
+				var e Location
+
+				if err := e.decode(t); err != nil {
+					return fmt.Errorf(errDecodingProp, cParticipant, cLocation, err)
+				}
+
+				s.StructuredLocation = append(s.StructuredLocation, e)
+			case "VRESOURCE":
+				var e Resource
+
+				if err := e.decode(t); err != nil {
+					return fmt.Errorf(errDecodingProp, cParticipant, cResource, err)
+				}
+
+				s.StructuredResource = append(s.StructuredResource, e)
+			default:
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
+					return fmt.Errorf(errDecodingType, cParticipant, err)
+				}
+
+				s.Components = append(s.Components, c)
+			}
+		case "UID":
+			if requiredUID {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cParticipant, ErrDuplicateProperty, cUID)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			requiredUID = true
+
+			if err := s.UID.decode(params, value); err != nil {
+				return fmt.Errorf(errDecodingProp, cParticipant, cUID, err)
+			}
+
+			s.PropertyParams.decode("UID", params)
+		case "PARTICIPANT-TYPE":
+			if requiredParticipantType {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cParticipant, ErrDuplicateProperty, cParticipantType)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			requiredParticipantType = true
+
+			if err := s.ParticipantType.decode(params, value); err != nil {
+				return fmt.Errorf(errDecodingProp, cParticipant, cParticipantType, err)
+			}
+
+			s.PropertyParams.decode("PARTICIPANT-TYPE", params)
+		case "CALENDAR-ADDRESS":
+			if s.CalendarAddress != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cParticipant, ErrDuplicateProperty, cCalendarAddress)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.CalendarAddress = new(PropCalendarAddress)
+
+			if err := s.CalendarAddress.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cCalendarAddress, err)); err != nil {
+					return err
+				}
+
+				s.CalendarAddress = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("CALENDAR-ADDRESS", params)
+		case "CREATED":
+			if s.Created != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cParticipant, ErrDuplicateProperty, cCreated)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Created = new(PropCreated)
+
+			if err := s.Created.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cCreated, err)); err != nil {
+					return err
+				}
+
+				s.Created = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("CREATED", params)
+		case "DESCRIPTION":
+			if s.Description != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cParticipant, ErrDuplicateProperty, cDescription)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Description = new(PropDescription)
+
+			if err := s.Description.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cDescription, err)); err != nil {
+					return err
+				}
+
+				s.Description = nil
+			}
+		case "DTSTAMP":
+			if s.DateTimeStamp != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cParticipant, ErrDuplicateProperty, cDateTimeStamp)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.DateTimeStamp = new(PropDateTimeStamp)
+
+			if err := s.DateTimeStamp.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cDateTimeStamp, err)); err != nil {
+					return err
+				}
+
+				s.DateTimeStamp = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("DTSTAMP", params)
+		case "GEO":
+			if s.Geo != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cParticipant, ErrDuplicateProperty, cGeo)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Geo = new(PropGeo)
+
+			if err := s.Geo.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cGeo, err)); err != nil {
+					return err
+				}
+
+				s.Geo = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("GEO", params)
+		case "LAST-MODIFIED":
+			if s.LastModified != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cParticipant, ErrDuplicateProperty, cLastModified)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.LastModified = new(PropLastModified)
+
+			if err := s.LastModified.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cLastModified, err)); err != nil {
+					return err
+				}
+
+				s.LastModified = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("LAST-MODIFIED", params)
+		case "PRIORITY":
+			if s.Priority != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cParticipant, ErrDuplicateProperty, cPriority)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Priority = new(PropPriority)
+
+			if err := s.Priority.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cPriority, err)); err != nil {
+					return err
+				}
+
+				s.Priority = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("PRIORITY", params)
+		case "SEQUENCE":
+			if s.Sequence != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cParticipant, ErrDuplicateProperty, cSequence)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Sequence = new(PropSequence)
+
+			if err := s.Sequence.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cSequence, err)); err != nil {
+					return err
+				}
+
+				s.Sequence = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("SEQUENCE", params)
+		case "STATUS":
+			if s.Status != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cParticipant, ErrDuplicateProperty, cStatus)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Status = new(PropStatus)
+
+			if err := s.Status.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cStatus, err)); err != nil {
+					return err
+				}
+
+				s.Status = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("STATUS", params)
+		case "SUMMARY":
+			if s.Summary != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cParticipant, ErrDuplicateProperty, cSummary)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Summary = new(PropSummary)
+
+			if err := s.Summary.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cSummary, err)); err != nil {
+					return err
+				}
+
+				s.Summary = nil
+			}
+		case "URL":
+			if s.URL != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cParticipant, ErrDuplicateProperty, cURL)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.URL = new(PropURL)
+
+			if err := s.URL.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cURL, err)); err != nil {
+					return err
+				}
+
+				s.URL = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("URL", params)
+		case "ATTACH":
+			var e PropAttachment
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cAttachment, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Attachment = append(s.Attachment, e)
+		case "CATEGORIES":
+			var e PropCategories
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cCategories, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Categories = append(s.Categories, e)
+		case "COMMENT":
+			var e PropComment
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cComment, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Comment = append(s.Comment, e)
+		case "CONTACT":
+			var e PropContact
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cContact, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Contact = append(s.Contact, e)
+		case "LOCATION":
+			var e PropLocation
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cLocation, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Location = append(s.Location, e)
+		case "REQUEST-STATUS":
+			var e PropRequestStatus
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cRequestStatus, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.RequestStatus = append(s.RequestStatus, e)
+
+			s.PropertyParams.decode("REQUEST-STATUS", params)
+		case "RELATED-TO":
+			var e PropRelatedTo
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cRelatedTo, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.RelatedTo = append(s.RelatedTo, e)
+		case "RESOURCES":
+			var e PropResources
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cResources, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Resources = append(s.Resources, e)
+		case "STYLED-DESCRIPTION":
+			var e PropStyledDescription
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cStyledDescription, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.StyledDescription = append(s.StyledDescription, e)
+		case "STRUCTURED-DATA":
+			var e PropStructuredData
+
+			if err := e.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cParticipant, cStructuredData, err)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.StructuredData = append(s.StructuredData, e)
+		case "END":
+			if err := endValue(t, value, "PARTICIPANT"); err != nil {
+				return fmt.Errorf(errDecodingType, cParticipant, err)
+			}
+
+			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
+		}
+	}
+
+	if !requiredUID || !requiredParticipantType {
+		return fmt.Errorf(errDecodingType, cParticipant, ErrMissingRequired)
+	}
+
+	return nil
+}
+
+func (s *Participant) encode(w writer) {
+	w.WriteString("BEGIN:PARTICIPANT\r\n")
+	s.UID.encode(s.PropertyParams.writer(w, "UID"))
+	s.ParticipantType.encode(s.PropertyParams.writer(w, "PARTICIPANT-TYPE"))
+
+	if s.CalendarAddress != nil {
+		s.CalendarAddress.encode(s.PropertyParams.writer(w, "CALENDAR-ADDRESS"))
+	}
+
+	if s.Created != nil {
+		s.Created.encode(s.PropertyParams.writer(w, "CREATED"))
+	}
+
+	if s.Description != nil {
+		s.Description.encode(w)
+	}
+
+	if s.DateTimeStamp != nil {
+		s.DateTimeStamp.encode(s.PropertyParams.writer(w, "DTSTAMP"))
+	}
+
+	if s.Geo != nil {
+		s.Geo.encode(s.PropertyParams.writer(w, "GEO"))
+	}
+
+	if s.LastModified != nil {
+		s.LastModified.encode(s.PropertyParams.writer(w, "LAST-MODIFIED"))
+	}
+
+	if s.Priority != nil {
+		s.Priority.encode(s.PropertyParams.writer(w, "PRIORITY"))
+	}
+
+	if s.Sequence != nil {
+		s.Sequence.encode(s.PropertyParams.writer(w, "SEQUENCE"))
+	}
+
+	if s.Status != nil {
+		s.Status.encode(s.PropertyParams.writer(w, "STATUS"))
+	}
+
+	if s.Summary != nil {
+		s.Summary.encode(w)
+	}
+
+	if s.URL != nil {
+		s.URL.encode(s.PropertyParams.writer(w, "URL"))
+	}
+
+	for n := range s.Attachment {
+		s.Attachment[n].encode(w)
+	}
+
+	for n := range s.Categories {
+		s.Categories[n].encode(w)
+	}
+
+	for n := range s.Comment {
+		s.Comment[n].encode(w)
+	}
+
+	for n := range s.Contact {
+		s.Contact[n].encode(w)
+	}
+
+	for n := range s.Location {
+		s.Location[n].encode(w)
+	}
+
+	for n := range s.RequestStatus {
+		s.RequestStatus[n].encode(s.PropertyParams.writer(w, "REQUEST-STATUS"))
+	}
+
+	for n := range s.RelatedTo {
+		s.RelatedTo[n].encode(w)
+	}
+
+	for n := range s.Resources {
+		s.Resources[n].encode(w)
+	}
+
+	for n := range s.StyledDescription {
+		s.StyledDescription[n].encode(w)
+	}
+
+	for n := range s.StructuredData {
+		s.StructuredData[n].encode(w)
+	}
+
+	s.Extensions.encode(w)
+
+	for n := range s.StructuredLocation {
+		s.StructuredLocation[n].encode(w)
+	}
+
+	for n := range s.StructuredResource {
+		s.StructuredResource[n].encode(w)
+	}
+
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
+
+	w.WriteString("END:PARTICIPANT\r\n")
+}
+
+func (s *Participant) valid() error {
+	if err := s.UID.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cParticipant, cUID, err)
+	}
+
+	if err := s.ParticipantType.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cParticipant, cParticipantType, err)
+	}
+
+	if s.CalendarAddress != nil {
+		if err := s.CalendarAddress.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cCalendarAddress, err)
+		}
+	}
+
+	if s.Created != nil {
+		if err := s.Created.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cCreated, err)
+		}
+	}
+
+	if s.Description != nil {
+		if err := s.Description.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cDescription, err)
+		}
+	}
+
+	if s.DateTimeStamp != nil {
+		if err := s.DateTimeStamp.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cDateTimeStamp, err)
+		}
+	}
+
+	if s.Geo != nil {
+		if err := s.Geo.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cGeo, err)
+		}
+	}
+
+	if s.LastModified != nil {
+		if err := s.LastModified.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cLastModified, err)
+		}
+	}
+
+	if s.Priority != nil {
+		if err := s.Priority.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cPriority, err)
+		}
+	}
+
+	if s.Sequence != nil {
+		if err := s.Sequence.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cSequence, err)
+		}
+	}
+
+	if s.Status != nil {
+		if err := s.Status.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cStatus, err)
+		}
+	}
+
+	if s.Summary != nil {
+		if err := s.Summary.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cSummary, err)
+		}
+	}
+
+	if s.URL != nil {
+		if err := s.URL.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cURL, err)
+		}
+	}
+
+	for n := range s.Attachment {
+		if err := s.Attachment[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cAttachment, err)
+		}
+	}
+
+	for n := range s.Categories {
+		if err := s.Categories[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cCategories, err)
+		}
+	}
+
+	for n := range s.Comment {
+		if err := s.Comment[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cComment, err)
+		}
+	}
+
+	for n := range s.Contact {
+		if err := s.Contact[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cContact, err)
+		}
+	}
+
+	for n := range s.Location {
+		if err := s.Location[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cLocation, err)
+		}
+	}
+
+	for n := range s.RequestStatus {
+		if err := s.RequestStatus[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cRequestStatus, err)
+		}
+	}
+
+	for n := range s.RelatedTo {
+		if err := s.RelatedTo[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cRelatedTo, err)
+		}
+	}
+
+	for n := range s.Resources {
+		if err := s.Resources[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cResources, err)
+		}
+	}
+
+	for n := range s.StyledDescription {
+		if err := s.StyledDescription[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cStyledDescription, err)
+		}
+	}
+
+	for n := range s.StructuredData {
+		if err := s.StructuredData[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cStructuredData, err)
+		}
+	}
+
+	for n := range s.StructuredLocation {
+		if err := s.StructuredLocation[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cLocation, err)
+		}
+	}
+
+	for n := range s.StructuredResource {
+		if err := s.StructuredResource[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cParticipant, cResource, err)
+		}
+	}
+
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cParticipant, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cParticipant, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cParticipant, err)
+		}
+	}
+
+	return nil
+}
+
+// Location provides a group of components that describe a structured location.
+type Location struct {
+	UID            PropUID
+	Description    *PropDescription
+	Geo            *PropGeo
+	Name           *PropName
+	URL            *PropURL
+	LocationType   *PropLocationType
+	StructuredData []PropStructuredData
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
+}
+
+func (s *Location) decode(t tokeniser) error {
+	var requiredUID bool
+
+Loop:
+	for {
+		p, err := t.GetPhrase()
+		if err != nil {
+			return fmt.Errorf(errDecodingType, cLocation, err)
+		} else if p.Type == parser.PhraseDone {
+			return fmt.Errorf(errDecodingType, cLocation, io.ErrUnexpectedEOF)
+		}
+
+		params := p.Data[1 : len(p.Data)-1]
+		value := p.Data[len(p.Data)-1].Data
+
+		checkProperty(t, p.Data[0].Data, params, value)
+
+		switch strings.ToUpper(p.Data[0].Data) {
+		case "BEGIN":
+			switch n := strings.ToUpper(value); n {
+			default:
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
+					return fmt.Errorf(errDecodingType, cLocation, err)
+				}
+
+				s.Components = append(s.Components, c)
+			}
+		case "UID":
+			if requiredUID {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cLocation, ErrDuplicateProperty, cUID)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			requiredUID = true
+
+			if err := s.UID.decode(params, value); err != nil {
+				return fmt.Errorf(errDecodingProp, cLocation, cUID, err)
+			}
+
+			s.PropertyParams.decode("UID", params)
+		case "DESCRIPTION":
+			if s.Description != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cLocation, ErrDuplicateProperty, cDescription)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Description = new(PropDescription)
+
+			if err := s.Description.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cLocation, cDescription, err)); err != nil {
+					return err
+				}
+
+				s.Description = nil
+			}
+		case "GEO":
+			if s.Geo != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cLocation, ErrDuplicateProperty, cGeo)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Geo = new(PropGeo)
+
+			if err := s.Geo.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cLocation, cGeo, err)); err != nil {
+					return err
+				}
+
+				s.Geo = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("GEO", params)
+		case "NAME":
+			if s.Name != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cLocation, ErrDuplicateProperty, cName)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.Name = new(PropName)
+
+			if err := s.Name.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cLocation, cName, err)); err != nil {
+					return err
+				}
+
+				s.Name = nil
+			}
+		case "URL":
+			if s.URL != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cLocation, ErrDuplicateProperty, cURL)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.URL = new(PropURL)
+
+			if err := s.URL.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cLocation, cURL, err)); err != nil {
+					return err
+				}
+
+				s.URL = nil
+
+				continue
+			}
+
+			s.PropertyParams.decode("URL", params)
+		case "LOCATION-TYPE":
+			if s.LocationType != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cLocation, ErrDuplicateProperty, cLocationType)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			s.LocationType = new(PropLocationType)
+
+			if err := s.LocationType.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cLocation, cLocationType, err)); err != nil {
+					return err
+				}
+
+				s.LocationType = nil
+
+				continue
 			}
 
-			s.RecurrenceDateTimes = append(s.RecurrenceDateTimes, e)
-		case "TZNAME":
-			var e PropTimezoneName
+			s.PropertyParams.decode("LOCATION-TYPE", params)
+		case "STRUCTURED-DATA":
+			var e PropStructuredData
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cStandard, cTimezoneName, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cLocation, cStructuredData, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
-			s.TimezoneName = append(s.TimezoneName, e)
+			s.StructuredData = append(s.StructuredData, e)
 		case "END":
-			if value != "STANDARD" {
-				return fmt.Errorf(errDecodingType, cStandard, ErrInvalidEnd)
+			if err := endValue(t, value, "VLOCATION"); err != nil {
+				return fmt.Errorf(errDecodingType, cLocation, err)
 			}
 
 			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
 		}
 	}
 
-	if !requiredDateTimeStart || !requiredTimezoneOffsetTo || !requiredTimezoneOffsetFrom {
-		return fmt.Errorf(errDecodingType, cStandard, ErrMissingRequired)
+	if !requiredUID {
+		return fmt.Errorf(errDecodingType, cLocation, ErrMissingRequired)
 	}
 
 	return nil
 }
 
-func (s *Standard) encode(w writer) {
-	w.WriteString("BEGIN:STANDARD\r\n")
-	s.DateTimeStart.encode(w)
-	s.TimezoneOffsetTo.encode(w)
-	s.TimezoneOffsetFrom.encode(w)
+func (s *Location) encode(w writer) {
+	w.WriteString("BEGIN:VLOCATION\r\n")
+	s.UID.encode(s.PropertyParams.writer(w, "UID"))
 
-	if s.RecurrenceRule != nil {
-		s.RecurrenceRule.encode(w)
+	if s.Description != nil {
+		s.Description.encode(w)
 	}
 
-	for n := range s.Comment {
-		s.Comment[n].encode(w)
+	if s.Geo != nil {
+		s.Geo.encode(s.PropertyParams.writer(w, "GEO"))
 	}
 
-	for n := range s.RecurrenceDateTimes {
-		s.RecurrenceDateTimes[n].encode(w)
+	if s.Name != nil {
+		s.Name.encode(w)
 	}
 
-	for n := range s.TimezoneName {
-		s.TimezoneName[n].encode(w)
+	if s.URL != nil {
+		s.URL.encode(s.PropertyParams.writer(w, "URL"))
 	}
 
-	w.WriteString("END:STANDARD\r\n")
+	if s.LocationType != nil {
+		s.LocationType.encode(s.PropertyParams.writer(w, "LOCATION-TYPE"))
+	}
+
+	for n := range s.StructuredData {
+		s.StructuredData[n].encode(w)
+	}
+
+	s.Extensions.encode(w)
+
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
+
+	w.WriteString("END:VLOCATION\r\n")
 }
 
-func (s *Standard) valid() error {
-	if err := s.DateTimeStart.valid(); err != nil {
-		return fmt.Errorf(errValidatingProp, cStandard, cDateTimeStart, err)
+func (s *Location) valid() error {
+	if err := s.UID.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cLocation, cUID, err)
 	}
 
-	if err := s.TimezoneOffsetTo.valid(); err != nil {
-		return fmt.Errorf(errValidatingProp, cStandard, cTimezoneOffsetTo, err)
+	if s.Description != nil {
+		if err := s.Description.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cLocation, cDescription, err)
+		}
 	}
 
-	if err := s.TimezoneOffsetFrom.valid(); err != nil {
-		return fmt.Errorf(errValidatingProp, cStandard, cTimezoneOffsetFrom, err)
+	if s.Geo != nil {
+		if err := s.Geo.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cLocation, cGeo, err)
+		}
 	}
 
-	if s.RecurrenceRule != nil {
-		if err := s.RecurrenceRule.valid(); err != nil {
-			return fmt.Errorf(errValidatingProp, cStandard, cRecurrenceRule, err)
+	if s.Name != nil {
+		if err := s.Name.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cLocation, cName, err)
 		}
 	}
 
-	for n := range s.Comment {
-		if err := s.Comment[n].valid(); err != nil {
-			return fmt.Errorf(errValidatingProp, cStandard, cComment, err)
+	if s.URL != nil {
+		if err := s.URL.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cLocation, cURL, err)
 		}
 	}
 
-	for n := range s.RecurrenceDateTimes {
-		if err := s.RecurrenceDateTimes[n].valid(); err != nil {
-			return fmt.Errorf(errValidatingProp, cStandard, cRecurrenceDateTimes, err)
+	if s.LocationType != nil {
+		if err := s.LocationType.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cLocation, cLocationType, err)
 		}
 	}
 
-	for n := range s.TimezoneName {
-		if err := s.TimezoneName[n].valid(); err != nil {
-			return fmt.Errorf(errValidatingProp, cStandard, cTimezoneName, err)
+	for n := range s.StructuredData {
+		if err := s.StructuredData[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cLocation, cStructuredData, err)
+		}
+	}
+
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cLocation, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cLocation, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cLocation, err)
 		}
 	}
 
 	return nil
 }
 
-// Daylight represents daylight savings timezone rules.
-type Daylight struct {
-	DateTimeStart       PropDateTimeStart
-	TimezoneOffsetTo    PropTimezoneOffsetTo
-	TimezoneOffsetFrom  PropTimezoneOffsetFrom
-	RecurrenceRule      *PropRecurrenceRule
-	Comment             []PropComment
-	RecurrenceDateTimes []PropRecurrenceDateTimes
-	TimezoneName        []PropTimezoneName
+// Resource provides a group of components that describe a structured resource.
+type Resource struct {
+	UID            PropUID
+	Description    *PropDescription
+	Geo            *PropGeo
+	Name           *PropName
+	ResourceType   *PropResourceType
+	StructuredData []PropStructuredData
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
 }
 
-func (s *Daylight) decode(t tokeniser) error {
-	var requiredDateTimeStart, requiredTimezoneOffsetTo, requiredTimezoneOffsetFrom bool
+func (s *Resource) decode(t tokeniser) error {
+	var requiredUID bool
 
 Loop:
 	for {
 		p, err := t.GetPhrase()
 		if err != nil {
-			return fmt.Errorf(errDecodingType, cDaylight, err)
+			return fmt.Errorf(errDecodingType, cResource, err)
 		} else if p.Type == parser.PhraseDone {
-			return fmt.Errorf(errDecodingType, cDaylight, io.ErrUnexpectedEOF)
+			return fmt.Errorf(errDecodingType, cResource, io.ErrUnexpectedEOF)
 		}
 
 		params := p.Data[1 : len(p.Data)-1]
 		value := p.Data[len(p.Data)-1].Data
 
+		checkProperty(t, p.Data[0].Data, params, value)
+
 		switch strings.ToUpper(p.Data[0].Data) {
 		case "BEGIN":
 			switch n := strings.ToUpper(value); n {
 			default:
-				if err := decodeDummy(t, n); err != nil {
-					return fmt.Errorf(errDecodingType, cDaylight, err)
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
+					return fmt.Errorf(errDecodingType, cResource, err)
 				}
+
+				s.Components = append(s.Components, c)
 			}
-		case "DTSTART":
-			if requiredDateTimeStart {
-				return fmt.Errorf(errMultiple, cDaylight, cDateTimeStart)
+		case "UID":
+			if requiredUID {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cResource, ErrDuplicateProperty, cUID)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
-			requiredDateTimeStart = true
+			requiredUID = true
 
-			if err := s.DateTimeStart.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cDaylight, cDateTimeStart, err)
+			if err := s.UID.decode(params, value); err != nil {
+				return fmt.Errorf(errDecodingProp, cResource, cUID, err)
 			}
-		case "TZOFFSETTO":
-			if requiredTimezoneOffsetTo {
-				return fmt.Errorf(errMultiple, cDaylight, cTimezoneOffsetTo)
+
+			s.PropertyParams.decode("UID", params)
+		case "DESCRIPTION":
+			if s.Description != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cResource, ErrDuplicateProperty, cDescription)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
-			requiredTimezoneOffsetTo = true
+			s.Description = new(PropDescription)
 
-			if err := s.TimezoneOffsetTo.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cDaylight, cTimezoneOffsetTo, err)
+			if err := s.Description.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cResource, cDescription, err)); err != nil {
+					return err
+				}
+
+				s.Description = nil
 			}
-		case "TZOFFSETFROM":
-			if requiredTimezoneOffsetFrom {
-				return fmt.Errorf(errMultiple, cDaylight, cTimezoneOffsetFrom)
+		case "GEO":
+			if s.Geo != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cResource, ErrDuplicateProperty, cGeo)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
-			requiredTimezoneOffsetFrom = true
+			s.Geo = new(PropGeo)
 
-			if err := s.TimezoneOffsetFrom.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cDaylight, cTimezoneOffsetFrom, err)
+			if err := s.Geo.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cResource, cGeo, err)); err != nil {
+					return err
+				}
+
+				s.Geo = nil
+
+				continue
 			}
-		case "RRULE":
-			if s.RecurrenceRule != nil {
-				return fmt.Errorf(errMultiple, cDaylight, cRecurrenceRule)
+
+			s.PropertyParams.decode("GEO", params)
+		case "NAME":
+			if s.Name != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cResource, ErrDuplicateProperty, cName)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
-			s.RecurrenceRule = new(PropRecurrenceRule)
+			s.Name = new(PropName)
 
-			if err := s.RecurrenceRule.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cDaylight, cRecurrenceRule, err)
+			if err := s.Name.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cResource, cName, err)); err != nil {
+					return err
+				}
+
+				s.Name = nil
 			}
-		case "COMMENT":
-			var e PropComment
+		case "RESOURCE-TYPE":
+			if s.ResourceType != nil {
+				if err := recoverable(t, fmt.Errorf(errMultiple, cResource, ErrDuplicateProperty, cResourceType)); err != nil {
+					return err
+				}
 
-			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cDaylight, cComment, err)
+				continue
 			}
 
-			s.Comment = append(s.Comment, e)
-		case "RDATE":
-			var e PropRecurrenceDateTimes
+			s.ResourceType = new(PropResourceType)
 
-			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cDaylight, cRecurrenceDateTimes, err)
+			if err := s.ResourceType.decode(params, value); err != nil {
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cResource, cResourceType, err)); err != nil {
+					return err
+				}
+
+				s.ResourceType = nil
+
+				continue
 			}
 
-			s.RecurrenceDateTimes = append(s.RecurrenceDateTimes, e)
-		case "TZNAME":
-			var e PropTimezoneName
+			s.PropertyParams.decode("RESOURCE-TYPE", params)
+		case "STRUCTURED-DATA":
+			var e PropStructuredData
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cDaylight, cTimezoneName, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cResource, cStructuredData, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
-			s.TimezoneName = append(s.TimezoneName, e)
+			s.StructuredData = append(s.StructuredData, e)
 		case "END":
-			if value != "DAYLIGHT" {
-				return fmt.Errorf(errDecodingType, cDaylight, ErrInvalidEnd)
+			if err := endValue(t, value, "VRESOURCE"); err != nil {
+				return fmt.Errorf(errDecodingType, cResource, err)
 			}
 
 			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
 		}
 	}
 
-	if !requiredDateTimeStart || !requiredTimezoneOffsetTo || !requiredTimezoneOffsetFrom {
-		return fmt.Errorf(errDecodingType, cDaylight, ErrMissingRequired)
+	if !requiredUID {
+		return fmt.Errorf(errDecodingType, cResource, ErrMissingRequired)
 	}
 
 	return nil
 }
 
-func (s *Daylight) encode(w writer) {
-	w.WriteString("BEGIN:DAYLIGHT\r\n")
-	s.DateTimeStart.encode(w)
-	s.TimezoneOffsetTo.encode(w)
-	s.TimezoneOffsetFrom.encode(w)
+func (s *Resource) encode(w writer) {
+	w.WriteString("BEGIN:VRESOURCE\r\n")
+	s.UID.encode(s.PropertyParams.writer(w, "UID"))
 
-	if s.RecurrenceRule != nil {
-		s.RecurrenceRule.encode(w)
+	if s.Description != nil {
+		s.Description.encode(w)
 	}
 
-	for n := range s.Comment {
-		s.Comment[n].encode(w)
+	if s.Geo != nil {
+		s.Geo.encode(s.PropertyParams.writer(w, "GEO"))
 	}
 
-	for n := range s.RecurrenceDateTimes {
-		s.RecurrenceDateTimes[n].encode(w)
+	if s.Name != nil {
+		s.Name.encode(w)
 	}
 
-	for n := range s.TimezoneName {
-		s.TimezoneName[n].encode(w)
+	if s.ResourceType != nil {
+		s.ResourceType.encode(s.PropertyParams.writer(w, "RESOURCE-TYPE"))
 	}
 
-	w.WriteString("END:DAYLIGHT\r\n")
+	for n := range s.StructuredData {
+		s.StructuredData[n].encode(w)
+	}
+
+	s.Extensions.encode(w)
+
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
+
+	w.WriteString("END:VRESOURCE\r\n")
 }
 
-func (s *Daylight) valid() error {
-	if err := s.DateTimeStart.valid(); err != nil {
-		return fmt.Errorf(errValidatingProp, cDaylight, cDateTimeStart, err)
+func (s *Resource) valid() error {
+	if err := s.UID.valid(); err != nil {
+		return fmt.Errorf(errValidatingProp, cResource, cUID, err)
 	}
 
-	if err := s.TimezoneOffsetTo.valid(); err != nil {
-		return fmt.Errorf(errValidatingProp, cDaylight, cTimezoneOffsetTo, err)
+	if s.Description != nil {
+		if err := s.Description.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cResource, cDescription, err)
+		}
 	}
 
-	if err := s.TimezoneOffsetFrom.valid(); err != nil {
-		return fmt.Errorf(errValidatingProp, cDaylight, cTimezoneOffsetFrom, err)
+	if s.Geo != nil {
+		if err := s.Geo.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cResource, cGeo, err)
+		}
 	}
 
-	if s.RecurrenceRule != nil {
-		if err := s.RecurrenceRule.valid(); err != nil {
-			return fmt.Errorf(errValidatingProp, cDaylight, cRecurrenceRule, err)
+	if s.Name != nil {
+		if err := s.Name.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cResource, cName, err)
 		}
 	}
 
-	for n := range s.Comment {
-		if err := s.Comment[n].valid(); err != nil {
-			return fmt.Errorf(errValidatingProp, cDaylight, cComment, err)
+	if s.ResourceType != nil {
+		if err := s.ResourceType.valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cResource, cResourceType, err)
 		}
 	}
 
-	for n := range s.RecurrenceDateTimes {
-		if err := s.RecurrenceDateTimes[n].valid(); err != nil {
-			return fmt.Errorf(errValidatingProp, cDaylight, cRecurrenceDateTimes, err)
+	for n := range s.StructuredData {
+		if err := s.StructuredData[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingProp, cResource, cStructuredData, err)
 		}
 	}
 
-	for n := range s.TimezoneName {
-		if err := s.TimezoneName[n].valid(); err != nil {
-			return fmt.Errorf(errValidatingProp, cDaylight, cTimezoneName, err)
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cResource, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cResource, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cResource, err)
 		}
 	}
 
@@ -2866,6 +7532,10 @@ type AlarmAudio struct {
 	GeoLocation   []PropGeoLocation
 	RelatedTo     *PropRelatedTo
 	DefaultAlarm  *PropDefaultAlarm
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
 }
 
 func (s *AlarmAudio) decode(t tokeniser) error {
@@ -2883,17 +7553,27 @@ Loop:
 		params := p.Data[1 : len(p.Data)-1]
 		value := p.Data[len(p.Data)-1].Data
 
+		checkProperty(t, p.Data[0].Data, params, value)
+
 		switch strings.ToUpper(p.Data[0].Data) {
 		case "BEGIN":
 			switch n := strings.ToUpper(value); n {
 			default:
-				if err := decodeDummy(t, n); err != nil {
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
 					return fmt.Errorf(errDecodingType, cAlarmAudio, err)
 				}
+
+				s.Components = append(s.Components, c)
 			}
 		case "TRIGGER":
 			if requiredTrigger {
-				return fmt.Errorf(errMultiple, cAlarmAudio, cTrigger)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmAudio, ErrDuplicateProperty, cTrigger)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredTrigger = true
@@ -2903,122 +7583,237 @@ Loop:
 			}
 		case "DURATION":
 			if s.Duration != nil {
-				return fmt.Errorf(errMultiple, cAlarmAudio, cDuration)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmAudio, ErrDuplicateProperty, cDuration)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Duration = new(PropDuration)
 
 			if err := s.Duration.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmAudio, cDuration, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmAudio, cDuration, err)); err != nil {
+					return err
+				}
+
+				s.Duration = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("DURATION", params)
 		case "REPEAT":
 			if s.Repeat != nil {
-				return fmt.Errorf(errMultiple, cAlarmAudio, cRepeat)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmAudio, ErrDuplicateProperty, cRepeat)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Repeat = new(PropRepeat)
 
 			if err := s.Repeat.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmAudio, cRepeat, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmAudio, cRepeat, err)); err != nil {
+					return err
+				}
+
+				s.Repeat = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("REPEAT", params)
 		case "ATTACH":
 			var e PropAttachment
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmAudio, cAttachment, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmAudio, cAttachment, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Attachment = append(s.Attachment, e)
 		case "UID":
 			if s.UID != nil {
-				return fmt.Errorf(errMultiple, cAlarmAudio, cUID)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmAudio, ErrDuplicateProperty, cUID)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.UID = new(PropUID)
 
 			if err := s.UID.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmAudio, cUID, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmAudio, cUID, err)); err != nil {
+					return err
+				}
+
+				s.UID = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("UID", params)
 		case "ALARM-AGENT":
 			var e PropAlarmAgent
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmAudio, cAlarmAgent, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmAudio, cAlarmAgent, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.AlarmAgent = append(s.AlarmAgent, e)
 		case "STATUS":
 			if s.AlarmStatus != nil {
-				return fmt.Errorf(errMultiple, cAlarmAudio, cAlarmStatus)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmAudio, ErrDuplicateProperty, cAlarmStatus)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.AlarmStatus = new(PropAlarmStatus)
 
 			if err := s.AlarmStatus.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmAudio, cAlarmStatus, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmAudio, cAlarmStatus, err)); err != nil {
+					return err
+				}
+
+				s.AlarmStatus = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("STATUS", params)
 		case "LAST-TRIGGERED":
 			var e PropLastTriggered
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmAudio, cLastTriggered, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmAudio, cLastTriggered, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.LastTriggered = append(s.LastTriggered, e)
+
+			s.PropertyParams.decode("LAST-TRIGGERED", params)
 		case "ACKNOWLEDGED":
 			if s.Acknowledged != nil {
-				return fmt.Errorf(errMultiple, cAlarmAudio, cAcknowledged)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmAudio, ErrDuplicateProperty, cAcknowledged)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Acknowledged = new(PropAcknowledged)
 
 			if err := s.Acknowledged.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmAudio, cAcknowledged, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmAudio, cAcknowledged, err)); err != nil {
+					return err
+				}
+
+				s.Acknowledged = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("ACKNOWLEDGED", params)
 		case "PROXIMITY":
 			if s.Proximity != nil {
-				return fmt.Errorf(errMultiple, cAlarmAudio, cProximity)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmAudio, ErrDuplicateProperty, cProximity)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Proximity = new(PropProximity)
 
 			if err := s.Proximity.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmAudio, cProximity, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmAudio, cProximity, err)); err != nil {
+					return err
+				}
+
+				s.Proximity = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("PROXIMITY", params)
 		case "GEO-LOCATION":
 			var e PropGeoLocation
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmAudio, cGeoLocation, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmAudio, cGeoLocation, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.GeoLocation = append(s.GeoLocation, e)
+
+			s.PropertyParams.decode("GEO-LOCATION", params)
 		case "RELATED-TO":
 			if s.RelatedTo != nil {
-				return fmt.Errorf(errMultiple, cAlarmAudio, cRelatedTo)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmAudio, ErrDuplicateProperty, cRelatedTo)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RelatedTo = new(PropRelatedTo)
 
 			if err := s.RelatedTo.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmAudio, cRelatedTo, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmAudio, cRelatedTo, err)); err != nil {
+					return err
+				}
+
+				s.RelatedTo = nil
 			}
 		case "DEFAULT-ALARM":
 			if s.DefaultAlarm != nil {
-				return fmt.Errorf(errMultiple, cAlarmAudio, cDefaultAlarm)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmAudio, ErrDuplicateProperty, cDefaultAlarm)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.DefaultAlarm = new(PropDefaultAlarm)
 
 			if err := s.DefaultAlarm.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmAudio, cDefaultAlarm, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmAudio, cDefaultAlarm, err)); err != nil {
+					return err
+				}
+
+				s.DefaultAlarm = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("DEFAULT-ALARM", params)
+		case "ACTION":
 		case "END":
-			if value != "VALARM" {
-				return fmt.Errorf(errDecodingType, cAlarmAudio, ErrInvalidEnd)
+			if err := endValue(t, value, "VALARM"); err != nil {
+				return fmt.Errorf(errDecodingType, cAlarmAudio, err)
 			}
 
 			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
 		}
 	}
 
@@ -3037,11 +7832,11 @@ func (s *AlarmAudio) encode(w writer) {
 	s.Trigger.encode(w)
 
 	if s.Duration != nil {
-		s.Duration.encode(w)
+		s.Duration.encode(s.PropertyParams.writer(w, "DURATION"))
 	}
 
 	if s.Repeat != nil {
-		s.Repeat.encode(w)
+		s.Repeat.encode(s.PropertyParams.writer(w, "REPEAT"))
 	}
 
 	for n := range s.Attachment {
@@ -3049,7 +7844,7 @@ func (s *AlarmAudio) encode(w writer) {
 	}
 
 	if s.UID != nil {
-		s.UID.encode(w)
+		s.UID.encode(s.PropertyParams.writer(w, "UID"))
 	}
 
 	for n := range s.AlarmAgent {
@@ -3057,23 +7852,23 @@ func (s *AlarmAudio) encode(w writer) {
 	}
 
 	if s.AlarmStatus != nil {
-		s.AlarmStatus.encode(w)
+		s.AlarmStatus.encode(s.PropertyParams.writer(w, "STATUS"))
 	}
 
 	for n := range s.LastTriggered {
-		s.LastTriggered[n].encode(w)
+		s.LastTriggered[n].encode(s.PropertyParams.writer(w, "LAST-TRIGGERED"))
 	}
 
 	if s.Acknowledged != nil {
-		s.Acknowledged.encode(w)
+		s.Acknowledged.encode(s.PropertyParams.writer(w, "ACKNOWLEDGED"))
 	}
 
 	if s.Proximity != nil {
-		s.Proximity.encode(w)
+		s.Proximity.encode(s.PropertyParams.writer(w, "PROXIMITY"))
 	}
 
 	for n := range s.GeoLocation {
-		s.GeoLocation[n].encode(w)
+		s.GeoLocation[n].encode(s.PropertyParams.writer(w, "GEO-LOCATION"))
 	}
 
 	if s.RelatedTo != nil {
@@ -3081,9 +7876,14 @@ func (s *AlarmAudio) encode(w writer) {
 	}
 
 	if s.DefaultAlarm != nil {
-		s.DefaultAlarm.encode(w)
+		s.DefaultAlarm.encode(s.PropertyParams.writer(w, "DEFAULT-ALARM"))
 	}
 
+	s.Extensions.encode(w)
+
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
 }
 
 func (s *AlarmAudio) valid() error {
@@ -3163,6 +7963,20 @@ func (s *AlarmAudio) valid() error {
 		}
 	}
 
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAlarmAudio, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAlarmAudio, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cAlarmAudio, err)
+		}
+	}
+
 	return nil
 }
 
@@ -3181,6 +7995,10 @@ type AlarmDisplay struct {
 	GeoLocation   []PropGeoLocation
 	RelatedTo     *PropRelatedTo
 	DefaultAlarm  *PropDefaultAlarm
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
 }
 
 func (s *AlarmDisplay) decode(t tokeniser) error {
@@ -3198,17 +8016,27 @@ Loop:
 		params := p.Data[1 : len(p.Data)-1]
 		value := p.Data[len(p.Data)-1].Data
 
+		checkProperty(t, p.Data[0].Data, params, value)
+
 		switch strings.ToUpper(p.Data[0].Data) {
 		case "BEGIN":
 			switch n := strings.ToUpper(value); n {
 			default:
-				if err := decodeDummy(t, n); err != nil {
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
 					return fmt.Errorf(errDecodingType, cAlarmDisplay, err)
 				}
+
+				s.Components = append(s.Components, c)
 			}
 		case "DESCRIPTION":
 			if requiredDescription {
-				return fmt.Errorf(errMultiple, cAlarmDisplay, cDescription)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmDisplay, ErrDuplicateProperty, cDescription)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredDescription = true
@@ -3218,7 +8046,11 @@ Loop:
 			}
 		case "TRIGGER":
 			if requiredTrigger {
-				return fmt.Errorf(errMultiple, cAlarmDisplay, cTrigger)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmDisplay, ErrDuplicateProperty, cTrigger)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredTrigger = true
@@ -3228,114 +8060,225 @@ Loop:
 			}
 		case "DURATION":
 			if s.Duration != nil {
-				return fmt.Errorf(errMultiple, cAlarmDisplay, cDuration)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmDisplay, ErrDuplicateProperty, cDuration)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Duration = new(PropDuration)
 
 			if err := s.Duration.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmDisplay, cDuration, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmDisplay, cDuration, err)); err != nil {
+					return err
+				}
+
+				s.Duration = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("DURATION", params)
 		case "REPEAT":
 			if s.Repeat != nil {
-				return fmt.Errorf(errMultiple, cAlarmDisplay, cRepeat)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmDisplay, ErrDuplicateProperty, cRepeat)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Repeat = new(PropRepeat)
 
 			if err := s.Repeat.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmDisplay, cRepeat, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmDisplay, cRepeat, err)); err != nil {
+					return err
+				}
+
+				s.Repeat = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("REPEAT", params)
 		case "UID":
 			if s.UID != nil {
-				return fmt.Errorf(errMultiple, cAlarmDisplay, cUID)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmDisplay, ErrDuplicateProperty, cUID)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.UID = new(PropUID)
 
 			if err := s.UID.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmDisplay, cUID, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmDisplay, cUID, err)); err != nil {
+					return err
+				}
+
+				s.UID = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("UID", params)
 		case "ALARM-AGENT":
 			var e PropAlarmAgent
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmDisplay, cAlarmAgent, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmDisplay, cAlarmAgent, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.AlarmAgent = append(s.AlarmAgent, e)
 		case "STATUS":
 			if s.AlarmStatus != nil {
-				return fmt.Errorf(errMultiple, cAlarmDisplay, cAlarmStatus)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmDisplay, ErrDuplicateProperty, cAlarmStatus)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.AlarmStatus = new(PropAlarmStatus)
 
 			if err := s.AlarmStatus.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmDisplay, cAlarmStatus, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmDisplay, cAlarmStatus, err)); err != nil {
+					return err
+				}
+
+				s.AlarmStatus = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("STATUS", params)
 		case "LAST-TRIGGERED":
 			var e PropLastTriggered
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmDisplay, cLastTriggered, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmDisplay, cLastTriggered, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.LastTriggered = append(s.LastTriggered, e)
+
+			s.PropertyParams.decode("LAST-TRIGGERED", params)
 		case "ACKNOWLEDGED":
 			if s.Acknowledged != nil {
-				return fmt.Errorf(errMultiple, cAlarmDisplay, cAcknowledged)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmDisplay, ErrDuplicateProperty, cAcknowledged)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Acknowledged = new(PropAcknowledged)
 
 			if err := s.Acknowledged.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmDisplay, cAcknowledged, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmDisplay, cAcknowledged, err)); err != nil {
+					return err
+				}
+
+				s.Acknowledged = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("ACKNOWLEDGED", params)
 		case "PROXIMITY":
 			if s.Proximity != nil {
-				return fmt.Errorf(errMultiple, cAlarmDisplay, cProximity)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmDisplay, ErrDuplicateProperty, cProximity)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Proximity = new(PropProximity)
 
 			if err := s.Proximity.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmDisplay, cProximity, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmDisplay, cProximity, err)); err != nil {
+					return err
+				}
+
+				s.Proximity = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("PROXIMITY", params)
 		case "GEO-LOCATION":
 			var e PropGeoLocation
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmDisplay, cGeoLocation, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmDisplay, cGeoLocation, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.GeoLocation = append(s.GeoLocation, e)
+
+			s.PropertyParams.decode("GEO-LOCATION", params)
 		case "RELATED-TO":
 			if s.RelatedTo != nil {
-				return fmt.Errorf(errMultiple, cAlarmDisplay, cRelatedTo)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmDisplay, ErrDuplicateProperty, cRelatedTo)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RelatedTo = new(PropRelatedTo)
 
 			if err := s.RelatedTo.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmDisplay, cRelatedTo, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmDisplay, cRelatedTo, err)); err != nil {
+					return err
+				}
+
+				s.RelatedTo = nil
 			}
 		case "DEFAULT-ALARM":
 			if s.DefaultAlarm != nil {
-				return fmt.Errorf(errMultiple, cAlarmDisplay, cDefaultAlarm)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmDisplay, ErrDuplicateProperty, cDefaultAlarm)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.DefaultAlarm = new(PropDefaultAlarm)
 
 			if err := s.DefaultAlarm.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmDisplay, cDefaultAlarm, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmDisplay, cDefaultAlarm, err)); err != nil {
+					return err
+				}
+
+				s.DefaultAlarm = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("DEFAULT-ALARM", params)
+		case "ACTION":
 		case "END":
-			if value != "VALARM" {
-				return fmt.Errorf(errDecodingType, cAlarmDisplay, ErrInvalidEnd)
+			if err := endValue(t, value, "VALARM"); err != nil {
+				return fmt.Errorf(errDecodingType, cAlarmDisplay, err)
 			}
 
 			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
 		}
 	}
 
@@ -3355,15 +8298,15 @@ func (s *AlarmDisplay) encode(w writer) {
 	s.Trigger.encode(w)
 
 	if s.Duration != nil {
-		s.Duration.encode(w)
+		s.Duration.encode(s.PropertyParams.writer(w, "DURATION"))
 	}
 
 	if s.Repeat != nil {
-		s.Repeat.encode(w)
+		s.Repeat.encode(s.PropertyParams.writer(w, "REPEAT"))
 	}
 
 	if s.UID != nil {
-		s.UID.encode(w)
+		s.UID.encode(s.PropertyParams.writer(w, "UID"))
 	}
 
 	for n := range s.AlarmAgent {
@@ -3371,23 +8314,23 @@ func (s *AlarmDisplay) encode(w writer) {
 	}
 
 	if s.AlarmStatus != nil {
-		s.AlarmStatus.encode(w)
+		s.AlarmStatus.encode(s.PropertyParams.writer(w, "STATUS"))
 	}
 
 	for n := range s.LastTriggered {
-		s.LastTriggered[n].encode(w)
+		s.LastTriggered[n].encode(s.PropertyParams.writer(w, "LAST-TRIGGERED"))
 	}
 
 	if s.Acknowledged != nil {
-		s.Acknowledged.encode(w)
+		s.Acknowledged.encode(s.PropertyParams.writer(w, "ACKNOWLEDGED"))
 	}
 
 	if s.Proximity != nil {
-		s.Proximity.encode(w)
+		s.Proximity.encode(s.PropertyParams.writer(w, "PROXIMITY"))
 	}
 
 	for n := range s.GeoLocation {
-		s.GeoLocation[n].encode(w)
+		s.GeoLocation[n].encode(s.PropertyParams.writer(w, "GEO-LOCATION"))
 	}
 
 	if s.RelatedTo != nil {
@@ -3395,9 +8338,14 @@ func (s *AlarmDisplay) encode(w writer) {
 	}
 
 	if s.DefaultAlarm != nil {
-		s.DefaultAlarm.encode(w)
+		s.DefaultAlarm.encode(s.PropertyParams.writer(w, "DEFAULT-ALARM"))
 	}
 
+	s.Extensions.encode(w)
+
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
 }
 
 func (s *AlarmDisplay) valid() error {
@@ -3475,6 +8423,20 @@ func (s *AlarmDisplay) valid() error {
 		}
 	}
 
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAlarmDisplay, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAlarmDisplay, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cAlarmDisplay, err)
+		}
+	}
+
 	return nil
 }
 
@@ -3495,6 +8457,10 @@ type AlarmEmail struct {
 	GeoLocation   []PropGeoLocation
 	RelatedTo     *PropRelatedTo
 	DefaultAlarm  *PropDefaultAlarm
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
 }
 
 func (s *AlarmEmail) decode(t tokeniser) error {
@@ -3512,17 +8478,27 @@ Loop:
 		params := p.Data[1 : len(p.Data)-1]
 		value := p.Data[len(p.Data)-1].Data
 
+		checkProperty(t, p.Data[0].Data, params, value)
+
 		switch strings.ToUpper(p.Data[0].Data) {
 		case "BEGIN":
 			switch n := strings.ToUpper(value); n {
 			default:
-				if err := decodeDummy(t, n); err != nil {
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
 					return fmt.Errorf(errDecodingType, cAlarmEmail, err)
 				}
+
+				s.Components = append(s.Components, c)
 			}
 		case "DESCRIPTION":
 			if requiredDescription {
-				return fmt.Errorf(errMultiple, cAlarmEmail, cDescription)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmEmail, ErrDuplicateProperty, cDescription)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredDescription = true
@@ -3532,7 +8508,11 @@ Loop:
 			}
 		case "TRIGGER":
 			if requiredTrigger {
-				return fmt.Errorf(errMultiple, cAlarmEmail, cTrigger)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmEmail, ErrDuplicateProperty, cTrigger)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredTrigger = true
@@ -3542,7 +8522,11 @@ Loop:
 			}
 		case "SUMMARY":
 			if requiredSummary {
-				return fmt.Errorf(errMultiple, cAlarmEmail, cSummary)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmEmail, ErrDuplicateProperty, cSummary)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredSummary = true
@@ -3552,124 +8536,243 @@ Loop:
 			}
 		case "ATTENDEE":
 			if s.Attendee != nil {
-				return fmt.Errorf(errMultiple, cAlarmEmail, cAttendee)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmEmail, ErrDuplicateProperty, cAttendee)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Attendee = new(PropAttendee)
 
 			if err := s.Attendee.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmEmail, cAttendee, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmEmail, cAttendee, err)); err != nil {
+					return err
+				}
+
+				s.Attendee = nil
 			}
 		case "DURATION":
 			if s.Duration != nil {
-				return fmt.Errorf(errMultiple, cAlarmEmail, cDuration)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmEmail, ErrDuplicateProperty, cDuration)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Duration = new(PropDuration)
 
 			if err := s.Duration.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmEmail, cDuration, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmEmail, cDuration, err)); err != nil {
+					return err
+				}
+
+				s.Duration = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("DURATION", params)
 		case "REPEAT":
 			if s.Repeat != nil {
-				return fmt.Errorf(errMultiple, cAlarmEmail, cRepeat)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmEmail, ErrDuplicateProperty, cRepeat)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Repeat = new(PropRepeat)
 
 			if err := s.Repeat.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmEmail, cRepeat, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmEmail, cRepeat, err)); err != nil {
+					return err
+				}
+
+				s.Repeat = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("REPEAT", params)
 		case "UID":
 			if s.UID != nil {
-				return fmt.Errorf(errMultiple, cAlarmEmail, cUID)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmEmail, ErrDuplicateProperty, cUID)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.UID = new(PropUID)
 
 			if err := s.UID.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmEmail, cUID, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmEmail, cUID, err)); err != nil {
+					return err
+				}
+
+				s.UID = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("UID", params)
 		case "ALARM-AGENT":
 			var e PropAlarmAgent
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmEmail, cAlarmAgent, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmEmail, cAlarmAgent, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.AlarmAgent = append(s.AlarmAgent, e)
 		case "STATUS":
 			if s.AlarmStatus != nil {
-				return fmt.Errorf(errMultiple, cAlarmEmail, cAlarmStatus)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmEmail, ErrDuplicateProperty, cAlarmStatus)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.AlarmStatus = new(PropAlarmStatus)
 
 			if err := s.AlarmStatus.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmEmail, cAlarmStatus, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmEmail, cAlarmStatus, err)); err != nil {
+					return err
+				}
+
+				s.AlarmStatus = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("STATUS", params)
 		case "LAST-TRIGGERED":
 			var e PropLastTriggered
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmEmail, cLastTriggered, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmEmail, cLastTriggered, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.LastTriggered = append(s.LastTriggered, e)
+
+			s.PropertyParams.decode("LAST-TRIGGERED", params)
 		case "ACKNOWLEDGED":
 			if s.Acknowledged != nil {
-				return fmt.Errorf(errMultiple, cAlarmEmail, cAcknowledged)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmEmail, ErrDuplicateProperty, cAcknowledged)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Acknowledged = new(PropAcknowledged)
 
 			if err := s.Acknowledged.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmEmail, cAcknowledged, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmEmail, cAcknowledged, err)); err != nil {
+					return err
+				}
+
+				s.Acknowledged = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("ACKNOWLEDGED", params)
 		case "PROXIMITY":
 			if s.Proximity != nil {
-				return fmt.Errorf(errMultiple, cAlarmEmail, cProximity)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmEmail, ErrDuplicateProperty, cProximity)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Proximity = new(PropProximity)
 
 			if err := s.Proximity.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmEmail, cProximity, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmEmail, cProximity, err)); err != nil {
+					return err
+				}
+
+				s.Proximity = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("PROXIMITY", params)
 		case "GEO-LOCATION":
 			var e PropGeoLocation
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmEmail, cGeoLocation, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmEmail, cGeoLocation, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.GeoLocation = append(s.GeoLocation, e)
+
+			s.PropertyParams.decode("GEO-LOCATION", params)
 		case "RELATED-TO":
 			if s.RelatedTo != nil {
-				return fmt.Errorf(errMultiple, cAlarmEmail, cRelatedTo)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmEmail, ErrDuplicateProperty, cRelatedTo)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RelatedTo = new(PropRelatedTo)
 
 			if err := s.RelatedTo.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmEmail, cRelatedTo, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmEmail, cRelatedTo, err)); err != nil {
+					return err
+				}
+
+				s.RelatedTo = nil
 			}
 		case "DEFAULT-ALARM":
 			if s.DefaultAlarm != nil {
-				return fmt.Errorf(errMultiple, cAlarmEmail, cDefaultAlarm)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmEmail, ErrDuplicateProperty, cDefaultAlarm)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.DefaultAlarm = new(PropDefaultAlarm)
 
 			if err := s.DefaultAlarm.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmEmail, cDefaultAlarm, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmEmail, cDefaultAlarm, err)); err != nil {
+					return err
+				}
+
+				s.DefaultAlarm = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("DEFAULT-ALARM", params)
+		case "ACTION":
 		case "END":
-			if value != "VALARM" {
-				return fmt.Errorf(errDecodingType, cAlarmEmail, ErrInvalidEnd)
+			if err := endValue(t, value, "VALARM"); err != nil {
+				return fmt.Errorf(errDecodingType, cAlarmEmail, err)
 			}
 
 			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
 		}
 	}
 
@@ -3698,15 +8801,15 @@ func (s *AlarmEmail) encode(w writer) {
 	}
 
 	if s.Duration != nil {
-		s.Duration.encode(w)
+		s.Duration.encode(s.PropertyParams.writer(w, "DURATION"))
 	}
 
 	if s.Repeat != nil {
-		s.Repeat.encode(w)
+		s.Repeat.encode(s.PropertyParams.writer(w, "REPEAT"))
 	}
 
 	if s.UID != nil {
-		s.UID.encode(w)
+		s.UID.encode(s.PropertyParams.writer(w, "UID"))
 	}
 
 	for n := range s.AlarmAgent {
@@ -3714,23 +8817,23 @@ func (s *AlarmEmail) encode(w writer) {
 	}
 
 	if s.AlarmStatus != nil {
-		s.AlarmStatus.encode(w)
+		s.AlarmStatus.encode(s.PropertyParams.writer(w, "STATUS"))
 	}
 
 	for n := range s.LastTriggered {
-		s.LastTriggered[n].encode(w)
+		s.LastTriggered[n].encode(s.PropertyParams.writer(w, "LAST-TRIGGERED"))
 	}
 
 	if s.Acknowledged != nil {
-		s.Acknowledged.encode(w)
+		s.Acknowledged.encode(s.PropertyParams.writer(w, "ACKNOWLEDGED"))
 	}
 
 	if s.Proximity != nil {
-		s.Proximity.encode(w)
+		s.Proximity.encode(s.PropertyParams.writer(w, "PROXIMITY"))
 	}
 
 	for n := range s.GeoLocation {
-		s.GeoLocation[n].encode(w)
+		s.GeoLocation[n].encode(s.PropertyParams.writer(w, "GEO-LOCATION"))
 	}
 
 	if s.RelatedTo != nil {
@@ -3738,9 +8841,14 @@ func (s *AlarmEmail) encode(w writer) {
 	}
 
 	if s.DefaultAlarm != nil {
-		s.DefaultAlarm.encode(w)
+		s.DefaultAlarm.encode(s.PropertyParams.writer(w, "DEFAULT-ALARM"))
 	}
 
+	s.Extensions.encode(w)
+
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
 }
 
 func (s *AlarmEmail) valid() error {
@@ -3828,6 +8936,20 @@ func (s *AlarmEmail) valid() error {
 		}
 	}
 
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAlarmEmail, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAlarmEmail, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cAlarmEmail, err)
+		}
+	}
+
 	return nil
 }
 
@@ -3845,6 +8967,10 @@ type AlarmURI struct {
 	GeoLocation   []PropGeoLocation
 	RelatedTo     *PropRelatedTo
 	DefaultAlarm  *PropDefaultAlarm
+
+	Extensions     ExtensionProperties
+	PropertyParams PropertyParams
+	Components     []Component
 }
 
 func (s *AlarmURI) decode(t tokeniser) error {
@@ -3862,17 +8988,27 @@ Loop:
 		params := p.Data[1 : len(p.Data)-1]
 		value := p.Data[len(p.Data)-1].Data
 
+		checkProperty(t, p.Data[0].Data, params, value)
+
 		switch strings.ToUpper(p.Data[0].Data) {
 		case "BEGIN":
 			switch n := strings.ToUpper(value); n {
 			default:
-				if err := decodeDummy(t, n); err != nil {
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
 					return fmt.Errorf(errDecodingType, cAlarmURI, err)
 				}
+
+				s.Components = append(s.Components, c)
 			}
 		case "URI":
 			if requiredURI {
-				return fmt.Errorf(errMultiple, cAlarmURI, cURI)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmURI, ErrDuplicateProperty, cURI)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			requiredURI = true
@@ -3880,116 +9016,229 @@ Loop:
 			if err := s.URI.decode(params, value); err != nil {
 				return fmt.Errorf(errDecodingProp, cAlarmURI, cURI, err)
 			}
+
+			s.PropertyParams.decode("URI", params)
 		case "DURATION":
 			if s.Duration != nil {
-				return fmt.Errorf(errMultiple, cAlarmURI, cDuration)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmURI, ErrDuplicateProperty, cDuration)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Duration = new(PropDuration)
 
 			if err := s.Duration.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmURI, cDuration, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmURI, cDuration, err)); err != nil {
+					return err
+				}
+
+				s.Duration = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("DURATION", params)
 		case "REPEAT":
 			if s.Repeat != nil {
-				return fmt.Errorf(errMultiple, cAlarmURI, cRepeat)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmURI, ErrDuplicateProperty, cRepeat)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Repeat = new(PropRepeat)
 
 			if err := s.Repeat.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmURI, cRepeat, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmURI, cRepeat, err)); err != nil {
+					return err
+				}
+
+				s.Repeat = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("REPEAT", params)
 		case "UID":
 			if s.UID != nil {
-				return fmt.Errorf(errMultiple, cAlarmURI, cUID)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmURI, ErrDuplicateProperty, cUID)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.UID = new(PropUID)
 
 			if err := s.UID.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmURI, cUID, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmURI, cUID, err)); err != nil {
+					return err
+				}
+
+				s.UID = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("UID", params)
 		case "ALARM-AGENT":
 			var e PropAlarmAgent
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmURI, cAlarmAgent, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmURI, cAlarmAgent, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.AlarmAgent = append(s.AlarmAgent, e)
 		case "STATUS":
 			if s.AlarmStatus != nil {
-				return fmt.Errorf(errMultiple, cAlarmURI, cAlarmStatus)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmURI, ErrDuplicateProperty, cAlarmStatus)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.AlarmStatus = new(PropAlarmStatus)
 
 			if err := s.AlarmStatus.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmURI, cAlarmStatus, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmURI, cAlarmStatus, err)); err != nil {
+					return err
+				}
+
+				s.AlarmStatus = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("STATUS", params)
 		case "LAST-TRIGGERED":
 			var e PropLastTriggered
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmURI, cLastTriggered, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmURI, cLastTriggered, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.LastTriggered = append(s.LastTriggered, e)
+
+			s.PropertyParams.decode("LAST-TRIGGERED", params)
 		case "ACKNOWLEDGED":
 			if s.Acknowledged != nil {
-				return fmt.Errorf(errMultiple, cAlarmURI, cAcknowledged)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmURI, ErrDuplicateProperty, cAcknowledged)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Acknowledged = new(PropAcknowledged)
 
 			if err := s.Acknowledged.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmURI, cAcknowledged, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmURI, cAcknowledged, err)); err != nil {
+					return err
+				}
+
+				s.Acknowledged = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("ACKNOWLEDGED", params)
 		case "PROXIMITY":
 			if s.Proximity != nil {
-				return fmt.Errorf(errMultiple, cAlarmURI, cProximity)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmURI, ErrDuplicateProperty, cProximity)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.Proximity = new(PropProximity)
 
 			if err := s.Proximity.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmURI, cProximity, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmURI, cProximity, err)); err != nil {
+					return err
+				}
+
+				s.Proximity = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("PROXIMITY", params)
 		case "GEO-LOCATION":
 			var e PropGeoLocation
 
 			if err := e.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmURI, cGeoLocation, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmURI, cGeoLocation, err)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.GeoLocation = append(s.GeoLocation, e)
+
+			s.PropertyParams.decode("GEO-LOCATION", params)
 		case "RELATED-TO":
 			if s.RelatedTo != nil {
-				return fmt.Errorf(errMultiple, cAlarmURI, cRelatedTo)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmURI, ErrDuplicateProperty, cRelatedTo)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.RelatedTo = new(PropRelatedTo)
 
 			if err := s.RelatedTo.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmURI, cRelatedTo, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmURI, cRelatedTo, err)); err != nil {
+					return err
+				}
+
+				s.RelatedTo = nil
 			}
 		case "DEFAULT-ALARM":
 			if s.DefaultAlarm != nil {
-				return fmt.Errorf(errMultiple, cAlarmURI, cDefaultAlarm)
+				if err := recoverable(t, fmt.Errorf(errMultiple, cAlarmURI, ErrDuplicateProperty, cDefaultAlarm)); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			s.DefaultAlarm = new(PropDefaultAlarm)
 
 			if err := s.DefaultAlarm.decode(params, value); err != nil {
-				return fmt.Errorf(errDecodingProp, cAlarmURI, cDefaultAlarm, err)
+				if err = recoverable(t, fmt.Errorf(errDecodingProp, cAlarmURI, cDefaultAlarm, err)); err != nil {
+					return err
+				}
+
+				s.DefaultAlarm = nil
+
+				continue
 			}
+
+			s.PropertyParams.decode("DEFAULT-ALARM", params)
+		case "ACTION":
 		case "END":
-			if value != "VALARM" {
-				return fmt.Errorf(errDecodingType, cAlarmURI, ErrInvalidEnd)
+			if err := endValue(t, value, "VALARM"); err != nil {
+				return fmt.Errorf(errDecodingType, cAlarmURI, err)
 			}
 
 			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
 		}
 	}
 
@@ -4009,18 +9258,18 @@ Loop:
 }
 
 func (s *AlarmURI) encode(w writer) {
-	s.URI.encode(w)
+	s.URI.encode(s.PropertyParams.writer(w, "URI"))
 
 	if s.Duration != nil {
-		s.Duration.encode(w)
+		s.Duration.encode(s.PropertyParams.writer(w, "DURATION"))
 	}
 
 	if s.Repeat != nil {
-		s.Repeat.encode(w)
+		s.Repeat.encode(s.PropertyParams.writer(w, "REPEAT"))
 	}
 
 	if s.UID != nil {
-		s.UID.encode(w)
+		s.UID.encode(s.PropertyParams.writer(w, "UID"))
 	}
 
 	for n := range s.AlarmAgent {
@@ -4028,23 +9277,23 @@ func (s *AlarmURI) encode(w writer) {
 	}
 
 	if s.AlarmStatus != nil {
-		s.AlarmStatus.encode(w)
+		s.AlarmStatus.encode(s.PropertyParams.writer(w, "STATUS"))
 	}
 
 	for n := range s.LastTriggered {
-		s.LastTriggered[n].encode(w)
+		s.LastTriggered[n].encode(s.PropertyParams.writer(w, "LAST-TRIGGERED"))
 	}
 
 	if s.Acknowledged != nil {
-		s.Acknowledged.encode(w)
+		s.Acknowledged.encode(s.PropertyParams.writer(w, "ACKNOWLEDGED"))
 	}
 
 	if s.Proximity != nil {
-		s.Proximity.encode(w)
+		s.Proximity.encode(s.PropertyParams.writer(w, "PROXIMITY"))
 	}
 
 	for n := range s.GeoLocation {
-		s.GeoLocation[n].encode(w)
+		s.GeoLocation[n].encode(s.PropertyParams.writer(w, "GEO-LOCATION"))
 	}
 
 	if s.RelatedTo != nil {
@@ -4052,9 +9301,14 @@ func (s *AlarmURI) encode(w writer) {
 	}
 
 	if s.DefaultAlarm != nil {
-		s.DefaultAlarm.encode(w)
+		s.DefaultAlarm.encode(s.PropertyParams.writer(w, "DEFAULT-ALARM"))
 	}
 
+	s.Extensions.encode(w)
+
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
 }
 
 func (s *AlarmURI) valid() error {
@@ -4128,11 +9382,28 @@ func (s *AlarmURI) valid() error {
 		}
 	}
 
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAlarmURI, err)
+	}
+
+	if err := s.PropertyParams.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAlarmURI, err)
+	}
+
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cAlarmURI, err)
+		}
+	}
+
 	return nil
 }
 
 // AlarmNone.
-type AlarmNone struct{}
+type AlarmNone struct {
+	Extensions ExtensionProperties
+	Components []Component
+}
 
 func (s *AlarmNone) decode(t tokeniser) error {
 Loop:
@@ -4144,68 +9415,69 @@ Loop:
 			return fmt.Errorf(errDecodingType, cAlarmNone, io.ErrUnexpectedEOF)
 		}
 
+		params := p.Data[1 : len(p.Data)-1]
 		value := p.Data[len(p.Data)-1].Data
 
+		checkProperty(t, p.Data[0].Data, params, value)
+
 		switch strings.ToUpper(p.Data[0].Data) {
 		case "BEGIN":
 			switch n := strings.ToUpper(value); n {
 			default:
-				if err := decodeDummy(t, n); err != nil {
+				var c Component
+
+				if err := c.decode(t, n); err != nil {
 					return fmt.Errorf(errDecodingType, cAlarmNone, err)
 				}
+
+				s.Components = append(s.Components, c)
 			}
+		case "ACTION":
 		case "END":
-			if value != "VALARM" {
-				return fmt.Errorf(errDecodingType, cAlarmNone, ErrInvalidEnd)
+			if err := endValue(t, value, "VALARM"); err != nil {
+				return fmt.Errorf(errDecodingType, cAlarmNone, err)
 			}
 
 			break Loop
+		default:
+			s.Extensions = append(s.Extensions, ExtensionProperty{Name: strings.ToUpper(p.Data[0].Data), Params: extensionParams(params), Value: value})
 		}
 	}
 	return nil
 }
 
 func (s *AlarmNone) encode(w writer) {
-}
+	s.Extensions.encode(w)
 
-func (s *AlarmNone) valid() error {
-	return nil
+	for n := range s.Components {
+		s.Components[n].encode(w)
+	}
 }
 
-// decodeDummy reads unknown sections, discarding the data.
-func decodeDummy(t tokeniser, n string) error {
-	for {
-		p, err := t.GetPhrase()
-		if err != nil {
-			return err
-		} else if p.Type == parser.PhraseDone {
-			return io.ErrUnexpectedEOF
-		}
-
-		switch strings.ToUpper(p.Data[0].Data) {
-		case "BEGIN":
-			if err := decodeDummy(t, p.Data[len(p.Data)-1].Data); err != nil {
-				return err
-			}
-		case "END":
-			if strings.ToUpper(p.Data[len(p.Data)-1].Data) == n {
-				return nil
-			}
+func (s *AlarmNone) valid() error {
+	if err := s.Extensions.valid(); err != nil {
+		return fmt.Errorf(errValidatingType, cAlarmNone, err)
+	}
 
-			return ErrInvalidEnd
+	for n := range s.Components {
+		if err := s.Components[n].valid(); err != nil {
+			return fmt.Errorf(errValidatingType, cAlarmNone, err)
 		}
 	}
+
+	return nil
 }
 
 // Errors.
 var (
+	ErrDuplicateProperty = errors.New("duplicate property")
 	ErrInvalidEnd        = errors.New("invalid end of section")
 	ErrMissingRequired   = errors.New("required property missing")
 	ErrRequirementNotMet = errors.New("requirement not met")
 )
 
 const (
-	errMultiple   = "error decoding %s: multiple %s"
+	errMultiple   = "error decoding %s: %w: %s"
 	cCalendar     = "Calendar"
 	cEvent        = "Event"
 	cTodo         = "Todo"
@@ -4213,6 +9485,10 @@ const (
 	cTimezone     = "Timezone"
 	cStandard     = "Standard"
 	cDaylight     = "Daylight"
+	cAvailability = "Availability"
+	cAvailable    = "Available"
+	cParticipant  = "Participant"
+	cResource     = "Resource"
 	cAlarmAudio   = "AlarmAudio"
 	cAlarmDisplay = "AlarmDisplay"
 	cAlarmEmail   = "AlarmEmail"
@@ -3,6 +3,7 @@ package ics
 import (
 	"errors"
 	"io"
+	"strings"
 
 	"vimagination.zapto.org/parser"
 )
@@ -23,8 +24,8 @@ func (p *psuedoTokeniser) GetPhrase() (parser.Phrase, error) {
 	return ph, nil
 }
 
-// AlarmType is an interface this is fulfilled by AlarmAudio, AlarmDisplay and
-// AlarmEmail.
+// AlarmType is an interface this is fulfilled by AlarmAudio, AlarmDisplay,
+// AlarmEmail, AlarmURI, AlarmNone, and AlarmUnknown.
 type AlarmType interface {
 	section
 	Type() string
@@ -36,26 +37,44 @@ type Alarm struct {
 }
 
 func (a *Alarm) decode(t tokeniser) error {
-	var pt psuedoTokeniser
+	var (
+		pt    psuedoTokeniser
+		pos   []position
+		depth int
+	)
+
+	l, _ := t.(*lineTokeniser)
 
 Loop:
 	for {
 		ph, err := t.GetPhrase()
 		if err != nil {
 			return err
+		} else if ph.Type == parser.PhraseDone {
+			return io.ErrUnexpectedEOF
 		}
 
 		pt = append(pt, ph)
 
-		switch ph.Data[0].Data {
+		if l != nil {
+			pos = append(pos, l.pos)
+		}
+
+		switch strings.ToUpper(ph.Data[0].Data) {
 		case "BEGIN":
-			return ErrInvalidStructure
+			depth++
 		case "ACTION":
-			if a.AlarmType != nil {
-				return ErrInvalidStructure
+			if depth > 0 {
+				continue
+			} else if a.AlarmType != nil {
+				if err := recoverable(t, ErrInvalidStructure); err != nil {
+					return err
+				}
+
+				continue
 			}
 
-			switch ph.Data[len(ph.Data)-1].Data {
+			switch action := ph.Data[len(ph.Data)-1].Data; strings.ToUpper(action) {
 			case "AUDIO":
 				a.AlarmType = new(AlarmAudio)
 			case "DISPLAY":
@@ -66,14 +85,30 @@ Loop:
 				a.AlarmType = new(AlarmURI)
 			case "NONE":
 				a.AlarmType = new(AlarmNone)
+			default:
+				if recoverable(t, ErrUnknownAlarmAction) == nil {
+					a.AlarmType = &AlarmUnknown{Action: action}
+				}
 			}
 		case "END":
-			break Loop
+			if depth == 0 {
+				break Loop
+			}
+
+			depth--
 		}
 	}
 
 	if a.AlarmType == nil {
-		return ErrMissingAlarmAction
+		if err := recoverable(t, ErrMissingAlarmAction); err != nil {
+			return err
+		}
+
+		a.AlarmType = new(AlarmNone)
+	}
+
+	if l != nil {
+		return l.replay(pt, pos, a.AlarmType.decode)
 	}
 
 	return a.AlarmType.decode(&pt)
@@ -93,6 +128,10 @@ func (a *Alarm) encode(w writer) {
 		w.WriteString("ACTION:URI\r\n")
 	case *AlarmNone:
 		w.WriteString("ACTION:NONE\r\n")
+	case *AlarmUnknown:
+		w.WriteString("ACTION:")
+		w.WriteString(a.AlarmType.Type())
+		w.WriteString("\r\n")
 	}
 
 	a.AlarmType.encode(w)
@@ -101,7 +140,7 @@ func (a *Alarm) encode(w writer) {
 
 func (a *Alarm) valid() error {
 	switch a.AlarmType.(type) {
-	case *AlarmAudio, *AlarmDisplay, *AlarmEmail, *AlarmURI, *AlarmNone:
+	case *AlarmAudio, *AlarmDisplay, *AlarmEmail, *AlarmURI, *AlarmNone, *AlarmUnknown:
 		return a.AlarmType.valid()
 	}
 
@@ -133,9 +172,31 @@ func (AlarmNone) Type() string {
 	return "NONE"
 }
 
+// AlarmUnknown is an alarm with an unrecognised ACTION, which is only produced
+// when decoding leniently. The properties and components of the alarm are
+// kept as they are in AlarmNone.
+type AlarmUnknown struct {
+	Action string
+	AlarmNone
+}
+
+// Type returns the ACTION of the alarm.
+func (a AlarmUnknown) Type() string {
+	return a.Action
+}
+
+func (a *AlarmUnknown) valid() error {
+	if !validName(a.Action) {
+		return ErrInvalidAlarm
+	}
+
+	return a.AlarmNone.valid()
+}
+
 // Errors.
 var (
 	ErrInvalidStructure   = errors.New("invalid structure")
 	ErrMissingAlarmAction = errors.New("missing alarm action")
+	ErrUnknownAlarmAction = errors.New("unknown alarm action")
 	ErrInvalidAlarm       = errors.New("invalid alarm type")
 )
@@ -0,0 +1,210 @@
+package ics
+
+import (
+	"sort"
+	"time"
+)
+
+// SlotAttendee is an Attendee, along with their free/busy data, to be
+// considered when finding slots.
+//
+// An Attendee with no, or an unrecognised, ParticipationRole is treated as a
+// required participant, and a Chair is also required. Non-participants are
+// ignored.
+type SlotAttendee struct {
+	Attendee PropAttendee
+	FreeBusy []FreeBusy
+}
+
+// WorkingHours is a period, between two wall clock offsets from midnight, on a
+// day of the week.
+type WorkingHours struct {
+	Weekday    time.Weekday
+	Start, End time.Duration
+}
+
+// SlotQuery describes the search for a time for a meeting.
+type SlotQuery struct {
+	Attendees []SlotAttendee
+
+	// Start and End are the time range in which to search.
+	Start, End time.Time
+
+	// Duration is the length of the meeting.
+	Duration Duration
+
+	// WorkingHours restricts slots to those entirely within one of the
+	// given periods. When empty, any time may be used.
+	WorkingHours []WorkingHours
+
+	// Location is the timezone of the WorkingHours. When nil, UTC is used.
+	Location *time.Location
+
+	// Quorum is the number of required participants that must be free for a
+	// slot to be returned. When zero, all required participants must be
+	// free. In either case, at least one participant must be free.
+	Quorum int
+
+	// Step is the interval between the starts of successive candidate slots.
+	// When zero, 15 minutes is used.
+	Step time.Duration
+
+	// Limit is the maximum number of slots returned. When zero, all slots
+	// are returned.
+	Limit int
+}
+
+// Slot is a candidate time for a meeting, listing the calendar addresses of
+// the attendees who are free, tentatively busy, or busy during it.
+type Slot struct {
+	Start, End time.Time
+
+	Free, Tentative, Busy []string
+
+	required, optional int
+}
+
+// Find returns the candidate slots, ranked first by the number of free required
+// participants, then by the number of free optional participants, then by the
+// fewest tentatively busy attendees, and finally by start time.
+//
+// An attendee is busy during a slot when it overlaps any FreeBusy period, other
+// than those with a FreeBusyType of Free, and tentatively busy when the only
+// overlapping periods are BusyTentative.
+func (q *SlotQuery) Find() []Slot {
+	step := q.Step
+	if step <= 0 {
+		step = 15 * time.Minute
+	}
+
+	loc := q.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var (
+		slots    []Slot
+		required int
+	)
+
+	for _, a := range q.Attendees {
+		if attendeeRequired(a.Attendee) {
+			required++
+		}
+	}
+
+	quorum := q.Quorum
+	if quorum <= 0 || quorum > required {
+		quorum = required
+	}
+
+	for start := q.Start; ; start = start.Add(step) {
+		end := q.Duration.add(start)
+		if end.After(q.End) || !start.Before(end) {
+			break
+		} else if !q.inWorkingHours(start.In(loc), end.In(loc)) {
+			continue
+		}
+
+		s := Slot{Start: start, End: end}
+
+		for _, a := range q.Attendees {
+			if role := a.Attendee.ParticipationRole; role != nil && *role == ParticipationRoleNonParticipant {
+				continue
+			}
+
+			address := a.Attendee.CalendarAddress.URL.String()
+
+			switch busyDuring(a.FreeBusy, start, end) {
+			case FreeBusyTypeFree:
+				s.Free = append(s.Free, address)
+
+				if attendeeRequired(a.Attendee) {
+					s.required++
+				} else {
+					s.optional++
+				}
+			case FreeBusyTypeBusyTentative:
+				s.Tentative = append(s.Tentative, address)
+			default:
+				s.Busy = append(s.Busy, address)
+			}
+		}
+
+		if s.required >= quorum && s.required+s.optional > 0 {
+			slots = append(slots, s)
+		}
+	}
+
+	sort.SliceStable(slots, func(i, j int) bool {
+		a, b := slots[i], slots[j]
+
+		if a.required != b.required {
+			return a.required > b.required
+		} else if a.optional != b.optional {
+			return a.optional > b.optional
+		}
+
+		return len(a.Tentative) < len(b.Tentative)
+	})
+
+	if q.Limit > 0 && len(slots) > q.Limit {
+		slots = slots[:q.Limit]
+	}
+
+	return slots
+}
+
+func attendeeRequired(a PropAttendee) bool {
+	if a.ParticipationRole == nil {
+		return true
+	}
+
+	switch *a.ParticipationRole {
+	case ParticipationRoleRequiredParticipant, ParticipationRoleChair, ParticipationRoleUnknown:
+		return true
+	}
+
+	return false
+}
+
+func (q *SlotQuery) inWorkingHours(start, end time.Time) bool {
+	if len(q.WorkingHours) == 0 {
+		return true
+	}
+
+	y, m, d := start.Date()
+
+	for _, w := range q.WorkingHours {
+		// The offsets are normalised as wall clock time, so that they are
+		// unaffected by any change of UTC offset during the day.
+		from := time.Date(y, m, d, 0, 0, 0, int(w.Start), start.Location())
+		to := time.Date(y, m, d, 0, 0, 0, int(w.End), start.Location())
+
+		if w.Weekday == start.Weekday() && !start.Before(from) && !end.After(to) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// busyDuring returns the most significant FreeBusyType of the periods
+// overlapping the given time range, which will be Free if there are none.
+func busyDuring(fbs []FreeBusy, start, end time.Time) ParamFreeBusyType {
+	busy := FreeBusyTypeFree
+
+	for _, fb := range fbs {
+		for _, p := range busyPeriodsOf(fb.FreeBusy) {
+			if p.busy == FreeBusyTypeFree || !p.start.Before(end) || !p.end.After(start) {
+				continue
+			}
+
+			if busyPrecedence(p.busy) > busyPrecedence(busy) {
+				busy = p.busy
+			}
+		}
+	}
+
+	return busy
+}
@@ -0,0 +1,128 @@
+package ics
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSlotQuery(t *testing.T) {
+	at := func(h, m int) time.Time {
+		return time.Date(2020, 1, 6, h, m, 0, 0, time.UTC)
+	}
+
+	attendee := func(address string, role *ParamParticipationRole, busy ...PropFreeBusy) SlotAttendee {
+		return SlotAttendee{
+			Attendee: PropAttendee{
+				ParticipationRole: role,
+				CalendarAddress:   CalendarAddress{URL: url.URL{Scheme: "mailto", Opaque: address}},
+			},
+			FreeBusy: []FreeBusy{{FreeBusy: busy}},
+		}
+	}
+
+	period := func(typ ParamFreeBusyType, start, end time.Time) PropFreeBusy {
+		return PropFreeBusy{FreeBusyType: typ.New(), MPeriod: MPeriod{{Start: DateTime{start}, End: DateTime{end}}}}
+	}
+
+	q := SlotQuery{
+		Attendees: []SlotAttendee{
+			attendee("alice@example.com", ParticipationRoleChair.New(), period(FreeBusyTypeBusy, at(9, 0), at(10, 0))),
+			attendee("bob@example.com", nil, period(FreeBusyTypeBusyTentative, at(10, 0), at(11, 0))),
+			attendee("carol@example.com", ParticipationRoleOptParticipant.New(), period(FreeBusyTypeBusyUnavailable, at(11, 0), at(12, 0))),
+			attendee("dave@example.com", ParticipationRoleNonParticipant.New(), period(FreeBusyTypeBusy, at(0, 0), at(23, 0))),
+			attendee("erin@example.com", ParticipationRoleRequiredParticipant.New(), period(FreeBusyTypeFree, at(9, 0), at(12, 0))),
+		},
+		Start:        at(8, 0),
+		End:          at(13, 0),
+		Duration:     Duration{Hours: 1},
+		WorkingHours: []WorkingHours{{Weekday: time.Monday, Start: 9 * time.Hour, End: 12 * time.Hour}},
+		Step:         30 * time.Minute,
+	}
+
+	for n, test := range [...]struct {
+		Quorum, Limit int
+		Starts        []time.Time
+	}{
+		{
+			Starts: []time.Time{at(11, 0)},
+		},
+		{
+			Quorum: 2,
+			Starts: []time.Time{at(11, 0), at(9, 0), at(10, 0), at(10, 30)},
+		},
+		{
+			Quorum: 2,
+			Limit:  1,
+			Starts: []time.Time{at(11, 0)},
+		},
+	} {
+		q.Quorum, q.Limit = test.Quorum, test.Limit
+
+		slots := q.Find()
+		if len(slots) != len(test.Starts) {
+			t.Errorf("test %d: expecting %d slots, got %d", n+1, len(test.Starts), len(slots))
+
+			continue
+		}
+
+		for m, s := range slots {
+			if !s.Start.Equal(test.Starts[m]) || !s.End.Equal(test.Starts[m].Add(time.Hour)) {
+				t.Errorf("test %d.%d: expecting slot at %s, got %s to %s", n+1, m+1, test.Starts[m], s.Start, s.End)
+			}
+		}
+	}
+
+	q.Quorum, q.Limit = 2, 0
+
+	s := q.Find()[2]
+
+	if len(s.Free) != 3 || len(s.Tentative) != 1 || s.Tentative[0] != "mailto:bob@example.com" || len(s.Busy) != 0 {
+		t.Errorf("unexpected attendees for slot at %s: free %v, tentative %v, busy %v", s.Start, s.Free, s.Tentative, s.Busy)
+	}
+
+	q.WorkingHours = nil
+	q.Quorum = 0
+
+	if slots := q.Find(); len(slots) != 4 || !slots[0].Start.Equal(at(8, 0)) {
+		t.Errorf("expecting 4 slots outside working hours, got %d", len(slots))
+	}
+
+	q.Attendees = []SlotAttendee{
+		q.Attendees[2],
+		attendee("frank@example.com", ParticipationRoleOptParticipant.New(), period(FreeBusyTypeBusy, at(9, 0), at(12, 0))),
+	}
+	q.WorkingHours = []WorkingHours{{Weekday: time.Monday, Start: 9 * time.Hour, End: 12 * time.Hour}}
+
+	if slots := q.Find(); len(slots) != 3 || !slots[2].Start.Equal(at(10, 0)) {
+		t.Errorf("expecting 3 slots with a free optional participant, got %d", len(slots))
+	}
+}
+
+func TestSlotQueryDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("unexpected error loading location: %s", err)
+	}
+
+	q := SlotQuery{
+		Attendees:    []SlotAttendee{{Attendee: PropAttendee{CalendarAddress: CalendarAddress{URL: url.URL{Scheme: "mailto", Opaque: "alice@example.com"}}}}},
+		Start:        time.Date(2020, 3, 8, 0, 0, 0, 0, loc),
+		End:          time.Date(2020, 3, 9, 0, 0, 0, 0, loc),
+		Duration:     Duration{Hours: 1},
+		WorkingHours: []WorkingHours{{Weekday: time.Sunday, Start: 9 * time.Hour, End: 12 * time.Hour}},
+		Location:     loc,
+		Step:         time.Hour,
+	}
+
+	slots := q.Find()
+	if len(slots) != 3 {
+		t.Fatalf("expecting 3 slots, got %d", len(slots))
+	}
+
+	for n, s := range slots {
+		if h := s.Start.In(loc).Hour(); h != 9+n {
+			t.Errorf("test %d: expecting slot at %d:00, got %s", n+1, 9+n, s.Start.In(loc))
+		}
+	}
+}
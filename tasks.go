@@ -0,0 +1,384 @@
+package ics
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TaskGraph is a directed acyclic graph of the dependencies between the Todos
+// of a Calendar, as described by their RelatedTo properties.
+//
+// A RelatedTo property with a RelationshipType of FinishToStart,
+// FinishToFinish, StartToFinish, or StartToStart makes the Todo containing it
+// a predecessor of the referenced Todo, whereas a RelationshipType of
+// DependsOn makes the referenced Todo a predecessor, with a FinishToStart
+// relationship, of the Todo containing it. Any Gap is applied as a lag (or
+// lead, when negative) to the relationship. RelationshipTypes are matched
+// case-insensitively, and all others are ignored.
+type TaskGraph struct {
+	// Tasks contains every task in the graph, in topological order.
+	Tasks []*Task
+	uids  map[string]*Task
+}
+
+// Task is a single Todo within a TaskGraph, along with its computed schedule.
+//
+// The EarliestStart of a Task will not be before its DateTimeStart, and the
+// LatestFinish will not be after its Due time.
+type Task struct {
+	Todo *Todo
+
+	// Duration is the remaining duration of the Todo, taken from the Duration
+	// property, or the difference between the DateTimeStart and Due, and
+	// reduced by any PercentComplete.
+	Duration time.Duration
+
+	Predecessors, Successors []TaskDependency
+
+	EarliestStart, EarliestFinish time.Time
+	LatestStart, LatestFinish     time.Time
+
+	index int
+	start time.Time
+	due   time.Time
+}
+
+// TaskDependency is a relationship to another Task.
+type TaskDependency struct {
+	Task *Task
+	Type ParamRelationshipType
+	Gap  Duration
+}
+
+// TaskSlip is a Task whose EarliestFinish has been moved later.
+type TaskSlip struct {
+	Task  *Task
+	Delay time.Duration
+}
+
+// TaskGraph builds the dependency graph of the Todos in the Calendar and
+// computes the schedule of each.
+//
+// Only Todos without a RecurrenceID are included, and relationships to UIDs
+// not in the Calendar are ignored.
+//
+// Returns an error wrapping ErrDependencyCycle if the dependencies contain a
+// cycle.
+func (c *Calendar) TaskGraph() (*TaskGraph, error) {
+	var (
+		tasks []*Task
+		uids  = make(map[string]*Task)
+	)
+
+	for n := range c.Todo {
+		t := &c.Todo[n]
+
+		if _, ok := uids[string(t.UID)]; ok || t.RecurrenceID != nil {
+			continue
+		}
+
+		task := newTask(t)
+		uids[string(t.UID)] = task
+		tasks = append(tasks, task)
+	}
+
+	for _, task := range tasks {
+		for _, r := range task.Todo.RelatedTo {
+			if r.RelationshipType == nil {
+				continue
+			}
+
+			other, ok := uids[relatedUID(r)]
+			if !ok {
+				continue
+			}
+
+			d := TaskDependency{Type: ParamRelationshipType(strings.ToUpper(string(*r.RelationshipType)))}
+
+			if r.Gap != nil {
+				d.Gap = Duration(*r.Gap)
+			}
+
+			switch d.Type {
+			case RelationshipTypeFinishToStart, RelationshipTypeFinishToFinish, RelationshipTypeStartToFinish, RelationshipTypeStartToStart:
+				addDependency(task, other, d)
+			case RelationshipTypeDependsOn:
+				d.Type = RelationshipTypeFinishToStart
+
+				addDependency(other, task, d)
+			}
+		}
+	}
+
+	g := &TaskGraph{uids: uids}
+
+	if err := g.sort(tasks); err != nil {
+		return nil, err
+	}
+
+	es, ef := g.forward(nil, time.Time{})
+
+	g.backward(ef)
+
+	for n, t := range g.Tasks {
+		t.EarliestStart, t.EarliestFinish = es[n], ef[n]
+	}
+
+	return g, nil
+}
+
+func newTask(t *Todo) *Task {
+	task := &Task{Todo: t}
+
+	if t.DateTimeStart != nil {
+		task.start, _ = startTime(t.DateTimeStart.DateTime, t.DateTimeStart.Date)
+	}
+
+	if t.Due != nil {
+		task.due, _ = startTime(t.Due.DateTime, t.Due.Date)
+	}
+
+	if t.Duration != nil {
+		start := task.start
+		if start.IsZero() {
+			start = task.due
+		}
+
+		task.Duration = Duration(*t.Duration).add(start).Sub(start)
+	} else if !task.start.IsZero() && !task.due.IsZero() {
+		task.Duration = task.due.Sub(task.start)
+	}
+
+	if t.PercentComplete != nil {
+		pc := time.Duration(*t.PercentComplete)
+
+		if pc > 100 {
+			pc = 100
+		} else if pc < 0 {
+			pc = 0
+		}
+
+		task.Duration = task.Duration * (100 - pc) / 100
+	}
+
+	return task
+}
+
+// relatedUID returns the UID referenced by the RelatedTo property, which is
+// empty when it references a URI.
+func relatedUID(r PropRelatedTo) string {
+	if r.UID != nil {
+		return string(*r.UID)
+	} else if r.URI != nil {
+		return ""
+	}
+
+	return string(r.Text)
+}
+
+func addDependency(pred, succ *Task, d TaskDependency) {
+	d.Task = succ
+	pred.Successors = append(pred.Successors, d)
+	d.Task = pred
+	succ.Predecessors = append(succ.Predecessors, d)
+}
+
+// sort orders the tasks topologically, keeping the original order where
+// possible.
+func (g *TaskGraph) sort(tasks []*Task) error {
+	incoming := make(map[*Task]int, len(tasks))
+
+	for _, t := range tasks {
+		incoming[t] = len(t.Predecessors)
+	}
+
+	for len(g.Tasks) < len(tasks) {
+		var next *Task
+
+		for _, t := range tasks {
+			if incoming[t] == 0 {
+				next = t
+
+				break
+			}
+		}
+
+		if next == nil {
+			var cycle []string
+
+			for _, t := range tasks {
+				if incoming[t] > 0 {
+					cycle = append(cycle, string(t.Todo.UID))
+				}
+			}
+
+			return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, ", "))
+		}
+
+		incoming[next] = -1
+		next.index = len(g.Tasks)
+		g.Tasks = append(g.Tasks, next)
+
+		for _, d := range next.Successors {
+			incoming[d.Task]--
+		}
+	}
+
+	return nil
+}
+
+// forward computes the earliest start and finish of each task, with the
+// finish of the given task being no earlier than the given time.
+func (g *TaskGraph) forward(moved *Task, finish time.Time) ([]time.Time, []time.Time) {
+	var (
+		es           = make([]time.Time, len(g.Tasks))
+		ef           = make([]time.Time, len(g.Tasks))
+		projectStart time.Time
+	)
+
+	for _, t := range g.Tasks {
+		s := t.start
+
+		if s.IsZero() && !t.due.IsZero() {
+			s = t.due.Add(-t.Duration)
+		}
+
+		if !s.IsZero() && (projectStart.IsZero() || s.Before(projectStart)) {
+			projectStart = s
+		}
+	}
+
+	for n, t := range g.Tasks {
+		s := t.start
+		if s.IsZero() {
+			s = projectStart
+		}
+
+		for _, d := range t.Predecessors {
+			var c time.Time
+
+			switch p := d.Task.index; d.Type {
+			case RelationshipTypeFinishToStart:
+				c = d.Gap.add(ef[p])
+			case RelationshipTypeStartToStart:
+				c = d.Gap.add(es[p])
+			case RelationshipTypeFinishToFinish:
+				c = d.Gap.add(ef[p]).Add(-t.Duration)
+			case RelationshipTypeStartToFinish:
+				c = d.Gap.add(es[p]).Add(-t.Duration)
+			}
+
+			if c.After(s) {
+				s = c
+			}
+		}
+
+		es[n], ef[n] = s, s.Add(t.Duration)
+
+		if t == moved && finish.After(ef[n]) {
+			ef[n] = finish
+		}
+	}
+
+	return es, ef
+}
+
+// backward computes the latest start and finish of each task.
+func (g *TaskGraph) backward(ef []time.Time) {
+	var projectEnd time.Time
+
+	for _, f := range ef {
+		if f.After(projectEnd) {
+			projectEnd = f
+		}
+	}
+
+	for n := len(g.Tasks) - 1; n >= 0; n-- {
+		t := g.Tasks[n]
+		f := projectEnd
+
+		if !t.due.IsZero() && t.due.Before(f) {
+			f = t.due
+		}
+
+		for _, d := range t.Successors {
+			var (
+				c   time.Time
+				s   = d.Task
+				gap = d.Gap
+			)
+
+			gap.Negative = !gap.Negative
+
+			switch d.Type {
+			case RelationshipTypeFinishToStart:
+				c = gap.add(s.LatestStart)
+			case RelationshipTypeStartToStart:
+				c = gap.add(s.LatestStart).Add(t.Duration)
+			case RelationshipTypeFinishToFinish:
+				c = gap.add(s.LatestFinish)
+			case RelationshipTypeStartToFinish:
+				c = gap.add(s.LatestFinish).Add(t.Duration)
+			}
+
+			if c.Before(f) {
+				f = c
+			}
+		}
+
+		t.LatestFinish, t.LatestStart = f, f.Add(-t.Duration)
+	}
+}
+
+// Task returns the Task for the Todo with the given UID, or nil if there is
+// no such Task.
+func (g *TaskGraph) Task(uid string) *Task {
+	return g.uids[uid]
+}
+
+// Slack returns the amount of time the start of the Task can be delayed
+// without delaying the completion of the graph or breaking a Due time.
+func (t *Task) Slack() time.Duration {
+	return t.LatestStart.Sub(t.EarliestStart)
+}
+
+// CriticalPath returns the tasks, in topological order, that have no slack.
+func (g *TaskGraph) CriticalPath() []*Task {
+	var tasks []*Task
+
+	for _, t := range g.Tasks {
+		if t.Slack() <= 0 {
+			tasks = append(tasks, t)
+		}
+	}
+
+	return tasks
+}
+
+// Slip returns the tasks, in topological order, whose EarliestFinish would be
+// delayed if the Todo with the given UID were instead due at the given time.
+func (g *TaskGraph) Slip(uid string, due time.Time) []TaskSlip {
+	moved := g.uids[uid]
+	if moved == nil {
+		return nil
+	}
+
+	_, ef := g.forward(moved, due)
+
+	var slips []TaskSlip
+
+	for n, t := range g.Tasks {
+		if t != moved && ef[n].After(t.EarliestFinish) {
+			slips = append(slips, TaskSlip{Task: t, Delay: ef[n].Sub(t.EarliestFinish)})
+		}
+	}
+
+	return slips
+}
+
+// Errors.
+var (
+	ErrDependencyCycle = errors.New("dependency cycle")
+)
@@ -0,0 +1,116 @@
+package ics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTaskGraph(t *testing.T) {
+	cal, err := Decode(strings.NewReader("BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:TEST\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID:a\r\n" +
+		"DTSTART:20200101T090000Z\r\n" +
+		"DURATION:P2D\r\n" +
+		"RELATED-TO;RELTYPE=FINISHTOSTART;GAP=P1D:b\r\n" +
+		"RELATED-TO;RELTYPE=StartToStart:c\r\n" +
+		"RELATED-TO;RELTYPE=FINISHTOSTART:external\r\n" +
+		"RELATED-TO;RELTYPE=X-OTHER:d\r\n" +
+		"END:VTODO\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID:d\r\n" +
+		"DUE:20200110T090000Z\r\n" +
+		"RELATED-TO;RELTYPE=DEPENDS-ON:b\r\n" +
+		"RELATED-TO;RELTYPE=DEPENDS-ON:c\r\n" +
+		"END:VTODO\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID:b\r\n" +
+		"DTSTART:20200101T090000Z\r\n" +
+		"DURATION:P1D\r\n" +
+		"END:VTODO\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"DTSTAMP:20200101T000000Z\r\n" +
+		"UID:c\r\n" +
+		"DTSTART:20200101T090000Z\r\n" +
+		"DURATION:P1D\r\n" +
+		"PERCENT-COMPLETE:50\r\n" +
+		"END:VTODO\r\n" +
+		"END:VCALENDAR\r\n"))
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	}
+
+	g, err := cal.TaskGraph()
+	if err != nil {
+		t.Fatalf("unexpected error building graph: %s", err)
+	}
+
+	date := func(day, hour int) time.Time {
+		return time.Date(2020, 1, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		UID                           string
+		EarliestStart, EarliestFinish time.Time
+		LatestStart, LatestFinish     time.Time
+	}{
+		{"a", date(1, 9), date(3, 9), date(1, 9), date(3, 9)},
+		{"b", date(4, 9), date(5, 9), date(4, 9), date(5, 9)},
+		{"c", date(1, 9), date(1, 21), date(4, 21), date(5, 9)},
+		{"d", date(5, 9), date(5, 9), date(5, 9), date(5, 9)},
+	}
+
+	if len(g.Tasks) != len(tests) {
+		t.Fatalf("expecting %d tasks, got %d", len(tests), len(g.Tasks))
+	}
+
+	for n, test := range tests {
+		if task := g.Tasks[n]; string(task.Todo.UID) != test.UID {
+			t.Errorf("test %d: expecting task %q, got %q", n+1, test.UID, task.Todo.UID)
+		} else if !task.EarliestStart.Equal(test.EarliestStart) || !task.EarliestFinish.Equal(test.EarliestFinish) {
+			t.Errorf("test %d: expecting earliest %s-%s, got %s-%s", n+1, test.EarliestStart, test.EarliestFinish, task.EarliestStart, task.EarliestFinish)
+		} else if !task.LatestStart.Equal(test.LatestStart) || !task.LatestFinish.Equal(test.LatestFinish) {
+			t.Errorf("test %d: expecting latest %s-%s, got %s-%s", n+1, test.LatestStart, test.LatestFinish, task.LatestStart, task.LatestFinish)
+		}
+	}
+
+	var critical []string
+
+	for _, task := range g.CriticalPath() {
+		critical = append(critical, string(task.Todo.UID))
+	}
+
+	if c := strings.Join(critical, ","); c != "a,b,d" {
+		t.Errorf("expecting critical path a,b,d, got %s", c)
+	}
+
+	if p := g.Task("c").Predecessors; len(p) != 1 || p[0].Task != g.Task("a") || p[0].Type != RelationshipTypeStartToStart {
+		t.Errorf("expecting task c to have a STARTTOSTART predecessor a, got %v", p)
+	} else if p := g.Task("d").Predecessors; len(p) != 2 {
+		t.Errorf("expecting task d to have 2 predecessors, got %d", len(p))
+	}
+
+	if slips := g.Slip("b", date(7, 9)); len(slips) != 1 {
+		t.Errorf("expecting 1 slipped task, got %d", len(slips))
+	} else if slips[0].Task != g.Task("d") || slips[0].Delay != 48*time.Hour {
+		t.Errorf("expecting task d to slip by 48h, got %s by %s", slips[0].Task.Todo.UID, slips[0].Delay)
+	}
+
+	if slips := g.Slip("c", date(4, 9)); len(slips) != 0 {
+		t.Errorf("expecting no slipped tasks, got %d", len(slips))
+	}
+
+	a := UID("a")
+
+	cal.Todo[2].RelatedTo = []PropRelatedTo{{RelationshipType: RelationshipTypeFinishToStart.New(), UID: &a}}
+
+	if _, err := cal.TaskGraph(); !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("expecting error ErrDependencyCycle, got %v", err)
+	}
+}
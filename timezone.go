@@ -0,0 +1,793 @@
+package ics
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const timezoneHorizon = 2100
+
+// TimezoneTransition describes a change of UTC offset within a Timezone.
+type TimezoneTransition struct {
+	Time                 time.Time
+	OffsetFrom, OffsetTo UTCOffset
+	Name                 string
+	DST                  bool
+}
+
+func (s *Standard) transitions(dst bool, until time.Time) []TimezoneTransition {
+	start, _ := startTime(s.DateTimeStart.DateTime, s.DateTimeStart.Date)
+	if start.IsZero() {
+		return nil
+	}
+
+	from := time.FixedZone("", int(s.TimezoneOffsetFrom))
+	onsets := []time.Time{wallTime(start, from)}
+
+	if s.RecurrenceRule != nil {
+		it := s.RecurrenceRule.Iterate(onsets[0])
+
+		for {
+			t, ok := it.Next()
+			if !ok || !t.Before(until) {
+				break
+			}
+
+			onsets = append(onsets, t)
+		}
+	}
+
+	for _, rdate := range s.RecurrenceDateTimes {
+		if rdate.DateTime != nil {
+			onsets = append(onsets, wallTime(rdate.DateTime.Time, from))
+		} else if rdate.Date != nil {
+			onsets = append(onsets, wallTime(rdate.Date.Time, from))
+		} else if rdate.Period != nil {
+			onsets = append(onsets, wallTime(rdate.Period.Start.Time, from))
+		}
+	}
+
+	name := offsetName(int(s.TimezoneOffsetTo))
+
+	if len(s.TimezoneName) > 0 && s.TimezoneName[0].Text != "" {
+		name = string(s.TimezoneName[0].Text)
+	}
+
+	trs := make([]TimezoneTransition, 0, len(onsets))
+
+	for _, onset := range onsets {
+		trs = append(trs, TimezoneTransition{
+			Time:       onset.UTC(),
+			OffsetFrom: UTCOffset(s.TimezoneOffsetFrom),
+			OffsetTo:   UTCOffset(s.TimezoneOffsetTo),
+			Name:       name,
+			DST:        dst,
+		})
+	}
+
+	return trs
+}
+
+func (t *Timezone) transitions(until time.Time) []TimezoneTransition {
+	var trs []TimezoneTransition
+
+	for n := range t.Standard {
+		trs = append(trs, t.Standard[n].transitions(false, until)...)
+	}
+
+	for n := range t.Daylight {
+		trs = append(trs, (*Standard)(&t.Daylight[n]).transitions(true, until)...)
+	}
+
+	sort.SliceStable(trs, func(i, j int) bool {
+		return trs[i].Time.Before(trs[j].Time)
+	})
+
+	var last time.Time
+
+	filtered := trs[:0]
+
+	for _, tr := range trs {
+		if len(filtered) > 0 && tr.Time.Equal(last) {
+			continue
+		}
+
+		last = tr.Time
+		filtered = append(filtered, tr)
+	}
+
+	return filtered
+}
+
+// Transitions returns the changes of UTC offset, in order, that occur within
+// the given time range, as defined by the Standard and Daylight components of
+// the Timezone.
+func (t *Timezone) Transitions(start, end time.Time) []TimezoneTransition {
+	trs := t.transitions(end)
+
+	for n, tr := range trs {
+		if !tr.Time.Before(start) {
+			return trs[n:]
+		}
+	}
+
+	return nil
+}
+
+// Location converts the Timezone into a time.Location by evaluating the
+// onsets, recurrence rules and offsets of its Standard and Daylight
+// components.
+//
+// Times before the first onset use the TZOFFSETFROM of that onset. When the
+// final Standard and Daylight components are open-ended yearly rules, those
+// rules are also used for all times after the last calculated transition.
+func (t *Timezone) Location() (*time.Location, error) {
+	trs := t.transitions(time.Date(timezoneHorizon, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(trs) == 0 {
+		return nil, ErrInvalidTimezone
+	}
+
+	return time.LoadLocationFromTZData(string(t.TimezoneID), tzData(trs, t.posixRule()))
+}
+
+// posixRule returns a POSIX TZ string, as used in the footer of TZif data, that
+// describes the open-ended Standard and Daylight rules of the Timezone. It
+// returns an empty string if there is no such pair of rules, or if they cannot
+// be described in that form.
+func (t *Timezone) posixRule() string {
+	var std, dst *Standard
+
+	for n := range t.Standard {
+		if s := &t.Standard[n]; openEnded(s) {
+			if std != nil {
+				return ""
+			}
+
+			std = s
+		}
+	}
+
+	for n := range t.Daylight {
+		if s := (*Standard)(&t.Daylight[n]); openEnded(s) {
+			if dst != nil {
+				return ""
+			}
+
+			dst = s
+		}
+	}
+
+	if std == nil || dst == nil {
+		return ""
+	}
+
+	stdName, okStdName := posixName(std)
+	dstName, okDstName := posixName(dst)
+	stdDate, okStdDate := posixDate(std)
+	dstDate, okDstDate := posixDate(dst)
+
+	if !okStdName || !okDstName || !okStdDate || !okDstDate {
+		return ""
+	}
+
+	return stdName + posixOffset(int(std.TimezoneOffsetTo)) + dstName + posixOffset(int(dst.TimezoneOffsetTo)) + "," + dstDate + "," + stdDate
+}
+
+func openEnded(s *Standard) bool {
+	return s.RecurrenceRule != nil && s.RecurrenceRule.Count == 0 && s.RecurrenceRule.Until.IsZero()
+}
+
+func posixName(s *Standard) (string, bool) {
+	name := offsetName(int(s.TimezoneOffsetTo))
+
+	if len(s.TimezoneName) > 0 && s.TimezoneName[0].Text != "" {
+		name = string(s.TimezoneName[0].Text)
+	}
+
+	alpha := len(name) >= 3
+
+	for _, c := range name {
+		if c == '>' || c == '<' || c == '\n' {
+			return "", false
+		} else if (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') {
+			alpha = false
+		}
+	}
+
+	if alpha {
+		return name, true
+	}
+
+	return "<" + name + ">", true
+}
+
+func posixOffset(offset int) string {
+	offset = -offset
+	sign := ""
+
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+
+	return sign + posixTime(offset/3600, offset/60%60, offset%60)
+}
+
+func posixTime(h, m, s int) string {
+	if s != 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	} else if m != 0 {
+		return fmt.Sprintf("%d:%02d", h, m)
+	}
+
+	return strconv.Itoa(h)
+}
+
+// posixDate converts a yearly rule that falls on the nth weekday of a month,
+// given either as an ordinal BYDAY or as a BYDAY with seven consecutive
+// BYMONTHDAY values, into the POSIX Mm.w.d/time form.
+func posixDate(s *Standard) (string, bool) {
+	r := s.RecurrenceRule
+
+	if r.Frequency != Yearly || r.Interval > 1 || len(r.ByMonth) != 1 || len(r.ByDay) != 1 || len(r.BySecond) > 0 || len(r.ByMinute) > 0 || len(r.ByHour) > 0 || len(r.ByYearDay) > 0 || len(r.ByWeekNum) > 0 || len(r.BySetPos) > 0 {
+		return "", false
+	}
+
+	week := int(r.ByDay[0].Occurrence)
+
+	switch {
+	case week == -1 && len(r.ByMonthDay) == 0:
+		week = 5
+	case week >= 1 && week <= 4 && len(r.ByMonthDay) == 0:
+	case week == 0 && len(r.ByMonthDay) == 7 && r.ByMonthDay[0] > 0 && r.ByMonthDay[0]%7 == 1:
+		for n, d := range r.ByMonthDay {
+			if d != r.ByMonthDay[0]+int8(n) {
+				return "", false
+			}
+		}
+
+		week = int(r.ByMonthDay[0]+6) / 7
+	default:
+		return "", false
+	}
+
+	start, _ := startTime(s.DateTimeStart.DateTime, s.DateTimeStart.Date)
+
+	return fmt.Sprintf("M%d.%d.%d/%s", r.ByMonth[0], week, weekday(r.ByDay[0].Day), posixTime(start.Hour(), start.Minute(), start.Second())), true
+}
+
+type tzZone struct {
+	offset int
+	dst    bool
+	name   string
+}
+
+// tzData builds version 2 TZif data, as described in RFC 8536, from the given
+// transitions and POSIX TZ footer.
+func tzData(trs []TimezoneTransition, footer string) []byte {
+	initial := tzZone{offset: int(trs[0].OffsetFrom), name: offsetName(int(trs[0].OffsetFrom))}
+
+	for _, tr := range trs {
+		if int(tr.OffsetTo) == initial.offset {
+			initial.dst = tr.DST
+			initial.name = tr.Name
+
+			break
+		}
+	}
+
+	zones := []tzZone{initial}
+	zoneIndexes := make(map[tzZone]int)
+	indexes := make([]byte, len(trs))
+
+	for n, tr := range trs {
+		z := tzZone{offset: int(tr.OffsetTo), dst: tr.DST, name: tr.Name}
+
+		idx, ok := zoneIndexes[z]
+		if !ok && len(zones) < 256 {
+			idx = len(zones)
+			zoneIndexes[z] = idx
+			zones = append(zones, z)
+		}
+
+		indexes[n] = byte(idx)
+	}
+
+	var abbrevs strings.Builder
+
+	abbrevIndexes := make(map[string]int)
+
+	for _, z := range zones {
+		if _, ok := abbrevIndexes[z.name]; !ok {
+			abbrevIndexes[z.name] = abbrevs.Len()
+
+			abbrevs.WriteString(z.name)
+			abbrevs.WriteByte(0)
+		}
+	}
+
+	data := make([]byte, 0, 88+len(trs)*9+len(zones)*6+abbrevs.Len())
+	data = append(data, "TZif2"...)
+	data = append(data, make([]byte, 15+24)...)
+	data = append(data, "TZif2"...)
+	data = append(data, make([]byte, 15+12)...)
+	data = appendUint32(data, uint32(len(trs)))
+	data = appendUint32(data, uint32(len(zones)))
+	data = appendUint32(data, uint32(abbrevs.Len()))
+
+	for _, tr := range trs {
+		data = appendUint64(data, uint64(tr.Time.Unix()))
+	}
+
+	data = append(data, indexes...)
+
+	for _, z := range zones {
+		var dst byte
+
+		if z.dst {
+			dst = 1
+		}
+
+		data = appendUint32(data, uint32(int32(z.offset)))
+		data = append(data, dst, byte(abbrevIndexes[z.name]))
+	}
+
+	data = append(data, abbrevs.String()...)
+
+	if footer != "" {
+		data = append(data, '\n')
+		data = append(data, footer...)
+		data = append(data, '\n')
+	}
+
+	return data
+}
+
+func appendUint32(data []byte, v uint32) []byte {
+	return append(data, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func appendUint64(data []byte, v uint64) []byte {
+	return appendUint32(appendUint32(data, uint32(v>>32)), uint32(v))
+}
+
+func wallTime(t time.Time, l *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), l)
+}
+
+func offsetName(offset int) string {
+	var sb strings.Builder
+
+	UTCOffset(offset).encode(&sb)
+
+	return sb.String()
+}
+
+type zoneState struct {
+	name   string
+	offset int
+	dst    bool
+}
+
+func stateAt(t time.Time, l *time.Location) zoneState {
+	t = t.In(l)
+	name, offset := t.Zone()
+
+	return zoneState{name: name, offset: offset, dst: t.IsDST()}
+}
+
+// locationTransitions finds all of the transitions of the location within the
+// given range.
+func locationTransitions(l *time.Location, start, end time.Time) []TimezoneTransition {
+	var trs []TimezoneTransition
+
+	prev := stateAt(start, l)
+
+	for t := start; t.Before(end); {
+		next := t.Add(24 * time.Hour)
+		state := stateAt(next, l)
+
+		if state != prev {
+			lo, hi := t.Unix(), next.Unix()
+
+			for hi-lo > 1 {
+				mid := lo + (hi-lo)/2
+
+				if stateAt(time.Unix(mid, 0), l) == prev {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+
+			at := time.Unix(hi, 0).UTC()
+			state = stateAt(at, l)
+			trs = append(trs, TimezoneTransition{
+				Time:       at,
+				OffsetFrom: UTCOffset(prev.offset),
+				OffsetTo:   UTCOffset(state.offset),
+				Name:       state.name,
+				DST:        state.dst,
+			})
+			next = at
+			prev = state
+		}
+
+		t = next
+	}
+
+	return trs
+}
+
+type yearlyRule struct {
+	weekday  time.Weekday
+	nth      int
+	last     bool
+	monthDay int
+}
+
+func newYearlyRule(t time.Time) yearlyRule {
+	return yearlyRule{
+		weekday:  t.Weekday(),
+		nth:      (t.Day()-1)/7 + 1,
+		last:     t.Day()+7 > daysInMonth(t.Year(), t.Month()),
+		monthDay: t.Day(),
+	}
+}
+
+func (y yearlyRule) intersect(z yearlyRule) (yearlyRule, bool) {
+	if y.weekday != z.weekday {
+		y.nth = 0
+		y.last = false
+	}
+
+	if y.nth != z.nth {
+		y.nth = 0
+	}
+
+	if !z.last {
+		y.last = false
+	}
+
+	if y.monthDay != z.monthDay {
+		y.monthDay = 0
+	}
+
+	return y, y.nth != 0 || y.last || y.monthDay != 0
+}
+
+func (y yearlyRule) recur(month time.Month) *PropRecurrenceRule {
+	r := &PropRecurrenceRule{
+		Frequency: Yearly,
+		ByMonth:   []Month{Month(month)},
+	}
+
+	if y.nth != 0 || y.last {
+		occurrence := int8(y.nth)
+
+		if y.nth == 0 || y.nth == 5 {
+			occurrence = -1
+		}
+
+		r.ByDay = []DayRecur{{Day: WeekDay(y.weekday + 1), Occurrence: occurrence}}
+	} else {
+		r.ByMonthDay = []int8{int8(y.monthDay)}
+	}
+
+	return r
+}
+
+type observanceKey struct {
+	from, to int
+	name     string
+	dst      bool
+	month    time.Month
+	clock    time.Duration
+}
+
+type observanceRun struct {
+	key   observanceKey
+	onset []time.Time
+	rule  yearlyRule
+}
+
+// NewTimezone generates a Timezone component that describes the given location
+// between the start and end times.
+//
+// Transitions that follow a yearly pattern are compressed into recurrence
+// rules, with any rule still in effect at the end time left open-ended; all
+// other transitions are listed as recurrence dates.
+func NewTimezone(l *time.Location, start, end time.Time) Timezone {
+	tz := Timezone{TimezoneID: PropTimezoneID(l.String())}
+	trs := locationTransitions(l, start.AddDate(-1, 0, 0), end.AddDate(1, 0, 0))
+
+	if len(trs) == 0 {
+		state := stateAt(start, l)
+		s := Standard{
+			DateTimeStart:      PropDateTimeStart{DateTime: &DateTime{wallTime(start.In(l), time.Local)}},
+			TimezoneOffsetTo:   PropTimezoneOffsetTo(state.offset),
+			TimezoneOffsetFrom: PropTimezoneOffsetFrom(state.offset),
+			TimezoneName:       []PropTimezoneName{{Text: Text(state.name)}},
+		}
+
+		if state.dst {
+			tz.Daylight = append(tz.Daylight, Daylight(s))
+		} else {
+			tz.Standard = append(tz.Standard, s)
+		}
+
+		return tz
+	}
+
+	var runs []*observanceRun
+
+	current := make(map[observanceKey]*observanceRun)
+
+	for _, tr := range trs {
+		local := tr.Time.Add(time.Duration(tr.OffsetFrom) * time.Second)
+		key := observanceKey{
+			from:  int(tr.OffsetFrom),
+			to:    int(tr.OffsetTo),
+			name:  tr.Name,
+			dst:   tr.DST,
+			month: local.Month(),
+			clock: local.Sub(local.Truncate(24 * time.Hour)),
+		}
+		rule := newYearlyRule(local)
+
+		if run, ok := current[key]; ok && run.onset[len(run.onset)-1].Year()+1 == local.Year() {
+			if r, ok := run.rule.intersect(rule); ok {
+				run.rule = r
+				run.onset = append(run.onset, local)
+
+				continue
+			}
+		}
+
+		if local.After(end) {
+			continue
+		}
+
+		run := &observanceRun{key: key, onset: []time.Time{local}, rule: rule}
+		current[key] = run
+		runs = append(runs, run)
+	}
+
+	var (
+		observances []Standard
+		dst         []bool
+	)
+
+	singles := make(map[observanceKey]int)
+
+	for _, run := range runs {
+		s := Standard{
+			DateTimeStart:      PropDateTimeStart{DateTime: &DateTime{wallTime(run.onset[0], time.Local)}},
+			TimezoneOffsetTo:   PropTimezoneOffsetTo(run.key.to),
+			TimezoneOffsetFrom: PropTimezoneOffsetFrom(run.key.from),
+			TimezoneName:       []PropTimezoneName{{Text: Text(run.key.name)}},
+		}
+
+		if len(run.onset) == 1 {
+			key := run.key
+			key.month = 0
+			key.clock = 0
+
+			if n, ok := singles[key]; ok {
+				observances[n].RecurrenceDateTimes = append(observances[n].RecurrenceDateTimes, PropRecurrenceDateTimes{DateTime: s.DateTimeStart.DateTime})
+
+				continue
+			}
+
+			singles[key] = len(observances)
+		} else {
+			s.RecurrenceRule = run.rule.recur(run.key.month)
+
+			if last := run.onset[len(run.onset)-1]; !last.After(end) {
+				s.RecurrenceRule.Until = last.Add(-time.Duration(run.key.from) * time.Second)
+				s.RecurrenceRule.UntilTime = true
+			}
+		}
+
+		observances = append(observances, s)
+		dst = append(dst, run.key.dst)
+	}
+
+	for n, s := range observances {
+		if dst[n] {
+			tz.Daylight = append(tz.Daylight, Daylight(s))
+		} else {
+			tz.Standard = append(tz.Standard, s)
+		}
+	}
+
+	return tz
+}
+
+// withTimezones returns a copy of the Calendar with a generated Timezone added
+// for every referenced TZID that the Calendar does not already define.
+func (c *Calendar) withTimezones() *Calendar {
+	type zoneRange struct {
+		location   *time.Location
+		start, end time.Time
+	}
+
+	defined := make(map[string]bool)
+
+	for n := range c.Timezone {
+		defined[string(c.Timezone[n].TimezoneID)] = true
+	}
+
+	var names []string
+
+	ranges := make(map[string]*zoneRange)
+
+	walkTimes(reflect.ValueOf(c), func(t *time.Time) {
+		l := t.Location()
+		if l == time.UTC || l == time.Local || defined[l.String()] {
+			return
+		}
+
+		if r, ok := ranges[l.String()]; !ok {
+			ranges[l.String()] = &zoneRange{location: l, start: *t, end: *t}
+			names = append(names, l.String())
+		} else if t.Before(r.start) {
+			r.start = *t
+		} else if t.After(r.end) {
+			r.end = *t
+		}
+	})
+
+	if len(names) == 0 {
+		return c
+	}
+
+	nc := *c
+	nc.Timezone = append(make([]Timezone, 0, len(c.Timezone)+len(names)), c.Timezone...)
+
+	for _, name := range names {
+		r := ranges[name]
+		nc.Timezone = append(nc.Timezone, NewTimezone(r.location, r.start, r.end))
+	}
+
+	return &nc
+}
+
+// resolveTimezones re-anchors every time in the Calendar that has a TZID
+// defined by one of the Calendar's Timezone components into a location built
+// from that definition. Any other TZID must be a known IANA timezone, the
+// names of which are returned.
+func (c *Calendar) resolveTimezones() ([]string, error) {
+	r := newTimezoneResolver()
+
+	for n := range c.Timezone {
+		if err := r.add(&c.Timezone[n]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.resolve(c); err != nil {
+		return nil, err
+	}
+
+	return r.fallbacks(), nil
+}
+
+type timezoneResolver struct {
+	locations map[string]*time.Location
+	known     map[string]bool
+	fallback  []string
+}
+
+func newTimezoneResolver() timezoneResolver {
+	return timezoneResolver{
+		locations: make(map[string]*time.Location),
+		known:     make(map[string]bool),
+	}
+}
+
+func (r *timezoneResolver) add(t *Timezone) error {
+	l, err := t.Location()
+	if err != nil {
+		return &timezoneError{name: string(t.TimezoneID), err: fmt.Errorf(errDecodingProp, cCalendar, cTimezone, err)}
+	}
+
+	r.locations[string(t.TimezoneID)] = l
+
+	return nil
+}
+
+// resolve re-anchors every time reachable from v, which must be a pointer,
+// that has a TZID added to the resolver.
+func (r *timezoneResolver) resolve(v interface{}) error {
+	var err error
+
+	walkTimes(reflect.ValueOf(v), func(t *time.Time) {
+		l := t.Location()
+		if err != nil || l == time.UTC || l == time.Local {
+			return
+		}
+
+		name := l.String()
+
+		if cl, ok := r.locations[name]; ok {
+			*t = wallTime(*t, cl)
+		} else if !r.known[name] {
+			if _, lerr := time.LoadLocation(name); lerr != nil {
+				err = &timezoneError{name: name, err: fmt.Errorf(errDecodingType, cCalendar, fmt.Errorf("error loading timezone %q: %w", name, ErrUnknownTimezone))}
+			} else {
+				r.fallback = append(r.fallback, name)
+			}
+
+			r.known[name] = true
+		}
+	})
+
+	return err
+}
+
+// fallbacks returns, and forgets, the names of the IANA timezones used since
+// the last call.
+func (r *timezoneResolver) fallbacks() []string {
+	names := r.fallback
+	r.fallback = nil
+
+	return names
+}
+
+// timezoneError records the name of the timezone responsible for an error
+// found while resolving times.
+type timezoneError struct {
+	name string
+	err  error
+}
+
+func (t *timezoneError) Error() string {
+	return t.err.Error()
+}
+
+func (t *timezoneError) Unwrap() error {
+	return t.err
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// walkTimes calls the given func with a pointer to every time.Time reachable
+// from the given value.
+func walkTimes(v reflect.Value, fn func(*time.Time)) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			walkTimes(v.Elem(), fn)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			walkTimes(v.Index(i), fn)
+		}
+	case reflect.Struct:
+		if v.Type() == timeType {
+			if v.CanAddr() {
+				fn(v.Addr().Interface().(*time.Time))
+			}
+
+			return
+		}
+
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				walkTimes(v.Field(i), fn)
+			}
+		}
+	}
+}
+
+// Errors.
+var (
+	ErrInvalidTimezone = errors.New("invalid timezone")
+	ErrUnknownTimezone = errors.New("unknown timezone")
+)
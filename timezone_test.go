@@ -0,0 +1,279 @@
+package ics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeTimezone(t *testing.T) {
+	const vtimezone = "BEGIN:VTIMEZONE\r\n" +
+		"TZID:Pacific Standard Time\r\n" +
+		"BEGIN:STANDARD\r\n" +
+		"DTSTART:16010101T020000\r\n" +
+		"TZOFFSETFROM:-0700\r\n" +
+		"TZOFFSETTO:-0800\r\n" +
+		"RRULE:FREQ=YEARLY;BYDAY=1SU;BYMONTH=11\r\n" +
+		"END:STANDARD\r\n" +
+		"BEGIN:DAYLIGHT\r\n" +
+		"DTSTART:16010101T020000\r\n" +
+		"TZOFFSETFROM:-0800\r\n" +
+		"TZOFFSETTO:-0700\r\n" +
+		"RRULE:FREQ=YEARLY;BYDAY=2SU;BYMONTH=3\r\n" +
+		"END:DAYLIGHT\r\n" +
+		"END:VTIMEZONE\r\n" +
+		"BEGIN:VTIMEZONE\r\n" +
+		"TZID:/mozilla.org/20050126_1/Europe/Berlin\r\n" +
+		"BEGIN:STANDARD\r\n" +
+		"DTSTART:19701025T030000\r\n" +
+		"TZOFFSETFROM:+0200\r\n" +
+		"TZOFFSETTO:+0100\r\n" +
+		"TZNAME:CET\r\n" +
+		"RRULE:FREQ=YEARLY;BYDAY=-1SU;BYMONTH=10\r\n" +
+		"END:STANDARD\r\n" +
+		"BEGIN:DAYLIGHT\r\n" +
+		"DTSTART:19700329T020000\r\n" +
+		"TZOFFSETFROM:+0100\r\n" +
+		"TZOFFSETTO:+0200\r\n" +
+		"TZNAME:CEST\r\n" +
+		"RRULE:FREQ=YEARLY;BYDAY=-1SU;BYMONTH=3\r\n" +
+		"END:DAYLIGHT\r\n" +
+		"END:VTIMEZONE\r\n"
+
+	for n, test := range [...]struct {
+		TZID, Input string
+		Output      time.Time
+		Error       error
+	}{
+		{ // 1
+			TZID:   "Pacific Standard Time",
+			Input:  "20200115T090000",
+			Output: time.Date(2020, 1, 15, 17, 0, 0, 0, time.UTC),
+		},
+		{ // 2
+			TZID:   "Pacific Standard Time",
+			Input:  "20200715T090000",
+			Output: time.Date(2020, 7, 15, 16, 0, 0, 0, time.UTC),
+		},
+		{ // 3
+			TZID:   "Pacific Standard Time",
+			Input:  "20200308T030000",
+			Output: time.Date(2020, 3, 8, 10, 0, 0, 0, time.UTC),
+		},
+		{ // 4
+			TZID:   "/mozilla.org/20050126_1/Europe/Berlin",
+			Input:  "20201025T010000",
+			Output: time.Date(2020, 10, 24, 23, 0, 0, 0, time.UTC),
+		},
+		{ // 5
+			TZID:   "/mozilla.org/20050126_1/Europe/Berlin",
+			Input:  "20201026T010000",
+			Output: time.Date(2020, 10, 26, 0, 0, 0, 0, time.UTC),
+		},
+		{ // 6
+			TZID:   "America/New_York",
+			Input:  "20200115T090000",
+			Output: time.Date(2020, 1, 15, 14, 0, 0, 0, time.UTC),
+		},
+		{ // 7
+			TZID:  "Unknown Standard Time",
+			Input: "20200115T090000",
+			Error: ErrUnknownTimezone,
+		},
+	} {
+		cal, err := Decode(strings.NewReader("BEGIN:VCALENDAR\r\n" +
+			"VERSION:2.0\r\n" +
+			"PRODID:TEST\r\n" +
+			vtimezone +
+			"BEGIN:VEVENT\r\n" +
+			"UID:event@example.com\r\n" +
+			"DTSTAMP:20200101T000000Z\r\n" +
+			"DTSTART;TZID=" + test.TZID + ":" + test.Input + "\r\n" +
+			"END:VEVENT\r\n" +
+			"END:VCALENDAR\r\n"))
+		if !errors.Is(err, test.Error) {
+			t.Errorf("test %d: expecting error %v, got %v", n+1, test.Error, err)
+		} else if err == nil {
+			start := cal.Event[0].DateTimeStart.DateTime.Time
+
+			if !start.Equal(test.Output) {
+				t.Errorf("test %d: expecting time %s, got %s", n+1, test.Output, start.UTC())
+			} else if tz := start.Location().String(); tz != test.TZID {
+				t.Errorf("test %d: expecting TZID %q, got %q", n+1, test.TZID, tz)
+			}
+		}
+	}
+}
+
+func TestTimezoneLocation(t *testing.T) {
+	tzny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("unexpected error loading timezone: %s", err)
+	}
+
+	cal, err := Decode(strings.NewReader("BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:TEST\r\n" +
+		"BEGIN:VTIMEZONE\r\n" +
+		"TZID:America/New_York\r\n" +
+		"BEGIN:DAYLIGHT\r\n" +
+		"DTSTART:19870405T020000\r\n" +
+		"TZOFFSETFROM:-0500\r\n" +
+		"TZOFFSETTO:-0400\r\n" +
+		"TZNAME:EDT\r\n" +
+		"RRULE:FREQ=YEARLY;BYMONTH=4;BYDAY=1SU;UNTIL=20060402T070000Z\r\n" +
+		"END:DAYLIGHT\r\n" +
+		"BEGIN:STANDARD\r\n" +
+		"DTSTART:19671029T020000\r\n" +
+		"TZOFFSETFROM:-0400\r\n" +
+		"TZOFFSETTO:-0500\r\n" +
+		"TZNAME:EST\r\n" +
+		"RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU;UNTIL=20061029T060000Z\r\n" +
+		"END:STANDARD\r\n" +
+		"BEGIN:DAYLIGHT\r\n" +
+		"DTSTART:20070311T020000\r\n" +
+		"TZOFFSETFROM:-0500\r\n" +
+		"TZOFFSETTO:-0400\r\n" +
+		"TZNAME:EDT\r\n" +
+		"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU\r\n" +
+		"END:DAYLIGHT\r\n" +
+		"BEGIN:STANDARD\r\n" +
+		"DTSTART:20071104T020000\r\n" +
+		"TZOFFSETFROM:-0400\r\n" +
+		"TZOFFSETTO:-0500\r\n" +
+		"TZNAME:EST\r\n" +
+		"RRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU\r\n" +
+		"END:STANDARD\r\n" +
+		"END:VTIMEZONE\r\n" +
+		"END:VCALENDAR\r\n"))
+	if err != nil {
+		t.Fatalf("unexpected error decoding calendar: %s", err)
+	}
+
+	l, err := cal.Timezone[0].Location()
+	if err != nil {
+		t.Fatalf("unexpected error creating location: %s", err)
+	}
+
+	for n, test := range [...]time.Time{
+		time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1990, 4, 1, 6, 59, 59, 0, time.UTC),
+		time.Date(1990, 4, 1, 7, 0, 0, 0, time.UTC),
+		time.Date(1990, 10, 28, 5, 59, 59, 0, time.UTC),
+		time.Date(1990, 10, 28, 6, 0, 0, 0, time.UTC),
+		time.Date(2006, 4, 2, 7, 0, 0, 0, time.UTC),
+		time.Date(2006, 10, 29, 6, 0, 0, 0, time.UTC),
+		time.Date(2007, 3, 11, 6, 59, 59, 0, time.UTC),
+		time.Date(2007, 3, 11, 7, 0, 0, 0, time.UTC),
+		time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2099, 11, 1, 6, 0, 0, 0, time.UTC),
+		time.Date(2150, 3, 8, 7, 0, 0, 0, time.UTC),
+		time.Date(2150, 11, 1, 5, 59, 59, 0, time.UTC),
+		time.Date(2150, 11, 1, 6, 0, 0, 0, time.UTC),
+	} {
+		name, offset := test.In(l).Zone()
+		expectedName, expectedOffset := test.In(tzny).Zone()
+
+		if name != expectedName {
+			t.Errorf("test %d: expecting zone name %q, got %q", n+1, expectedName, name)
+		} else if offset != expectedOffset {
+			t.Errorf("test %d: expecting offset %d, got %d", n+1, expectedOffset, offset)
+		}
+	}
+
+	transitions := cal.Timezone[0].Transitions(time.Date(2006, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC))
+	expected := []TimezoneTransition{
+		{Time: time.Date(2006, 4, 2, 7, 0, 0, 0, time.UTC), OffsetFrom: -5 * 3600, OffsetTo: -4 * 3600, Name: "EDT", DST: true},
+		{Time: time.Date(2006, 10, 29, 6, 0, 0, 0, time.UTC), OffsetFrom: -4 * 3600, OffsetTo: -5 * 3600, Name: "EST"},
+		{Time: time.Date(2007, 3, 11, 7, 0, 0, 0, time.UTC), OffsetFrom: -5 * 3600, OffsetTo: -4 * 3600, Name: "EDT", DST: true},
+		{Time: time.Date(2007, 11, 4, 6, 0, 0, 0, time.UTC), OffsetFrom: -4 * 3600, OffsetTo: -5 * 3600, Name: "EST"},
+	}
+
+	if len(transitions) != len(expected) {
+		t.Fatalf("expecting %d transitions, got %d: %v", len(expected), len(transitions), transitions)
+	}
+
+	for n, tr := range transitions {
+		if !tr.Time.Equal(expected[n].Time) || tr.OffsetFrom != expected[n].OffsetFrom || tr.OffsetTo != expected[n].OffsetTo || tr.Name != expected[n].Name || tr.DST != expected[n].DST {
+			t.Errorf("test %d: expecting transition %v, got %v", n+1, expected[n], tr)
+		}
+	}
+
+	if rule := cal.Timezone[0].posixRule(); rule != "EST5EDT4,M3.2.0/2,M11.1.0/2" {
+		t.Errorf("expecting POSIX rule %q, got %q", "EST5EDT4,M3.2.0/2,M11.1.0/2", rule)
+	}
+}
+
+func TestNewTimezone(t *testing.T) {
+	for n, test := range [...]struct {
+		Location           string
+		Start, End         time.Time
+		Standard, Daylight int
+		OpenEnded          bool
+	}{
+		{ // 1
+			Location:  "Europe/London",
+			Start:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:       time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+			Standard:  1,
+			Daylight:  1,
+			OpenEnded: true,
+		},
+		{ // 2
+			Location:  "America/New_York",
+			Start:     time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:       time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC),
+			Standard:  2,
+			Daylight:  2,
+			OpenEnded: true,
+		},
+		{ // 3
+			Location: "Asia/Tokyo",
+			Start:    time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:      time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC),
+			Standard: 1,
+		},
+		{ // 4
+			Location: "America/Sao_Paulo",
+			Start:    time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:      time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			Standard: 2,
+			Daylight: 2,
+		},
+	} {
+		l, err := time.LoadLocation(test.Location)
+		if err != nil {
+			t.Errorf("test %d: unexpected error loading timezone: %s", n+1, err)
+
+			continue
+		}
+
+		tz := NewTimezone(l, test.Start, test.End)
+
+		if string(tz.TimezoneID) != test.Location {
+			t.Errorf("test %d: expecting TZID %q, got %q", n+1, test.Location, tz.TimezoneID)
+		} else if len(tz.Standard) != test.Standard {
+			t.Errorf("test %d: expecting %d Standard components, got %d", n+1, test.Standard, len(tz.Standard))
+		} else if len(tz.Daylight) != test.Daylight {
+			t.Errorf("test %d: expecting %d Daylight components, got %d", n+1, test.Daylight, len(tz.Daylight))
+		} else if openEnded := tz.posixRule() != ""; openEnded != test.OpenEnded {
+			t.Errorf("test %d: expecting open-ended rules to be %v", n+1, test.OpenEnded)
+		} else if err := tz.valid(); err != nil {
+			t.Errorf("test %d: unexpected error validating timezone: %s", n+1, err)
+		} else if tl, err := tz.Location(); err != nil {
+			t.Errorf("test %d: unexpected error creating location: %s", n+1, err)
+		} else {
+			for tm := test.Start; tm.Before(test.End); tm = tm.Add(time.Hour) {
+				name, offset := tm.In(tl).Zone()
+				expectedName, expectedOffset := tm.In(l).Zone()
+
+				if name != expectedName || offset != expectedOffset {
+					t.Errorf("test %d: at %s expecting zone %s (%d), got %s (%d)", n+1, tm, expectedName, expectedOffset, name, offset)
+
+					break
+				}
+			}
+		}
+	}
+}
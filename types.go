@@ -157,10 +157,7 @@ type DateTime struct {
 
 func (d *DateTime) decode(params map[string]string, data string) error {
 	if tz, ok := params["TZID"]; ok {
-		l, err := time.LoadLocation(tz)
-		if err != nil {
-			return fmt.Errorf("error loading timezone for DateTime: %w", err)
-		}
+		l := loadLocation(tz)
 
 		t, err := time.ParseInLocation(dateTimeFormat[:15], data, l)
 		if err != nil {
@@ -561,6 +558,56 @@ func (p Period) valid() error {
 	return nil
 }
 
+// MPeriod contains multiple Period values.
+type MPeriod []Period
+
+func (m *MPeriod) decode(params map[string]string, data string) error {
+	for _, d := range strings.Split(data, ",") {
+		var p Period
+
+		if err := p.decode(params, d); err != nil {
+			return err
+		}
+
+		*m = append(*m, p)
+	}
+
+	return nil
+}
+
+func (m MPeriod) aencode(w writer) {
+	if len(m) > 0 {
+		writeTimezone(w, m[0].Start.Time)
+	}
+
+	w.WriteString(":")
+	m.encode(w)
+}
+
+func (m MPeriod) encode(w writer) {
+	for n, p := range m {
+		if n > 0 {
+			w.WriteString(",")
+		}
+
+		p.encode(w)
+	}
+}
+
+func (m MPeriod) valid() error {
+	if len(m) == 0 {
+		return ErrInvalidPeriod
+	}
+
+	for _, p := range m {
+		if err := p.valid(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Frequency represents the Recurrence frequency.
 type Frequency uint8
 
@@ -1377,10 +1424,7 @@ type Time struct {
 
 func (t *Time) decode(params map[string]string, data string) error {
 	if tz, ok := params["TZID"]; ok {
-		l, err := time.LoadLocation(tz)
-		if err != nil {
-			return fmt.Errorf("error loading timezone for Time: %w", err)
-		}
+		l := loadLocation(tz)
 
 		ct, err := time.ParseInLocation(dateTimeFormat[9:15], data, l)
 		if err != nil {
@@ -1436,6 +1480,21 @@ func (t Time) valid() error {
 	return nil
 }
 
+// UID is a unique identifier of a component.
+type UID Text
+
+func (u *UID) decode(params map[string]string, data string) error {
+	return (*Text)(u).decode(params, data)
+}
+
+func (u *UID) aencode(w writer) {
+	(*Text)(u).aencode(w)
+}
+
+func (u UID) valid() error {
+	return Text(u).valid()
+}
+
 // URI contains a reference to another piece of data.
 type URI struct {
 	url.URL
@@ -1565,6 +1624,17 @@ func (UTCOffset) valid() error {
 	return nil
 }
 
+// loadLocation loads the named IANA timezone, returning a placeholder location
+// when the name is unknown so that it can be resolved against the Timezone
+// components of a Calendar once decoding is complete.
+func loadLocation(tz string) *time.Location {
+	if l, err := time.LoadLocation(tz); err == nil {
+		return l
+	}
+
+	return time.FixedZone(tz, 0)
+}
+
 func writeTimezone(w writer, t time.Time) {
 	switch l := t.Location(); l {
 	case time.UTC, time.Local:
@@ -1574,6 +1644,12 @@ func writeTimezone(w writer, t time.Time) {
 	}
 }
 
+// XMLReference is a URI to an XML document, with a fragment identifying the
+// referenced part of the document.
+type XMLReference struct {
+	URI
+}
+
 // Errors.
 var (
 	ErrInvalidEncoding        = errors.New("invalid Binary encoding")
@@ -1607,8 +1683,10 @@ const (
 	cCalendarAddress = "CalendarAddress"
 	cDate            = "Date"
 	cDateTime        = "DateTime"
+	cMPeriod         = "MPeriod"
 	cMText           = "MText"
 	cPeriod          = "Period"
 	cText            = "Text"
+	cXMLReference    = "XMLReference"
 	cAlarm           = "Alarm"
 )
@@ -240,6 +240,37 @@ func TestPeriod(t *testing.T) {
 	})
 }
 
+func TestMPeriod(t *testing.T) {
+	testType(t, []typeTest{
+		{
+			Data:  "19970101T180000Z/19970102T070000Z",
+			Input: &MPeriod{},
+			Match: &MPeriod{
+				{
+					Start: DateTime{Time: time.Date(1997, 1, 1, 18, 0, 0, 0, time.UTC)},
+					End:   DateTime{Time: time.Date(1997, 1, 2, 7, 0, 0, 0, time.UTC)},
+				},
+			},
+			Output: "19970101T180000Z/19970102T070000Z",
+		},
+		{
+			Data:  "19970308T160000Z/PT8H30M,19970308T230000Z/19970309T000000Z",
+			Input: &MPeriod{},
+			Match: &MPeriod{
+				{
+					Start:    DateTime{Time: time.Date(1997, 3, 8, 16, 0, 0, 0, time.UTC)},
+					Duration: Duration{Hours: 8, Minutes: 30},
+				},
+				{
+					Start: DateTime{Time: time.Date(1997, 3, 8, 23, 0, 0, 0, time.UTC)},
+					End:   DateTime{Time: time.Date(1997, 3, 9, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			Output: "19970308T160000Z/PT8H30M,19970308T230000Z/19970309T000000Z",
+		},
+	})
+}
+
 func TestRecur(t *testing.T) {
 	testType(t, []typeTest{
 		{ // 1
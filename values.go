@@ -0,0 +1,45 @@
+package ics
+
+// RFC 7986 display values, for use in a ParamDisplay. Other values, such as
+// x-names, are retained as they appear.
+const (
+	DisplayBadge     = "BADGE"
+	DisplayGraphic   = "GRAPHIC"
+	DisplayFullsize  = "FULLSIZE"
+	DisplayThumbnail = "THUMBNAIL"
+)
+
+// RFC 7986 feature values, for use in a ParamFeature. Other values, such as
+// x-names, are retained as they appear.
+const (
+	FeatureAudio     = "AUDIO"
+	FeatureChat      = "CHAT"
+	FeatureFeed      = "FEED"
+	FeatureModerator = "MODERATOR"
+	FeaturePhone     = "PHONE"
+	FeatureScreen    = "SCREEN"
+	FeatureVideo     = "VIDEO"
+)
+
+// RFC 5545 and RFC 9253 relationship types. Other values, such as x-names, are
+// retained as they appear.
+const (
+	RelationshipTypeParent         ParamRelationshipType = "PARENT"
+	RelationshipTypeChild          ParamRelationshipType = "CHILD"
+	RelationshipTypeSibling        ParamRelationshipType = "SIBLING"
+	RelationshipTypeFinishToStart  ParamRelationshipType = "FINISHTOSTART"
+	RelationshipTypeFinishToFinish ParamRelationshipType = "FINISHTOFINISH"
+	RelationshipTypeStartToFinish  ParamRelationshipType = "STARTTOFINISH"
+	RelationshipTypeStartToStart   ParamRelationshipType = "STARTTOSTART"
+	RelationshipTypeFirst          ParamRelationshipType = "FIRST"
+	RelationshipTypeNext           ParamRelationshipType = "NEXT"
+	RelationshipTypeDependsOn      ParamRelationshipType = "DEPENDS-ON"
+	RelationshipTypeRefID          ParamRelationshipType = "REFID"
+	RelationshipTypeConcept        ParamRelationshipType = "CONCEPT"
+)
+
+// New returns a pointer to the type (used with constants for ease of use with
+// optional values).
+func (t ParamRelationshipType) New() *ParamRelationshipType {
+	return &t
+}
@@ -0,0 +1,119 @@
+package ics
+
+import (
+	"errors"
+	"strings"
+
+	"vimagination.zapto.org/parser"
+)
+
+func lineTokeniserOf(t tokeniser) *lineTokeniser {
+	switch t := t.(type) {
+	case *lineTokeniser:
+		return t
+	case *replayTokeniser:
+		return t.l
+	}
+
+	return nil
+}
+
+// warning records the error as a Warning at the current position.
+func warning(t tokeniser, err error) {
+	if l := lineTokeniserOf(t); l != nil {
+		l.warn(err)
+	}
+}
+
+// recoverable returns the given error, unless decoding leniently, in which
+// case the error is recorded as a Warning and nil is returned.
+func recoverable(t tokeniser, err error) error {
+	l := lineTokeniserOf(t)
+	if l == nil || !l.lenient {
+		return err
+	}
+
+	l.warn(err)
+
+	return nil
+}
+
+// endValue checks the value of an END property, which, when decoding
+// leniently, may differ in case from the name of the section.
+func endValue(t tokeniser, value, name string) error {
+	if value == name {
+		return nil
+	} else if !strings.EqualFold(value, name) {
+		return ErrInvalidEnd
+	}
+
+	return recoverable(t, ErrInvalidEnd)
+}
+
+// checkProperty records warnings for problems with a property that do not
+// prevent it from being decoded.
+func checkProperty(t tokeniser, name string, params []parser.Token, value string) {
+	name = strings.ToUpper(name)
+
+	switch name {
+	case "EXRULE":
+		warning(t, ErrDeprecated)
+	case "ACTION":
+		if strings.EqualFold(value, "PROCEDURE") {
+			warning(t, ErrDeprecated)
+		}
+	}
+
+	var seen [3]bool
+
+	eachParam(params, func(pName string, vs []parser.Token) {
+		var n int
+
+		switch pName {
+		case "VALUE":
+			if len(vs) != 1 {
+				break
+			} else if vt := strings.ToUpper(vs[0].Data); !knownValueType(vt) {
+				warning(t, ErrUnknownValueType)
+			} else if expected := valueType(name); expected != "" && vt != expected {
+				warning(t, ErrUnexpectedValueType)
+			}
+		case "TZID":
+			n = 1
+		case "ENCODING":
+			n = 2
+		case "RANGE":
+			if len(vs) == 1 && strings.EqualFold(vs[0].Data, "THISANDPRIOR") {
+				warning(t, ErrDeprecated)
+			}
+
+			return
+		default:
+			return
+		}
+
+		if seen[n] {
+			warning(t, ErrIgnoredParam)
+		}
+
+		seen[n] = true
+	})
+}
+
+func knownValueType(vt string) bool {
+	switch vt {
+	case "BINARY", "BOOLEAN", "CAL-ADDRESS", "DATE", "DATE-TIME", "DURATION", "FLOAT", "INTEGER", "PERIOD", "RECUR", "TEXT", "TIME", "UID", "URI", "UTC-OFFSET", "XML-REFERENCE":
+		return true
+	}
+
+	return strings.HasPrefix(vt, "X-")
+}
+
+// Errors.
+var (
+	ErrDeprecated          = errors.New("deprecated")
+	ErrIgnoredParam        = errors.New("duplicate param ignored")
+	ErrUnknownValueType    = errors.New("unknown value type")
+	ErrUnexpectedValueType = errors.New("unexpected value type")
+	ErrTimezoneFallback    = errors.New("timezone not defined in calendar")
+)